@@ -18,6 +18,7 @@ package engine
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -27,11 +28,15 @@ type EngineAPIError struct {
 	code int
 	msg  string
 	err  error
+	data interface{} // the structured error data, overrides the err in the response
 }
 
 func (e *EngineAPIError) ErrorCode() int { return e.code }
 func (e *EngineAPIError) Error() string  { return e.msg }
 func (e *EngineAPIError) ErrorData() interface{} {
+	if e.data != nil {
+		return e.data
+	}
 	if e.err == nil {
 		return nil
 	}
@@ -49,6 +54,25 @@ func (e *EngineAPIError) With(err error) *EngineAPIError {
 	}
 }
 
+// WithData returns a copy of the error with the error and its structured data.
+func (e *EngineAPIError) WithData(err error, data interface{}) *EngineAPIError {
+	return &EngineAPIError{
+		code: e.code,
+		msg:  e.msg,
+		err:  err,
+		data: data,
+	}
+}
+
+// GoatTxErrorData is the structured error data of the goat tx failures
+type GoatTxErrorData struct {
+	Error        string         `json:"err"`
+	Index        hexutil.Uint64 `json:"index"`
+	Hash         common.Hash    `json:"hash"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	RevertData   hexutil.Bytes  `json:"revertData,omitempty"`
+}
+
 var (
 	_ rpc.Error     = new(EngineAPIError)
 	_ rpc.DataError = new(EngineAPIError)
@@ -82,6 +106,12 @@ var (
 	InvalidParams            = &EngineAPIError{code: -32602, msg: "Invalid parameters"}
 	UnsupportedFork          = &EngineAPIError{code: -38005, msg: "Unsupported fork"}
 
+	// The goat tx failures in the payload building, the error data is GoatTxErrorData
+	GoatTxReverted           = &EngineAPIError{code: -38100, msg: "Goat tx reverted"}
+	InvalidGoatDepositReturn = &EngineAPIError{code: -38101, msg: "Invalid goat deposit return"}
+	GoatDepositTaxTooLarge   = &EngineAPIError{code: -38102, msg: "Goat deposit tax too large"}
+	InvalidGoatMintAmount    = &EngineAPIError{code: -38103, msg: "Invalid goat mint amount"}
+
 	STATUS_INVALID         = ForkChoiceResponse{PayloadStatus: PayloadStatusV1{Status: INVALID}, PayloadID: nil}
 	STATUS_SYNCING         = ForkChoiceResponse{PayloadStatus: PayloadStatusV1{Status: SYNCING}, PayloadID: nil}
 	INVALID_TERMINAL_BLOCK = PayloadStatusV1{Status: INVALID, LatestValidHash: &common.Hash{}}
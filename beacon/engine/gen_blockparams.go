@@ -22,6 +22,7 @@ func (p PayloadAttributes) MarshalJSON() ([]byte, error) {
 		Withdrawals           []*types.Withdrawal `json:"withdrawals"`
 		BeaconRoot            *common.Hash        `json:"parentBeaconBlockRoot"`
 		GoatTxs               []hexutil.Bytes     `json:"goatTxs,omitempty"  gencodec:"optional"`
+		ConsensusBlockHash    *common.Hash        `json:"consensusBlockHash,omitempty"  gencodec:"optional"`
 	}
 	var enc PayloadAttributes
 	enc.Timestamp = hexutil.Uint64(p.Timestamp)
@@ -30,6 +31,7 @@ func (p PayloadAttributes) MarshalJSON() ([]byte, error) {
 	enc.Withdrawals = p.Withdrawals
 	enc.BeaconRoot = p.BeaconRoot
 	enc.GoatTxs = p.GoatTxs
+	enc.ConsensusBlockHash = p.ConsensusBlockHash
 	return json.Marshal(&enc)
 }
 
@@ -42,6 +44,7 @@ func (p *PayloadAttributes) UnmarshalJSON(input []byte) error {
 		Withdrawals           []*types.Withdrawal `json:"withdrawals"`
 		BeaconRoot            *common.Hash        `json:"parentBeaconBlockRoot"`
 		GoatTxs               []hexutil.Bytes     `json:"goatTxs,omitempty"  gencodec:"optional"`
+		ConsensusBlockHash    *common.Hash        `json:"consensusBlockHash,omitempty"  gencodec:"optional"`
 	}
 	var dec PayloadAttributes
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -68,5 +71,8 @@ func (p *PayloadAttributes) UnmarshalJSON(input []byte) error {
 	if dec.GoatTxs != nil {
 		p.GoatTxs = dec.GoatTxs
 	}
+	if dec.ConsensusBlockHash != nil {
+		p.ConsensusBlockHash = dec.ConsensusBlockHash
+	}
 	return nil
 }
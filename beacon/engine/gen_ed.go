@@ -17,31 +17,39 @@ var _ = (*executableDataMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (e ExecutableData) MarshalJSON() ([]byte, error) {
 	type ExecutableData struct {
-		ParentHash        common.Hash             `json:"parentHash"    gencodec:"required"`
-		FeeRecipient      common.Address          `json:"feeRecipient"  gencodec:"required"`
-		StateRoot         common.Hash             `json:"stateRoot"     gencodec:"required"`
-		ReceiptsRoot      common.Hash             `json:"receiptsRoot"  gencodec:"required"`
-		LogsBloom         hexutil.Bytes           `json:"logsBloom"     gencodec:"required"`
-		Random            common.Hash             `json:"prevRandao"    gencodec:"required"`
-		Number            hexutil.Uint64          `json:"blockNumber"   gencodec:"required"`
-		GasLimit          hexutil.Uint64          `json:"gasLimit"      gencodec:"required"`
-		GasUsed           hexutil.Uint64          `json:"gasUsed"       gencodec:"required"`
-		Timestamp         hexutil.Uint64          `json:"timestamp"     gencodec:"required"`
-		ExtraData         hexutil.Bytes           `json:"extraData"     gencodec:"required"`
-		BaseFeePerGas     *hexutil.Big            `json:"baseFeePerGas" gencodec:"required"`
-		BlockHash         common.Hash             `json:"blockHash"     gencodec:"required"`
-		Transactions      []hexutil.Bytes         `json:"transactions"  gencodec:"required"`
-		Withdrawals       []*types.Withdrawal     `json:"withdrawals"`
-		BlobGasUsed       *hexutil.Uint64         `json:"blobGasUsed"`
-		ExcessBlobGas     *hexutil.Uint64         `json:"excessBlobGas"`
-		Deposits          types.Deposits          `json:"depositRequests"`
-		ExecutionWitness  *types.ExecutionWitness `json:"executionWitness,omitempty"`
-		GasRevenues       types.GasRevenues       `json:"gasRevenueRequests"`
-		AddVoters         types.AddVoters         `json:"addVoterRequests"`
-		RemoveVoters      types.RemoveVoters      `json:"removeVoterRequests"`
-		BridgeWithdrawals types.BridgeWithdrawals `json:"bridgeWithdrawalsRequests"`
-		ReplaceByFees     types.ReplaceByFees     `json:"rbfRequests"`
-		Cancel1s          types.Cancel1s          `json:"cancel1Requests"`
+		ParentHash            common.Hash                 `json:"parentHash"    gencodec:"required"`
+		FeeRecipient          common.Address              `json:"feeRecipient"  gencodec:"required"`
+		StateRoot             common.Hash                 `json:"stateRoot"     gencodec:"required"`
+		ReceiptsRoot          common.Hash                 `json:"receiptsRoot"  gencodec:"required"`
+		LogsBloom             hexutil.Bytes               `json:"logsBloom"     gencodec:"required"`
+		Random                common.Hash                 `json:"prevRandao"    gencodec:"required"`
+		Number                hexutil.Uint64              `json:"blockNumber"   gencodec:"required"`
+		GasLimit              hexutil.Uint64              `json:"gasLimit"      gencodec:"required"`
+		GasUsed               hexutil.Uint64              `json:"gasUsed"       gencodec:"required"`
+		Timestamp             hexutil.Uint64              `json:"timestamp"     gencodec:"required"`
+		ExtraData             hexutil.Bytes               `json:"extraData"     gencodec:"required"`
+		BaseFeePerGas         *hexutil.Big                `json:"baseFeePerGas" gencodec:"required"`
+		BlockHash             common.Hash                 `json:"blockHash"     gencodec:"required"`
+		Transactions          []hexutil.Bytes             `json:"transactions"  gencodec:"required"`
+		Withdrawals           []*types.Withdrawal         `json:"withdrawals"`
+		BlobGasUsed           *hexutil.Uint64             `json:"blobGasUsed"`
+		ExcessBlobGas         *hexutil.Uint64             `json:"excessBlobGas"`
+		Deposits              types.Deposits              `json:"depositRequests"`
+		ExecutionWitness      *types.ExecutionWitness     `json:"executionWitness,omitempty"`
+		GasRevenues           types.GasRevenues           `json:"gasRevenueRequests"`
+		AddVoters             types.AddVoters             `json:"addVoterRequests"`
+		RemoveVoters          types.RemoveVoters          `json:"removeVoterRequests"`
+		BridgeWithdrawals     types.BridgeWithdrawals     `json:"bridgeWithdrawalsRequests"`
+		ReplaceByFees         types.ReplaceByFees         `json:"rbfRequests"`
+		Cancel1s              types.Cancel1s              `json:"cancel1Requests"`
+		CreateValidators      types.CreateValidators      `json:"createValidatorRequests"`
+		Locks                 types.Locks                 `json:"lockRequests"`
+		Unlocks               types.Unlocks               `json:"unlockRequests"`
+		Claims                types.Claims                `json:"claimRequests"`
+		UpdateTokenWeights    types.UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+		UpdateTokenThresholds types.UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+		Grants                types.Grants                `json:"grantRequests"`
+		Requests              []hexutil.Bytes             `json:"requests,omitempty" gencodec:"optional"`
 	}
 	var enc ExecutableData
 	enc.ParentHash = e.ParentHash
@@ -74,37 +82,53 @@ func (e ExecutableData) MarshalJSON() ([]byte, error) {
 	enc.BridgeWithdrawals = e.BridgeWithdrawals
 	enc.ReplaceByFees = e.ReplaceByFees
 	enc.Cancel1s = e.Cancel1s
+	enc.CreateValidators = e.CreateValidators
+	enc.Locks = e.Locks
+	enc.Unlocks = e.Unlocks
+	enc.Claims = e.Claims
+	enc.UpdateTokenWeights = e.UpdateTokenWeights
+	enc.UpdateTokenThresholds = e.UpdateTokenThresholds
+	enc.Grants = e.Grants
+	enc.Requests = e.Requests
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (e *ExecutableData) UnmarshalJSON(input []byte) error {
 	type ExecutableData struct {
-		ParentHash        *common.Hash             `json:"parentHash"    gencodec:"required"`
-		FeeRecipient      *common.Address          `json:"feeRecipient"  gencodec:"required"`
-		StateRoot         *common.Hash             `json:"stateRoot"     gencodec:"required"`
-		ReceiptsRoot      *common.Hash             `json:"receiptsRoot"  gencodec:"required"`
-		LogsBloom         *hexutil.Bytes           `json:"logsBloom"     gencodec:"required"`
-		Random            *common.Hash             `json:"prevRandao"    gencodec:"required"`
-		Number            *hexutil.Uint64          `json:"blockNumber"   gencodec:"required"`
-		GasLimit          *hexutil.Uint64          `json:"gasLimit"      gencodec:"required"`
-		GasUsed           *hexutil.Uint64          `json:"gasUsed"       gencodec:"required"`
-		Timestamp         *hexutil.Uint64          `json:"timestamp"     gencodec:"required"`
-		ExtraData         *hexutil.Bytes           `json:"extraData"     gencodec:"required"`
-		BaseFeePerGas     *hexutil.Big             `json:"baseFeePerGas" gencodec:"required"`
-		BlockHash         *common.Hash             `json:"blockHash"     gencodec:"required"`
-		Transactions      []hexutil.Bytes          `json:"transactions"  gencodec:"required"`
-		Withdrawals       []*types.Withdrawal      `json:"withdrawals"`
-		BlobGasUsed       *hexutil.Uint64          `json:"blobGasUsed"`
-		ExcessBlobGas     *hexutil.Uint64          `json:"excessBlobGas"`
-		Deposits          *types.Deposits          `json:"depositRequests"`
-		ExecutionWitness  *types.ExecutionWitness  `json:"executionWitness,omitempty"`
-		GasRevenues       *types.GasRevenues       `json:"gasRevenueRequests"`
-		AddVoters         *types.AddVoters         `json:"addVoterRequests"`
-		RemoveVoters      *types.RemoveVoters      `json:"removeVoterRequests"`
-		BridgeWithdrawals *types.BridgeWithdrawals `json:"bridgeWithdrawalsRequests"`
-		ReplaceByFees     *types.ReplaceByFees     `json:"rbfRequests"`
-		Cancel1s          *types.Cancel1s          `json:"cancel1Requests"`
+		ParentHash            *common.Hash                 `json:"parentHash"    gencodec:"required"`
+		FeeRecipient          *common.Address              `json:"feeRecipient"  gencodec:"required"`
+		StateRoot             *common.Hash                 `json:"stateRoot"     gencodec:"required"`
+		ReceiptsRoot          *common.Hash                 `json:"receiptsRoot"  gencodec:"required"`
+		LogsBloom             *hexutil.Bytes               `json:"logsBloom"     gencodec:"required"`
+		Random                *common.Hash                 `json:"prevRandao"    gencodec:"required"`
+		Number                *hexutil.Uint64              `json:"blockNumber"   gencodec:"required"`
+		GasLimit              *hexutil.Uint64              `json:"gasLimit"      gencodec:"required"`
+		GasUsed               *hexutil.Uint64              `json:"gasUsed"       gencodec:"required"`
+		Timestamp             *hexutil.Uint64              `json:"timestamp"     gencodec:"required"`
+		ExtraData             *hexutil.Bytes               `json:"extraData"     gencodec:"required"`
+		BaseFeePerGas         *hexutil.Big                 `json:"baseFeePerGas" gencodec:"required"`
+		BlockHash             *common.Hash                 `json:"blockHash"     gencodec:"required"`
+		Transactions          []hexutil.Bytes              `json:"transactions"  gencodec:"required"`
+		Withdrawals           []*types.Withdrawal          `json:"withdrawals"`
+		BlobGasUsed           *hexutil.Uint64              `json:"blobGasUsed"`
+		ExcessBlobGas         *hexutil.Uint64              `json:"excessBlobGas"`
+		Deposits              *types.Deposits              `json:"depositRequests"`
+		ExecutionWitness      *types.ExecutionWitness      `json:"executionWitness,omitempty"`
+		GasRevenues           *types.GasRevenues           `json:"gasRevenueRequests"`
+		AddVoters             *types.AddVoters             `json:"addVoterRequests"`
+		RemoveVoters          *types.RemoveVoters          `json:"removeVoterRequests"`
+		BridgeWithdrawals     *types.BridgeWithdrawals     `json:"bridgeWithdrawalsRequests"`
+		ReplaceByFees         *types.ReplaceByFees         `json:"rbfRequests"`
+		Cancel1s              *types.Cancel1s              `json:"cancel1Requests"`
+		CreateValidators      *types.CreateValidators      `json:"createValidatorRequests"`
+		Locks                 *types.Locks                 `json:"lockRequests"`
+		Unlocks               *types.Unlocks               `json:"unlockRequests"`
+		Claims                *types.Claims                `json:"claimRequests"`
+		UpdateTokenWeights    *types.UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+		UpdateTokenThresholds *types.UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+		Grants                *types.Grants                `json:"grantRequests"`
+		Requests              []hexutil.Bytes              `json:"requests,omitempty" gencodec:"optional"`
 	}
 	var dec ExecutableData
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -202,5 +226,29 @@ func (e *ExecutableData) UnmarshalJSON(input []byte) error {
 	if dec.Cancel1s != nil {
 		e.Cancel1s = *dec.Cancel1s
 	}
+	if dec.CreateValidators != nil {
+		e.CreateValidators = *dec.CreateValidators
+	}
+	if dec.Locks != nil {
+		e.Locks = *dec.Locks
+	}
+	if dec.Unlocks != nil {
+		e.Unlocks = *dec.Unlocks
+	}
+	if dec.Claims != nil {
+		e.Claims = *dec.Claims
+	}
+	if dec.UpdateTokenWeights != nil {
+		e.UpdateTokenWeights = *dec.UpdateTokenWeights
+	}
+	if dec.UpdateTokenThresholds != nil {
+		e.UpdateTokenThresholds = *dec.UpdateTokenThresholds
+	}
+	if dec.Grants != nil {
+		e.Grants = *dec.Grants
+	}
+	if dec.Requests != nil {
+		e.Requests = dec.Requests
+	}
 	return nil
 }
@@ -0,0 +1,370 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// goatRequestList is the typed request list of a goat request type in the engine payloads
+type goatRequestList interface {
+	init()                             // sets the list to an empty list
+	clear()                            // sets the list to nil
+	isSet() bool                       // reports whether the list is not nil
+	add(data types.RequestData) bool   // appends the request, false if the type mismatches
+	requests() (types.Requests, error) // returns the requests of the list
+}
+
+// typedList is the request list field of the engine payload for the request type T
+type typedList[S ~[]T, T interface {
+	comparable
+	types.RequestData
+}] struct {
+	list *S
+}
+
+func newTypedList[S ~[]T, T interface {
+	comparable
+	types.RequestData
+}](list *S) goatRequestList {
+	return typedList[S, T]{list}
+}
+
+func (l typedList[S, T]) init()       { *l.list = make(S, 0) }
+func (l typedList[S, T]) clear()      { *l.list = nil }
+func (l typedList[S, T]) isSet() bool { return *l.list != nil }
+
+func (l typedList[S, T]) add(data types.RequestData) bool {
+	v, ok := data.(T)
+	if ok {
+		*l.list = append(*l.list, v)
+	}
+	return ok
+}
+
+func (l typedList[S, T]) requests() (types.Requests, error) {
+	var zero T
+	requests := make(types.Requests, 0, len(*l.list))
+	for i, v := range *l.list {
+		if v == zero {
+			return nil, fmt.Errorf("invalid request %d", i)
+		}
+		requests = append(requests, types.NewRequest(v))
+	}
+	return requests, nil
+}
+
+// goatRequestLists returns the goat request lists of the payload by request type.
+//
+// The request lists are the fields of the payloads, which gencodec requires to be
+// declared in the payload types, so the mapping is kept here. It's checked against
+// the registered request types and their JSON keys at the startup.
+func (data *ExecutableData) goatRequestLists() map[byte]goatRequestList {
+	return map[byte]goatRequestList{
+		types.GoatGasRevenueRequestType:           newTypedList(&data.GasRevenues),
+		types.GoatAddVoterRequestType:             newTypedList(&data.AddVoters),
+		types.GoatRemoveVoterRequestType:          newTypedList(&data.RemoveVoters),
+		types.GoatWithdrawalRequestType:           newTypedList(&data.BridgeWithdrawals),
+		types.GoatReplaceByFeeRequestType:         newTypedList(&data.ReplaceByFees),
+		types.GoatCancel1RequestType:              newTypedList(&data.Cancel1s),
+		types.GoatCreateValidatorRequestType:      newTypedList(&data.CreateValidators),
+		types.GoatLockRequestType:                 newTypedList(&data.Locks),
+		types.GoatUnlockRequestType:               newTypedList(&data.Unlocks),
+		types.GoatClaimRequestType:                newTypedList(&data.Claims),
+		types.GoatUpdateTokenWeightRequestType:    newTypedList(&data.UpdateTokenWeights),
+		types.GoatUpdateTokenThresholdRequestType: newTypedList(&data.UpdateTokenThresholds),
+		types.GoatGrantRequestType:                newTypedList(&data.Grants),
+	}
+}
+
+// goatRequestLists returns the goat request lists of the payload body by request type
+func (body *ExecutionPayloadBody) goatRequestLists() map[byte]goatRequestList {
+	return map[byte]goatRequestList{
+		types.GoatGasRevenueRequestType:           newTypedList(&body.GasRevenues),
+		types.GoatAddVoterRequestType:             newTypedList(&body.AddVoters),
+		types.GoatRemoveVoterRequestType:          newTypedList(&body.RemoveVoters),
+		types.GoatWithdrawalRequestType:           newTypedList(&body.BridgeWithdrawals),
+		types.GoatReplaceByFeeRequestType:         newTypedList(&body.ReplaceByFees),
+		types.GoatCancel1RequestType:              newTypedList(&body.Cancel1s),
+		types.GoatCreateValidatorRequestType:      newTypedList(&body.CreateValidators),
+		types.GoatLockRequestType:                 newTypedList(&body.Locks),
+		types.GoatUnlockRequestType:               newTypedList(&body.Unlocks),
+		types.GoatClaimRequestType:                newTypedList(&body.Claims),
+		types.GoatUpdateTokenWeightRequestType:    newTypedList(&body.UpdateTokenWeights),
+		types.GoatUpdateTokenThresholdRequestType: newTypedList(&body.UpdateTokenThresholds),
+		types.GoatGrantRequestType:                newTypedList(&body.Grants),
+	}
+}
+
+// checkGoatRequestLists checks every registered goat request type has its request
+// list in the engine payloads, which is encoded under the registered JSON key. The
+// requests of an unmapped type can't be delivered to the consensus layer by the
+// engine methods before V5.
+func checkGoatRequestLists() error {
+	payloads := []struct {
+		name string
+		new  func() (any, map[byte]goatRequestList)
+	}{
+		{"ExecutableData", func() (any, map[byte]goatRequestList) {
+			data := new(ExecutableData)
+			return data, data.goatRequestLists()
+		}},
+		{"ExecutionPayloadBody", func() (any, map[byte]goatRequestList) {
+			body := new(ExecutionPayloadBody)
+			return body, body.goatRequestLists()
+		}},
+	}
+	for _, kind := range types.GoatRequestKinds() {
+		for _, p := range payloads {
+			payload, lists := p.new()
+			list, ok := lists[kind.Type]
+			if !ok {
+				return fmt.Errorf("goat request type %#x (%s) has no request list in %s", kind.Type, kind.Name, p.name)
+			}
+			list.init()
+			enc, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+			if !bytes.Contains(enc, []byte(`"`+kind.Key+`":[]`)) {
+				return fmt.Errorf("goat request type %#x (%s) is not encoded under %q in %s", kind.Type, kind.Name, kind.Key, p.name)
+			}
+			if !list.add(kind.New()) {
+				return fmt.Errorf("goat request type %#x (%s) mismatches its request list in %s", kind.Type, kind.Name, p.name)
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	if err := checkGoatRequestLists(); err != nil {
+		panic(err)
+	}
+}
+
+// getGoatRequests appends the goat requests from the request lists of the engine
+// payload in the request type order
+func getGoatRequests(requests types.Requests, lists map[byte]goatRequestList) (types.Requests, error) {
+	for _, kind := range types.GoatRequestKinds() {
+		list := lists[kind.Type]
+		if list == nil || !list.isSet() {
+			continue
+		}
+		reqs, err := list.requests()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s requests: %w", kind.Name, err)
+		}
+		// we always have a GasRevenue request
+		if kind.Type == types.GoatGasRevenueRequestType && len(reqs) != 1 {
+			return nil, fmt.Errorf("invalid %s length expect 1 got %d", kind.Name, len(reqs))
+		}
+		requests = append(requests, reqs...)
+	}
+	return requests, nil
+}
+
+// initGoatRequests sets the request lists of the engine payload to empty lists
+func initGoatRequests(lists map[byte]goatRequestList) {
+	for _, list := range lists {
+		list.init()
+	}
+}
+
+// setGoatRequest appends the goat request to its request list of the engine payload,
+// the request lists of all registered types are checked at the startup.
+func setGoatRequest(lists map[byte]goatRequestList, req *types.Request) {
+	list, ok := lists[req.Type()]
+	if !ok || !list.add(req.Inner()) {
+		panic(fmt.Sprintf("no request list for the goat request type %#x", req.Type()))
+	}
+}
+
+// SetPayloadBodyRequests assigns the requests to the associated fields in ExecutionPayloadBody,
+// the ordered request list is set instead of the per type request lists if list is true.
+func SetPayloadBodyRequests(requests types.Requests, body *ExecutionPayloadBody, list bool) {
+	if list {
+		body.Requests = EncodeRequests(requests)
+		if body.Requests == nil {
+			body.Requests = make([]hexutil.Bytes, 0)
+		}
+		return
+	}
+	lists := body.goatRequestLists()
+	for _, r := range requests {
+		if v, ok := r.Inner().(*types.Deposit); ok {
+			body.Deposits = append(body.Deposits, v)
+			continue
+		}
+		setGoatRequest(lists, r)
+	}
+	body.DeprecatedGasRevenues = body.GasRevenues
+}
+
+// clearGoatRequests sets the request lists of the engine payload to nil
+func clearGoatRequests(lists map[byte]goatRequestList) {
+	for _, list := range lists {
+		list.clear()
+	}
+}
+
+// hasGoatRequests returns whether any request list of the engine payload is set
+func hasGoatRequests(lists map[byte]goatRequestList) bool {
+	for _, list := range lists {
+		if list.isSet() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeRequests encodes the requests as the ordered list of the typed opaque
+// requests, each one is the request type byte followed by the RLP encoded request
+// data. Unlike the per type request lists, the list keeps the order of the requests
+// in the block, so the requests of different types can be interleaved.
+func EncodeRequests(requests types.Requests) []hexutil.Bytes {
+	if requests == nil {
+		return nil
+	}
+	list := make([]hexutil.Bytes, len(requests))
+	for i, r := range requests {
+		list[i], _ = r.MarshalBinary()
+	}
+	return list
+}
+
+// DecodeRequests decodes the ordered list of the typed opaque requests
+func DecodeRequests(list []hexutil.Bytes) (types.Requests, error) {
+	requests := make(types.Requests, len(list))
+	for i, enc := range list {
+		req := new(types.Request)
+		if err := req.UnmarshalBinary(enc); err != nil {
+			return nil, fmt.Errorf("invalid request %d: %w", i, err)
+		}
+		requests[i] = req
+	}
+	return requests, nil
+}
+
+// equalLegacyRequests reports whether the request list carries the same requests
+// as the per type request lists, which are grouped in the request type order.
+func equalLegacyRequests(list, legacy types.Requests) bool {
+	if len(list) != len(legacy) {
+		return false
+	}
+	sorted := slices.Clone(list)
+	slices.SortStableFunc(sorted, func(a, b *types.Request) int { return int(a.Type()) - int(b.Type()) })
+	for i, enc := range EncodeRequests(sorted) {
+		if want, _ := legacy[i].MarshalBinary(); !bytes.Equal(enc, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasLegacyRequests returns whether any per type request list of the payload is set
+func HasLegacyRequests(data *ExecutableData) bool {
+	return data.Deposits != nil || hasGoatRequests(data.goatRequestLists())
+}
+
+// LegacyRequestsEnvelope returns a copy of the envelope whose payload carries the
+// requests in the per type request lists only, it's the encoding of the engine
+// methods before V5.
+func LegacyRequestsEnvelope(env *ExecutionPayloadEnvelope) *ExecutionPayloadEnvelope {
+	if env.ExecutionPayload.Requests == nil {
+		return env
+	}
+	cpy, data := *env, *env.ExecutionPayload
+	data.Requests = nil
+	cpy.ExecutionPayload = &data
+	return &cpy
+}
+
+// RequestListEnvelope returns a copy of the envelope whose payload carries the
+// requests in the ordered request list only, it's the encoding of the engine
+// methods since V5.
+func RequestListEnvelope(env *ExecutionPayloadEnvelope) *RequestListPayloadEnvelope {
+	cpy, data := *env, *env.ExecutionPayload
+	data.Deposits = nil
+	clearGoatRequests(data.goatRequestLists())
+	if data.Requests == nil {
+		data.Requests = make([]hexutil.Bytes, 0)
+	}
+	cpy.ExecutionPayload = &data
+	return &RequestListPayloadEnvelope{&cpy}
+}
+
+// RequestListPayloadEnvelope is the envelope of the engine methods since V5. The
+// per type request lists are left out of its payload and the request list is always
+// set, the empty list means the payload has no requests.
+type RequestListPayloadEnvelope struct {
+	*ExecutionPayloadEnvelope
+}
+
+// MarshalJSON marshals as JSON.
+func (env RequestListPayloadEnvelope) MarshalJSON() ([]byte, error) {
+	enc, err := json.Marshal(env.ExecutionPayloadEnvelope)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		return nil, err
+	}
+	if fields["executionPayload"], err = requestListJSON(fields["executionPayload"], env.ExecutionPayload.Requests); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (env *RequestListPayloadEnvelope) UnmarshalJSON(input []byte) error {
+	env.ExecutionPayloadEnvelope = new(ExecutionPayloadEnvelope)
+	return json.Unmarshal(input, env.ExecutionPayloadEnvelope)
+}
+
+// MarshalJSON marshals as JSON. The per type request lists are left out of the body
+// carrying the ordered request list.
+func (body ExecutionPayloadBody) MarshalJSON() ([]byte, error) {
+	type executionPayloadBody ExecutionPayloadBody
+	enc, err := json.Marshal(executionPayloadBody(body))
+	if err != nil || body.Requests == nil {
+		return enc, err
+	}
+	return requestListJSON(enc, body.Requests)
+}
+
+// legacyRequestKeys returns the JSON keys of the per type request lists in the engine
+// payloads, which are the deposit ones and the registered goat ones.
+func legacyRequestKeys() []string {
+	keys := []string{"depositRequests", "gasRevenuesRequest"}
+	for _, kind := range types.GoatRequestKinds() {
+		keys = append(keys, kind.Key)
+	}
+	return keys
+}
+
+// requestListJSON re-encodes the JSON object of the payload without the per type
+// request lists and with the ordered request list.
+func requestListJSON(enc []byte, requests []hexutil.Bytes) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range legacyRequestKeys() {
+		delete(fields, key)
+	}
+	if requests == nil {
+		requests = make([]hexutil.Bytes, 0)
+	}
+	list, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+	fields["requests"] = list
+	return json.Marshal(fields)
+}
@@ -17,6 +17,7 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"slices"
@@ -49,7 +50,8 @@ type PayloadAttributes struct {
 	Withdrawals           []*types.Withdrawal `json:"withdrawals"`
 	BeaconRoot            *common.Hash        `json:"parentBeaconBlockRoot"`
 
-	GoatTxs []hexutil.Bytes `json:"goatTxs,omitempty"  gencodec:"optional"`
+	GoatTxs            []hexutil.Bytes `json:"goatTxs,omitempty"  gencodec:"optional"`
+	ConsensusBlockHash *common.Hash    `json:"consensusBlockHash,omitempty"  gencodec:"optional"` // committed in the goat header extra since V1
 }
 
 // JSON type overrides for PayloadAttributes.
@@ -81,13 +83,27 @@ type ExecutableData struct {
 	Deposits         types.Deposits          `json:"depositRequests"`
 	ExecutionWitness *types.ExecutionWitness `json:"executionWitness,omitempty"`
 
-	// goat requests
+	// goat requests, the lists are bound to the request types by goatRequestLists
 	GasRevenues       types.GasRevenues       `json:"gasRevenueRequests"`
 	AddVoters         types.AddVoters         `json:"addVoterRequests"`
 	RemoveVoters      types.RemoveVoters      `json:"removeVoterRequests"`
 	BridgeWithdrawals types.BridgeWithdrawals `json:"bridgeWithdrawalsRequests"`
 	ReplaceByFees     types.ReplaceByFees     `json:"rbfRequests"`
 	Cancel1s          types.Cancel1s          `json:"cancel1Requests"`
+
+	// goat locking requests
+	CreateValidators      types.CreateValidators      `json:"createValidatorRequests"`
+	Locks                 types.Locks                 `json:"lockRequests"`
+	Unlocks               types.Unlocks               `json:"unlockRequests"`
+	Claims                types.Claims                `json:"claimRequests"`
+	UpdateTokenWeights    types.UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+	UpdateTokenThresholds types.UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+	Grants                types.Grants                `json:"grantRequests"`
+
+	// Requests is the ordered list of the typed opaque requests, the request type
+	// byte followed by the RLP encoded request data. It replaces the request lists
+	// above in the V5 engine methods, see EncodeRequests and RequestListPayloadEnvelope.
+	Requests []hexutil.Bytes `json:"requests,omitempty" gencodec:"optional"`
 }
 
 // JSON type overrides for executableData.
@@ -124,6 +140,25 @@ type executionPayloadEnvelopeMarshaling struct {
 	BlockValue *hexutil.Big
 }
 
+// GoatPayloadSimulation is the result of engine_simulateGoatPayloadV1, the goat requests
+// are in the request fields of the payload.
+type GoatPayloadSimulation struct {
+	ExecutionPayload *ExecutableData `json:"executionPayload"`
+	GasRevenue       *hexutil.Big    `json:"gasRevenue"`
+	FoundationTax    *hexutil.Big    `json:"foundationTax"`
+	GoatTxs          []*GoatTxResult `json:"goatTxs"`
+}
+
+// GoatTxResult is the execution result of a goat tx in the payload simulation
+type GoatTxResult struct {
+	Index        hexutil.Uint64 `json:"index"`
+	Hash         common.Hash    `json:"hash"`
+	Status       hexutil.Uint64 `json:"status"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	RevertData   hexutil.Bytes  `json:"revertData,omitempty"`
+}
+
 type PayloadStatusV1 struct {
 	Status          string       `json:"status"`
 	LatestValidHash *common.Hash `json:"latestValidHash"`
@@ -207,12 +242,18 @@ func decodeTransactions(enc [][]byte) ([]*types.Transaction, error) {
 // and that the blockhash of the constructed block matches the parameters. Nil
 // Withdrawals value will propagate through the returned block. Empty
 // Withdrawals value must be passed via non-nil, length 0 value in data.
-func ExecutableDataToBlock(data ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash) (*types.Block, error) {
+//
+// The extradata of the goat chains is limited by the goat header extra instead.
+func ExecutableDataToBlock(data ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash, config *params.ChainConfig) (*types.Block, error) {
 	txs, err := decodeTransactions(data.Transactions)
 	if err != nil {
 		return nil, err
 	}
-	if len(data.ExtraData) > int(params.MaximumExtraDataSize) {
+	maxExtra := params.MaximumExtraDataSize
+	if config.Goat != nil {
+		maxExtra = params.GoatHeaderExtraMaxLength
+	}
+	if len(data.ExtraData) > int(maxExtra) {
 		return nil, fmt.Errorf("invalid extradata length: %v", len(data.ExtraData))
 	}
 	if len(data.LogsBloom) != 256 {
@@ -254,27 +295,21 @@ func ExecutableDataToBlock(data ExecutableData, versionedHashes []common.Hash, b
 		}
 	}
 
-	if data.GasRevenues != nil {
-		// we always have a GasRevenue request
-		if l := len(data.GasRevenues); l != 1 {
-			return nil, fmt.Errorf("invalid GasRevenues length expect 1 got %d", l)
-		}
-		requests = append(requests, types.NewRequest(data.GasRevenues[0]))
-	}
-	if data.AddVoters != nil {
-		requests = append(requests, data.AddVoters.Requests()...)
-	}
-	if data.RemoveVoters != nil {
-		requests = append(requests, data.RemoveVoters.Requests()...)
-	}
-	if data.BridgeWithdrawals != nil {
-		requests = append(requests, data.BridgeWithdrawals.Requests()...)
-	}
-	if data.ReplaceByFees != nil {
-		requests = append(requests, data.ReplaceByFees.Requests()...)
+	requests, err = getGoatRequests(requests, data.goatRequestLists())
+	if err != nil {
+		return nil, err
 	}
-	if data.Cancel1s != nil {
-		requests = append(requests, data.Cancel1s.Requests()...)
+	if data.Requests != nil {
+		list, err := DecodeRequests(data.Requests)
+		if err != nil {
+			return nil, err
+		}
+		// The per type request lists are allowed along with the request list if they
+		// carry the same requests, e.g. the payloads built by BlockToExecutableData.
+		if requests != nil && !equalLegacyRequests(list, requests) {
+			return nil, errors.New("request list mismatches the per type request lists")
+		}
+		requests = list
 	}
 
 	if requests != nil {
@@ -348,6 +383,7 @@ func BlockToExecutableData(block *types.Block, fees *big.Int, sidecars []*types.
 		}
 	}
 	setRequests(block.Requests(), data)
+	data.Requests = EncodeRequests(block.Requests())
 	return &ExecutionPayloadEnvelope{ExecutionPayload: data, BlockValue: fees, BlobsBundle: &bundle, Override: false}
 }
 
@@ -359,31 +395,15 @@ func setRequests(requests types.Requests, data *ExecutableData) {
 		// should return an empty slice instead of nil if there are no deposits.
 		data.Deposits = make(types.Deposits, 0)
 
-		data.GasRevenues = make(types.GasRevenues, 0)
-		data.AddVoters = make(types.AddVoters, 0)
-		data.RemoveVoters = make(types.RemoveVoters, 0)
-		data.BridgeWithdrawals = make(types.BridgeWithdrawals, 0)
-		data.ReplaceByFees = make(types.ReplaceByFees, 0)
-		data.Cancel1s = make(types.Cancel1s, 0)
+		initGoatRequests(data.goatRequestLists())
 	}
+	lists := data.goatRequestLists()
 	for _, r := range requests {
 		switch v := r.Inner().(type) {
 		case *types.Deposit:
 			data.Deposits = append(data.Deposits, v)
-
-		// goat types
-		case *types.GasRevenue:
-			data.GasRevenues = append(data.GasRevenues, v)
-		case *types.AddVoter:
-			data.AddVoters = append(data.AddVoters, v)
-		case *types.RemoveVoter:
-			data.RemoveVoters = append(data.RemoveVoters, v)
-		case *types.BridgeWithdrawal:
-			data.BridgeWithdrawals = append(data.BridgeWithdrawals, v)
-		case *types.ReplaceByFee:
-			data.ReplaceByFees = append(data.ReplaceByFees, v)
-		case *types.Cancel1:
-			data.Cancel1s = append(data.Cancel1s, v)
+		default:
+			setGoatRequest(lists, r)
 		}
 	}
 }
@@ -395,12 +415,28 @@ type ExecutionPayloadBody struct {
 	Deposits        types.Deposits      `json:"depositRequests"`
 
 	// goat requests
-	GasRevenues       types.GasRevenues       `json:"gasRevenuesRequest"`
+	GasRevenues       types.GasRevenues       `json:"gasRevenueRequests"`
 	AddVoters         types.AddVoters         `json:"addVoterRequests"`
 	RemoveVoters      types.RemoveVoters      `json:"removeVoterRequests"`
 	BridgeWithdrawals types.BridgeWithdrawals `json:"bridgeWithdrawalsRequests"`
 	ReplaceByFees     types.ReplaceByFees     `json:"rbfRequests"`
 	Cancel1s          types.Cancel1s          `json:"cancel1Requests"`
+
+	// goat locking requests
+	CreateValidators      types.CreateValidators      `json:"createValidatorRequests"`
+	Locks                 types.Locks                 `json:"lockRequests"`
+	Unlocks               types.Unlocks               `json:"unlockRequests"`
+	Claims                types.Claims                `json:"claimRequests"`
+	UpdateTokenWeights    types.UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+	UpdateTokenThresholds types.UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+	Grants                types.Grants                `json:"grantRequests"`
+
+	// DeprecatedGasRevenues is the gas revenue requests under the misspelled key of
+	// the V1 and V2 bodies, it's kept for the CL clients which still read it.
+	DeprecatedGasRevenues types.GasRevenues `json:"gasRevenuesRequest,omitempty"`
+
+	// Requests is the ordered list of the typed opaque requests since the V3 bodies
+	Requests []hexutil.Bytes `json:"requests,omitempty"`
 }
 
 // Client identifiers to support ClientVersionV1.
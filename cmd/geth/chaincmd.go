@@ -102,13 +102,18 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.VMTraceJsonConfigFlag,
 			utils.TransactionHistoryFlag,
 			utils.StateHistoryFlag,
+			goatVerifyFlag,
 		}, utils.DatabaseFlags),
 		Description: `
 The import command imports blocks from an RLP-encoded form. The form can be one file
 with several RLP-encoded blocks, or several files can be used.
 
 If only one file is used, import error will result in failure. If several files are used,
-processing will proceed even if an individual RLP-file import failure occurs.`,
+processing will proceed even if an individual RLP-file import failure occurs.
+
+With --verify-goat, the goat tx root in the header extra and the requests hash of every
+canonical block are re-derived from the stored body and receipts after the import. The
+files can be omitted to check the existing database only.`,
 	}
 	exportCommand = &cli.Command{
 		Action:    exportChain,
@@ -285,7 +290,7 @@ func dumpGenesis(ctx *cli.Context) error {
 }
 
 func importChain(ctx *cli.Context) error {
-	if ctx.Args().Len() < 1 {
+	if ctx.Args().Len() < 1 && !ctx.Bool(goatVerifyFlag.Name) {
 		utils.Fatalf("This command requires an argument.")
 	}
 	// Start metrics export if enabled
@@ -332,6 +337,12 @@ func importChain(ctx *cli.Context) error {
 			}
 		}
 	}
+	if ctx.Bool(goatVerifyFlag.Name) {
+		if err := verifyGoatChain(chain); err != nil {
+			importErr = err
+			log.Error("Goat verification error", "err", err)
+		}
+	}
 	chain.Stop()
 	fmt.Printf("Import done in %v.\n\n", time.Since(start))
 
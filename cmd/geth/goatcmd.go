@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	goatAuditStartFlag = &cli.Uint64Flag{
+		Name:  "start",
+		Usage: "The first block of the audit range",
+	}
+	goatAuditEndFlag = &cli.Uint64Flag{
+		Name:  "end",
+		Usage: "The last block of the audit range (default = head block)",
+	}
+	goatAuditFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "The output format (csv or json)",
+		Value: "csv",
+	}
+	goatVerifyFlag = &cli.BoolFlag{
+		Name:  "verify-goat",
+		Usage: "Verify the goat txs and requests of the stored canonical blocks against their receipts after the import",
+	}
+)
+
+var goatCommand = &cli.Command{
+	Name:  "goat",
+	Usage: "Goat chain operations",
+	Subcommands: []*cli.Command{
+		goatAuditCommand,
+	},
+}
+
+var goatAuditCommand = &cli.Command{
+	Action: goatAudit,
+	Name:   "audit",
+	Usage:  "Audits the bridged BTC against the native supply",
+	Flags: flags.Merge([]cli.Flag{
+		goatAuditStartFlag,
+		goatAuditEndFlag,
+		goatAuditFormatFlag,
+	}, utils.NetworkFlags, utils.DatabaseFlags),
+	Description: `
+The goat audit command walks the canonical blocks of the range from the local
+database, and reports the minted deposits, the deposit tax, withdrawals, cancellations
+and payments of the bridge per block with the cumulative net bridged BTC. The tax
+is read from the Deposit events in the stored receipts. The net bridged BTC is
+compared with the total native supply at the last block if its state is available.
+
+The withdrawals requested before the range are resolved with the withdrawal index,
+so the range should start from the genesis if the index is not enabled.`,
+}
+
+var goatGenesisCommand = &cli.Command{
+	Action:    goatGenesis,
+	Name:      "goat-genesis",
+	Usage:     "Builds a goat devnet genesis with the system contracts predeployed",
+	ArgsUsage: "<specPath>",
+	Description: `
+The goat-genesis command reads the goat genesis spec file, and prints the genesis
+JSON with the bridge, locking, bitcoin, relayer and goat foundation contracts
+predeployed to stdout. The output can be used by the init command.`,
+}
+
+func goatGenesis(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("need goat genesis spec file as the only argument")
+	}
+	spec, err := core.ReadGoatGenesisSpec(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to read goat genesis spec: %v", err)
+	}
+	genesis, err := core.DeveloperGoatGenesisBlock(spec)
+	if err != nil {
+		utils.Fatalf("Failed to build goat genesis: %v", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(genesis)
+}
+
+// goatAuditRow is the bridged BTC flow of a block in the audit report
+type goatAuditRow struct {
+	Number     uint64       `json:"number"`
+	Hash       common.Hash  `json:"hash"`
+	Minted     *hexutil.Big `json:"minted"`
+	Tax        *hexutil.Big `json:"tax"`
+	Withdrawn  *hexutil.Big `json:"withdrawn"`
+	Cancelled  *hexutil.Big `json:"cancelled"`
+	Paid       *hexutil.Big `json:"paid"`
+	Net        *hexutil.Big `json:"net"`
+	Cumulative *hexutil.Big `json:"cumulative"`
+}
+
+// goatAuditReport is the audit report in JSON
+type goatAuditReport struct {
+	Blocks     []*goatAuditRow `json:"blocks"`
+	Net        *hexutil.Big    `json:"net"`
+	Paid       *hexutil.Big    `json:"paid"`
+	Unmatched  int             `json:"unmatched"`
+	Supply     *hexutil.Big    `json:"supply,omitempty"`
+	Difference *hexutil.Big    `json:"difference,omitempty"` // the supply not backed by the bridge
+}
+
+func goatAudit(ctx *cli.Context) error {
+	format := ctx.String(goatAuditFormatFlag.Name)
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unknown output format %q", format)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	config, err := core.LoadChainConfig(db, nil)
+	if err != nil {
+		return err
+	}
+	if config.Goat == nil {
+		return errors.New("not a goat chain")
+	}
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return errors.New("no head block")
+	}
+	start, end := ctx.Uint64(goatAuditStartFlag.Name), head.NumberU64()
+	if ctx.IsSet(goatAuditEndFlag.Name) {
+		end = ctx.Uint64(goatAuditEndFlag.Name)
+	}
+	if start > end || end > head.NumberU64() {
+		return fmt.Errorf("invalid block range %d-%d, head %d", start, end, head.NumberU64())
+	}
+
+	var (
+		auditor = core.NewGoatBridgeAuditor(config.Goat, func(id uint64) *types.BridgeWithdrawal {
+			if lc, _ := core.ReadGoatWithdrawalLifecycle(db, config.Goat, id); lc != nil {
+				return lc.Withdrawal
+			}
+			return nil
+		})
+		report = &goatAuditReport{Blocks: make([]*goatAuditRow, 0)}
+		writer = csv.NewWriter(os.Stdout)
+		last   *types.Block
+	)
+	if format == "csv" {
+		writer.Write([]string{"number", "hash", "minted", "tax", "withdrawn", "cancelled", "paid", "net", "cumulative"})
+	}
+	for number := start; number <= end; number++ {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		block := rawdb.ReadBlock(db, hash, number)
+		if block == nil {
+			return fmt.Errorf("missing block %d", number)
+		}
+		flow := auditor.Process(block, rawdb.ReadRawReceipts(db, hash, number))
+		row := &goatAuditRow{
+			Number:     number,
+			Hash:       hash,
+			Minted:     (*hexutil.Big)(flow.Minted),
+			Tax:        (*hexutil.Big)(flow.Tax),
+			Withdrawn:  (*hexutil.Big)(flow.Withdrawn),
+			Cancelled:  (*hexutil.Big)(flow.Cancelled),
+			Paid:       (*hexutil.Big)(flow.Paid),
+			Net:        (*hexutil.Big)(flow.Net()),
+			Cumulative: (*hexutil.Big)(auditor.Total.Net()),
+		}
+		if format == "csv" {
+			writer.Write([]string{strconv.FormatUint(number, 10), hash.Hex(), flow.Minted.String(), flow.Tax.String(), flow.Withdrawn.String(),
+				flow.Cancelled.String(), flow.Paid.String(), flow.Net().String(), auditor.Total.Net().String()})
+		} else {
+			report.Blocks = append(report.Blocks, row)
+		}
+		last = block
+	}
+	writer.Flush()
+
+	net := auditor.Total.Net()
+	report.Net, report.Paid, report.Unmatched = (*hexutil.Big)(net), (*hexutil.Big)(auditor.Total.Paid), auditor.Unmatched
+
+	tdb := utils.MakeTrieDatabase(ctx, db, false, true, false)
+	defer tdb.Close()
+	if supply, err := goatNativeSupply(tdb, last.Root()); err != nil {
+		log.Warn("Failed to compute the native supply", "number", last.NumberU64(), "err", err)
+	} else {
+		report.Supply, report.Difference = (*hexutil.Big)(supply), (*hexutil.Big)(new(big.Int).Sub(supply, net))
+	}
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	log.Info("Audited the bridged BTC", "start", start, "end", end, "net", net, "paid", auditor.Total.Paid,
+		"unmatched", auditor.Unmatched, "supply", (*big.Int)(report.Supply), "difference", (*big.Int)(report.Difference))
+	return nil
+}
+
+// goatNativeSupply sums the balances of all accounts in the state
+func goatNativeSupply(tdb *triedb.Database, root common.Hash) (*big.Int, error) {
+	t, err := trie.NewStateTrie(trie.StateTrieID(root), tdb)
+	if err != nil {
+		return nil, err
+	}
+	it, err := t.NodeIterator(nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		supply = new(big.Int)
+		iter   = trie.NewIterator(it)
+	)
+	for iter.Next() {
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(iter.Value, &acc); err != nil {
+			return nil, err
+		}
+		supply.Add(supply, acc.Balance.ToBig())
+	}
+	return supply, iter.Err
+}
+
+// verifyGoatChain checks the goat txs and the requests of the canonical blocks
+// against the header commitments and the stored receipts, it doesn't need the state.
+func verifyGoatChain(chain *core.BlockChain) error {
+	config := chain.Config()
+	if config.Goat == nil {
+		return errors.New("not a goat chain")
+	}
+	var (
+		head     = chain.CurrentBlock().Number.Uint64()
+		start    = time.Now()
+		logged   = time.Now()
+		failures int
+	)
+	log.Info("Verifying goat blocks", "head", head)
+	for number := uint64(1); number <= head; number++ {
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			return fmt.Errorf("block %d not found", number)
+		}
+		receipts := chain.GetReceiptsByHash(block.Hash())
+		if receipts == nil && block.Transactions().Len() > 0 {
+			return fmt.Errorf("receipts of block %d not found", number)
+		}
+		if err := core.VerifyGoatBlock(config, block, receipts); err != nil {
+			log.Error("Invalid goat block", "number", number, "hash", block.Hash(), "err", err)
+			failures++
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying goat blocks", "number", number, "head", head, "failures", failures, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d invalid goat blocks", failures)
+	}
+	log.Info("Verified goat blocks", "head", head, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
@@ -225,6 +225,9 @@ func init() {
 		removedbCommand,
 		dumpCommand,
 		dumpGenesisCommand,
+		// See goatcmd.go:
+		goatGenesisCommand,
+		goatCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -1958,15 +1958,19 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSyst
 	}
 }
 
-// RegisterFilterAPI adds the eth log filtering RPC API to the node.
+// RegisterFilterAPI adds the eth log filtering and the goat bridge event RPC APIs to the node.
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
 		LogCacheSize: ethcfg.FilterLogCacheSize,
 	})
-	stack.RegisterAPIs([]rpc.API{{
+	apis := []rpc.API{{
 		Namespace: "eth",
 		Service:   filters.NewFilterAPI(filterSystem),
-	}})
+	}}
+	if backend.ChainConfig().Goat != nil {
+		apis = append(apis, rpc.API{Namespace: "goat", Service: filters.NewGoatFilterAPI(backend)})
+	}
+	stack.RegisterAPIs(apis)
 	return filterSystem
 }
 
@@ -229,8 +229,12 @@ func (beacon *Beacon) VerifyUncles(chain consensus.ChainReader, block *types.Blo
 // (c) the extradata is limited to 32 bytes
 func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, parent *types.Header) error {
 	// Ensure that the header's extra-data section is of a reasonable size
-	if len(header.Extra) > int(params.MaximumExtraDataSize) {
-		return fmt.Errorf("extra-data longer than 32 bytes (%d)", len(header.Extra))
+	maxExtra := params.MaximumExtraDataSize
+	if chain.Config().Goat != nil {
+		maxExtra = params.GoatHeaderExtraMaxLength
+	}
+	if len(header.Extra) > int(maxExtra) {
+		return fmt.Errorf("extra-data longer than %d bytes (%d)", maxExtra, len(header.Extra))
 	}
 	// Verify the seal parts. Ensure the nonce and uncle hash are the expected value.
 	if header.Nonce != beaconNonce {
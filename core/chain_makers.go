@@ -350,7 +350,7 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 
 		if config.Goat != nil {
 			for _, r := range b.receipts {
-				d, err := ProcessGoatRequests(new(big.Int), r.Logs, config)
+				d, err := ProcessGoatRequests(new(big.Int), r.Logs, config, b.header.Time)
 				if err != nil {
 					panic(fmt.Sprintf("failed to parse deposit log: %v", err))
 				}
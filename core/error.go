@@ -112,4 +112,20 @@ var (
 
 	// ErrBlobTxCreate is returned if a blob transaction has no explicit to field.
 	ErrBlobTxCreate = errors.New("blob transaction of type create")
+
+	// ErrGoatTxNonce is returned if the goat txs are not in the strict nonce
+	// sequence of their executor.
+	ErrGoatTxNonce = errors.New("invalid goat tx nonce")
+
+	// ErrGoatDuplicateDeposit is returned if a bridge deposit (txid, txout) is
+	// included more than once in a block.
+	ErrGoatDuplicateDeposit = errors.New("duplicate goat deposit")
+
+	// ErrGoatBitcoinHeader is returned if the appended bitcoin header is not
+	// the valid child of the tracked bitcoin header chain.
+	ErrGoatBitcoinHeader = errors.New("invalid bitcoin header")
+
+	// ErrGoatDepositProof is returned if the bitcoin inclusion proof of a bridge
+	// deposit doesn't match the tracked bitcoin headers.
+	ErrGoatDepositProof = errors.New("invalid goat deposit proof")
 )
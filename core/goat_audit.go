@@ -0,0 +1,145 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GoatBridgeFlow is the bridged BTC flow in wei
+//
+// The deposits are split into the amounts minted to the targets and the tax minted
+// to the goat foundation, both are backed by the bridge. The withdrawals are counted
+// once they're requested, the cancelled ones are refunded on the goat chain.
+type GoatBridgeFlow struct {
+	Minted    *big.Int // the deposits minted to the targets without the tax
+	Tax       *big.Int // the deposit tax minted to the goat foundation
+	Withdrawn *big.Int // the withdrawals requested
+	Cancelled *big.Int // the withdrawals cancelled and refunded
+	Paid      *big.Int // the amounts paid on the bitcoin network
+}
+
+func newGoatBridgeFlow() *GoatBridgeFlow {
+	return &GoatBridgeFlow{Minted: new(big.Int), Tax: new(big.Int), Withdrawn: new(big.Int), Cancelled: new(big.Int), Paid: new(big.Int)}
+}
+
+// Net returns the net bridged BTC of the flow
+func (f *GoatBridgeFlow) Net() *big.Int {
+	net := new(big.Int).Add(f.Minted, f.Tax)
+	net.Sub(net, f.Withdrawn)
+	return net.Add(net, f.Cancelled)
+}
+
+func (f *GoatBridgeFlow) add(o *GoatBridgeFlow) {
+	f.Minted.Add(f.Minted, o.Minted)
+	f.Tax.Add(f.Tax, o.Tax)
+	f.Withdrawn.Add(f.Withdrawn, o.Withdrawn)
+	f.Cancelled.Add(f.Cancelled, o.Cancelled)
+	f.Paid.Add(f.Paid, o.Paid)
+}
+
+// GoatBridgeAuditor accumulates the bridged BTC flows of the blocks in the chain order
+type GoatBridgeAuditor struct {
+	Total     *GoatBridgeFlow // the cumulative flow of the processed blocks
+	Unmatched int             // the number of the cancellations and payments without known withdrawal
+
+	bridge      common.Address                          // the bridge contract emitting the deposit events
+	withdrawals map[uint64]*big.Int                     // the withdrawal amounts by id
+	lookup      func(id uint64) *types.BridgeWithdrawal // the fallback for the withdrawals before the range
+}
+
+// NewGoatBridgeAuditor creates an auditor, the lookup is used to find the withdrawals
+// requested before the first processed block, it can be nil.
+func NewGoatBridgeAuditor(config *params.GoatConfig, lookup func(id uint64) *types.BridgeWithdrawal) *GoatBridgeAuditor {
+	return &GoatBridgeAuditor{
+		Total:       newGoatBridgeFlow(),
+		bridge:      config.SystemAddresses().Bridge,
+		withdrawals: make(map[uint64]*big.Int),
+		lookup:      lookup,
+	}
+}
+
+func (a *GoatBridgeAuditor) withdrawal(id *big.Int) *big.Int {
+	if !id.IsUint64() {
+		return nil
+	}
+	if amount, ok := a.withdrawals[id.Uint64()]; ok {
+		return amount
+	}
+	if a.lookup != nil {
+		if w := a.lookup(id.Uint64()); w != nil {
+			return new(big.Int).Mul(new(big.Int).SetUint64(w.Amount), big.NewInt(params.Satoshi))
+		}
+	}
+	return nil
+}
+
+// settle returns the requested amount of the cancelled or paid withdrawal, it's
+// nil if the withdrawal is unknown
+func (a *GoatBridgeAuditor) settle(block *types.Block, tx *types.Transaction, id *big.Int) *big.Int {
+	amount := a.withdrawal(id)
+	if amount == nil {
+		log.Warn("Unknown bridge withdrawal", "number", block.NumberU64(), "tx", tx.Hash(), "id", id)
+		a.Unmatched++
+	}
+	return amount
+}
+
+// depositTax returns the tax of the deposit from the Deposit event of the bridge
+// in the receipt, the tax is the last word of the event data.
+func (a *GoatBridgeAuditor) depositTax(block *types.Block, tx *types.Transaction, receipt *types.Receipt) *big.Int {
+	if receipt != nil {
+		for _, l := range receipt.Logs {
+			if l.Address == a.bridge && len(l.Topics) != 0 && l.Topics[0] == types.GoatDepositTopic && len(l.Data) >= 32 {
+				return new(big.Int).SetBytes(l.Data[len(l.Data)-32:])
+			}
+		}
+	}
+	log.Warn("Missing bridge deposit event", "number", block.NumberU64(), "tx", tx.Hash())
+	return new(big.Int)
+}
+
+// Process adds the bridged BTC flow of the block and returns it, the receipts are
+// used to find the deposit tax
+func (a *GoatBridgeAuditor) Process(block *types.Block, receipts types.Receipts) *GoatBridgeFlow {
+	flow := newGoatBridgeFlow()
+	for i, tx := range block.Transactions() {
+		goatTx := tx.GoatTx()
+		if goatTx == nil {
+			// the goat txs are at the beginning of the block
+			break
+		}
+		var receipt *types.Receipt
+		if i < len(receipts) {
+			receipt = receipts[i]
+		}
+		if deposit := goatTx.Inner().Deposit(); deposit != nil {
+			tax := a.depositTax(block, tx, receipt)
+			flow.Tax.Add(flow.Tax, tax)
+			flow.Minted.Add(flow.Minted, new(big.Int).Sub(deposit.Amount, tax))
+			continue
+		}
+		switch v := goatTx.Inner().(type) {
+		case *goattypes.Cancel2Tx:
+			if amount := a.settle(block, tx, v.Id); amount != nil {
+				flow.Cancelled.Add(flow.Cancelled, amount)
+			}
+		case *goattypes.PaidTx:
+			a.settle(block, tx, v.Id)
+			flow.Paid.Add(flow.Paid, v.Amount)
+		}
+	}
+	for _, r := range block.Requests() {
+		if w, ok := r.Inner().(*types.BridgeWithdrawal); ok {
+			amount := new(big.Int).Mul(new(big.Int).SetUint64(w.Amount), big.NewInt(params.Satoshi))
+			a.withdrawals[w.Id] = amount
+			flow.Withdrawn.Add(flow.Withdrawn, amount)
+		}
+	}
+	a.Total.add(flow)
+	return flow
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestGoatBridgeAuditor(t *testing.T) {
+	var (
+		gwei    = big.NewInt(1e9)
+		tax     = big.NewInt(1e7)
+		bitcoin = func(sat uint64) *big.Int {
+			return new(big.Int).Mul(new(big.Int).SetUint64(sat), big.NewInt(params.Satoshi))
+		}
+		bridgeTx = func(action goattypes.Action, nonce uint64, inner goattypes.Tx) *types.Transaction {
+			return types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, action, nonce, inner))
+		}
+		block = func(number int64, txs []*types.Transaction, requests types.Requests) *types.Block {
+			header := &types.Header{Number: big.NewInt(number)}
+			return types.NewBlock(header, &types.Body{Transactions: txs, Requests: requests}, nil, trie.NewStackTrie(nil))
+		}
+		// the Deposit(address,uint256,bytes32,uint32,uint256) event with the tax
+		depositReceipt = func(tax *big.Int) *types.Receipt {
+			data := make([]byte, 96)
+			tax.FillBytes(data[64:])
+			return &types.Receipt{Logs: []*types.Log{{
+				Address: params.GoatBridgeContract,
+				Topics:  []common.Hash{types.GoatDepositTopic, common.BytesToHash([]byte{0x1}), common.BigToHash(gwei)},
+				Data:    data,
+			}}}
+		}
+	)
+	// the withdrawal 1 is requested before the audit range
+	auditor := NewGoatBridgeAuditor(&params.GoatConfig{}, func(id uint64) *types.BridgeWithdrawal {
+		if id == 1 {
+			return &types.BridgeWithdrawal{Id: 1, Amount: 7}
+		}
+		return nil
+	})
+
+	flow := auditor.Process(block(1, []*types.Transaction{
+		bridgeTx(goattypes.BridgeDepoitAction, 0, &goattypes.DepositTx{Txid: common.Hash{0x1}, Target: common.Address{0x1}, Amount: gwei}),
+		bridgeTx(goattypes.BridgePaidAction, 1, &goattypes.PaidTx{Id: big.NewInt(1), Txid: common.Hash{0x2}, Amount: bitcoin(6)}),
+	}, types.Requests{
+		types.NewRequest(&types.BridgeWithdrawal{Id: 2, Amount: 10}),
+	}), types.Receipts{depositReceipt(tax), {}})
+	if want := new(big.Int).Sub(gwei, tax); flow.Minted.Cmp(want) != 0 || flow.Tax.Cmp(tax) != 0 {
+		t.Fatalf("block 1 deposit mismatch: minted %v tax %v", flow.Minted, flow.Tax)
+	}
+	if flow.Withdrawn.Cmp(bitcoin(10)) != 0 || flow.Paid.Cmp(bitcoin(6)) != 0 || flow.Cancelled.Sign() != 0 {
+		t.Fatalf("block 1 flow mismatch: %+v", flow)
+	}
+	if want := new(big.Int).Sub(gwei, bitcoin(10)); flow.Net().Cmp(want) != 0 {
+		t.Fatalf("block 1 net mismatch: have %v want %v", flow.Net(), want)
+	}
+
+	flow = auditor.Process(block(2, []*types.Transaction{
+		bridgeTx(goattypes.BridgeCancel2Action, 2, &goattypes.Cancel2Tx{Id: big.NewInt(2)}),
+		bridgeTx(goattypes.BridgeCancel2Action, 3, &goattypes.Cancel2Tx{Id: big.NewInt(3)}),
+	}, nil), types.Receipts{{}, {}})
+	if flow.Cancelled.Cmp(bitcoin(10)) != 0 || flow.Minted.Sign() != 0 || flow.Tax.Sign() != 0 || flow.Withdrawn.Sign() != 0 {
+		t.Fatalf("block 2 flow mismatch: %+v", flow)
+	}
+	if auditor.Unmatched != 1 {
+		t.Fatalf("unmatched mismatch: have %d want 1", auditor.Unmatched)
+	}
+	if auditor.Total.Net().Cmp(gwei) != 0 {
+		t.Fatalf("total net mismatch: have %v want %v", auditor.Total.Net(), gwei)
+	}
+	if auditor.Total.Paid.Cmp(bitcoin(6)) != 0 || auditor.Total.Tax.Cmp(tax) != 0 {
+		t.Fatalf("total mismatch: paid %v tax %v", auditor.Total.Paid, auditor.Total.Tax)
+	}
+
+	// the deposit without the event is counted without tax
+	flow = auditor.Process(block(3, []*types.Transaction{
+		bridgeTx(goattypes.BridgeDepoitAction, 4, &goattypes.DepositTx{Txid: common.Hash{0x3}, Target: common.Address{0x1}, Amount: gwei}),
+	}, nil), nil)
+	if flow.Minted.Cmp(gwei) != 0 || flow.Tax.Sign() != 0 {
+		t.Fatalf("block 3 deposit mismatch: minted %v tax %v", flow.Minted, flow.Tax)
+	}
+}
@@ -0,0 +1,334 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The bitcoin header chain is stored in the storage of the bitcoin contract with the
+// hashed slots, which never collide with the solidity layout of the contract. All the
+// appended headers are kept by hash, the canonical chain is the heaviest one.
+//
+// tip: the height of the canonical tip plus one, zero means no header is tracked
+// canonical(height): the hash of the canonical header at the height
+// header(hash): the raw header in the 3 consecutive slots starting from the hashed slot,
+// followed by the height plus one and the cumulative work since the checkpoint
+var (
+	bitcoinTipSlot           = crypto.Keccak256Hash([]byte("goat.bitcoin.tip"))
+	bitcoinCanonicalSlotBase = []byte("goat.bitcoin.canonical")
+	bitcoinHeaderSlotBase    = []byte("goat.bitcoin.header")
+)
+
+// BitcoinStateReader is the state to read the tracked bitcoin headers
+type BitcoinStateReader interface {
+	GetState(common.Address, common.Hash) common.Hash
+}
+
+// BitcoinStateWriter is the state to append the bitcoin headers
+type BitcoinStateWriter interface {
+	BitcoinStateReader
+	SetState(common.Address, common.Hash, common.Hash)
+}
+
+func bitcoinCanonicalSlot(height uint64) common.Hash {
+	return crypto.Keccak256Hash(bitcoinCanonicalSlotBase, binary.BigEndian.AppendUint64(nil, height))
+}
+
+// bitcoinHeaderSlots returns the 3 slots of the raw header, the height slot and the work slot
+func bitcoinHeaderSlots(hash common.Hash) [5]common.Hash {
+	base := new(big.Int).SetBytes(crypto.Keccak256(bitcoinHeaderSlotBase, hash[:]))
+	var slots [5]common.Hash
+	for i := range slots {
+		slots[i] = common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i))))
+	}
+	return slots
+}
+
+// trackedBitcoinHeader is an appended bitcoin header of the canonical or a side branch
+type trackedBitcoinHeader struct {
+	*goattypes.BitcoinHeader
+	raw    []byte
+	hash   common.Hash
+	height uint64
+	work   *big.Int // the cumulative work since the checkpoint
+}
+
+// readTrackedBitcoinHeader returns the appended bitcoin header by hash, it returns nil if not found
+func readTrackedBitcoinHeader(config *params.GoatConfig, state BitcoinStateReader, hash common.Hash) *trackedBitcoinHeader {
+	var (
+		addr   = config.SystemAddresses().Bitcoin
+		slots  = bitcoinHeaderSlots(hash)
+		height = state.GetState(addr, slots[3]).Big()
+	)
+	if height.Sign() == 0 || !height.IsUint64() {
+		return nil
+	}
+	raw := make([]byte, 0, 3*common.HashLength)
+	for _, slot := range slots[:3] {
+		v := state.GetState(addr, slot)
+		raw = append(raw, v[:]...)
+	}
+	raw = raw[:goattypes.BitcoinHeaderLength]
+	header, err := goattypes.DecodeBitcoinHeader(raw)
+	if err != nil {
+		return nil
+	}
+	return &trackedBitcoinHeader{
+		BitcoinHeader: header,
+		raw:           raw,
+		hash:          hash,
+		height:        height.Uint64() - 1,
+		work:          state.GetState(addr, slots[4]).Big(),
+	}
+}
+
+// ReadBitcoinTip returns the height of the canonical tip of the tracked bitcoin headers
+func ReadBitcoinTip(config *params.GoatConfig, state BitcoinStateReader) (uint64, bool) {
+	v := state.GetState(config.SystemAddresses().Bitcoin, bitcoinTipSlot).Big()
+	if v.Sign() == 0 || !v.IsUint64() {
+		return 0, false
+	}
+	return v.Uint64() - 1, true
+}
+
+func readCanonicalBitcoinHeader(config *params.GoatConfig, state BitcoinStateReader, height uint64) *trackedBitcoinHeader {
+	if config == nil || config.Bitcoin == nil || height <= config.Bitcoin.StartHeight {
+		return nil
+	}
+	if tip, ok := ReadBitcoinTip(config, state); !ok || height > tip {
+		return nil
+	}
+	hash := state.GetState(config.SystemAddresses().Bitcoin, bitcoinCanonicalSlot(height))
+	return readTrackedBitcoinHeader(config, state, hash)
+}
+
+// ReadBitcoinHeader returns the raw canonical bitcoin header at the given height, it returns nil if not found
+func ReadBitcoinHeader(config *params.GoatConfig, state BitcoinStateReader, height uint64) []byte {
+	if header := readCanonicalBitcoinHeader(config, state, height); header != nil {
+		return header.raw
+	}
+	return nil
+}
+
+// bitcoinAncestor returns the header at the given height on the branch of the head,
+// it returns nil if the height is not after the checkpoint or above the head.
+func bitcoinAncestor(config *params.GoatConfig, state BitcoinStateReader, head *trackedBitcoinHeader, height uint64) *goattypes.BitcoinHeader {
+	addr := config.SystemAddresses().Bitcoin
+	for cur := head; cur != nil && cur.height >= height; {
+		if cur.height == height {
+			return cur.BitcoinHeader
+		}
+		// the ancestors of a canonical header are canonical
+		if state.GetState(addr, bitcoinCanonicalSlot(cur.height)) == cur.hash {
+			if ancestor := readCanonicalBitcoinHeader(config, state, height); ancestor != nil {
+				return ancestor.BitcoinHeader
+			}
+			return nil
+		}
+		cur = readTrackedBitcoinHeader(config, state, cur.PrevBlock)
+	}
+	return nil
+}
+
+// bitcoinBlockWork returns the expected number of hashes to mine a block with the bits
+func bitcoinBlockWork(bits uint32) *big.Int {
+	target := goattypes.CompactToBig(bits)
+	work := new(big.Int).Lsh(common.Big1, 256)
+	return work.Div(work, target.Add(target, common.Big1))
+}
+
+// AppendBitcoinHeader checks the bitcoin header with the proof of work and the prev hash linkage,
+// and then appends it to the tracked headers. The header can extend the canonical tip or any
+// appended header, the branch with the most cumulative work becomes canonical and the first
+// appended one wins the ties. It returns the height of the header.
+func AppendBitcoinHeader(config *params.GoatConfig, state BitcoinStateWriter, raw []byte) (uint64, error) {
+	if config == nil || config.Bitcoin == nil {
+		return 0, fmt.Errorf("%w: header tracking is disabled", ErrGoatBitcoinHeader)
+	}
+	header, err := goattypes.DecodeBitcoinHeader(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrGoatBitcoinHeader, err)
+	}
+	hash := header.Hash()
+	if hash == config.Bitcoin.StartHash || readTrackedBitcoinHeader(config, state, hash) != nil {
+		return 0, fmt.Errorf("%w: duplicate header %x", ErrGoatBitcoinHeader, hash)
+	}
+
+	var (
+		height = config.Bitcoin.StartHeight + 1
+		work   = new(big.Int)
+		prev   *trackedBitcoinHeader
+	)
+	if header.PrevBlock != config.Bitcoin.StartHash {
+		if prev = readTrackedBitcoinHeader(config, state, header.PrevBlock); prev == nil {
+			return 0, fmt.Errorf("%w: unknown prev block %x", ErrGoatBitcoinHeader, header.PrevBlock)
+		}
+		height, work = prev.height+1, work.Set(prev.work)
+	}
+
+	pow := config.Bitcoin.BitcoinPowParams()
+	if err := checkBitcoinPow(pow, header); err != nil {
+		return 0, fmt.Errorf("%w: height %d: %v", ErrGoatBitcoinHeader, height, err)
+	}
+	if prev != nil {
+		ancestor := func(number uint64) *goattypes.BitcoinHeader { return bitcoinAncestor(config, state, prev, number) }
+		if bits, ok := nextBitcoinBits(pow, prev.BitcoinHeader, height, header.Timestamp, ancestor); ok && bits != header.Bits {
+			return 0, fmt.Errorf("%w: unexpected difficulty bits at height %d: have %08x want %08x", ErrGoatBitcoinHeader, height, header.Bits, bits)
+		}
+	}
+	work.Add(work, bitcoinBlockWork(header.Bits))
+
+	var (
+		addr   = config.SystemAddresses().Bitcoin
+		slots  = bitcoinHeaderSlots(hash)
+		padded = make([]byte, 3*common.HashLength)
+	)
+	copy(padded, raw)
+	for i, slot := range slots[:3] {
+		state.SetState(addr, slot, common.BytesToHash(padded[i*common.HashLength:(i+1)*common.HashLength]))
+	}
+	state.SetState(addr, slots[3], common.BigToHash(new(big.Int).SetUint64(height+1)))
+	state.SetState(addr, slots[4], common.BigToHash(work))
+
+	tip, ok := ReadBitcoinTip(config, state)
+	if ok {
+		current := readCanonicalBitcoinHeader(config, state, tip)
+		if current == nil {
+			return 0, fmt.Errorf("%w: missing tip header %d", ErrGoatBitcoinHeader, tip)
+		}
+		// the header of a side branch, which becomes canonical once it's heavier
+		if work.Cmp(current.work) <= 0 {
+			return height, nil
+		}
+		for number := height + 1; number <= tip; number++ {
+			state.SetState(addr, bitcoinCanonicalSlot(number), common.Hash{})
+		}
+	}
+	// rewrite the canonical hashes from the header back to the fork point
+	for number, cur := height, header; ; number-- {
+		slot := bitcoinCanonicalSlot(number)
+		if state.GetState(addr, slot) == cur.Hash() {
+			break
+		}
+		state.SetState(addr, slot, cur.Hash())
+		if cur.PrevBlock == config.Bitcoin.StartHash {
+			break
+		}
+		parent := readTrackedBitcoinHeader(config, state, cur.PrevBlock)
+		if parent == nil {
+			return 0, fmt.Errorf("%w: missing header %x", ErrGoatBitcoinHeader, cur.PrevBlock)
+		}
+		cur = parent.BitcoinHeader
+	}
+	state.SetState(addr, bitcoinTipSlot, common.BigToHash(new(big.Int).SetUint64(height+1)))
+	return height, nil
+}
+
+// checkBitcoinPow checks the header hash meets the target claimed by itself
+func checkBitcoinPow(pow *params.BitcoinPowParams, header *goattypes.BitcoinHeader) error {
+	target := goattypes.CompactToBig(header.Bits)
+	if target.Sign() <= 0 {
+		return errors.New("invalid target")
+	}
+	if target.Cmp(pow.PowLimit) > 0 {
+		return fmt.Errorf("target %x is higher than the pow limit", target)
+	}
+	if goattypes.HashToBig(header.Hash()).Cmp(target) > 0 {
+		return errors.New("hash is higher than the target")
+	}
+	return nil
+}
+
+// nextBitcoinBits returns the expected difficulty bits of the next header, the ancestor
+// returns the header at the given height on the branch of the prev header. The false is
+// returned if it's not able to be computed with the tracked headers after the checkpoint.
+func nextBitcoinBits(pow *params.BitcoinPowParams, prev *goattypes.BitcoinHeader, height uint64, time uint32, ancestor func(height uint64) *goattypes.BitcoinHeader) (uint32, bool) {
+	limitBits := goattypes.BigToCompact(pow.PowLimit)
+	if height%pow.RetargetInterval != 0 {
+		if !pow.MinDifficulty {
+			return prev.Bits, true
+		}
+		// the min difficulty block is allowed if no block is mined in 2*TargetSpacing
+		if uint64(time) > uint64(prev.Timestamp)+2*pow.TargetSpacing {
+			return limitBits, true
+		}
+		// otherwise it's the difficulty of the last block which is not the min difficulty one
+		for number, last := height-1, prev; ; number-- {
+			if number%pow.RetargetInterval == 0 || last.Bits != limitBits {
+				return last.Bits, true
+			}
+			if last = ancestor(number - 1); last == nil {
+				return 0, false
+			}
+		}
+	}
+	if pow.NoRetargeting {
+		return prev.Bits, true
+	}
+
+	first := ancestor(height - pow.RetargetInterval)
+	if first == nil {
+		return 0, false
+	}
+	timespan := int64(prev.Timestamp) - int64(first.Timestamp)
+	if min := int64(pow.TargetTimespan / 4); timespan < min {
+		timespan = min
+	}
+	if max := int64(pow.TargetTimespan * 4); timespan > max {
+		timespan = max
+	}
+	target := goattypes.CompactToBig(prev.Bits)
+	target.Mul(target, big.NewInt(timespan))
+	target.Div(target, new(big.Int).SetUint64(pow.TargetTimespan))
+	if target.Cmp(pow.PowLimit) > 0 {
+		target.Set(pow.PowLimit)
+	}
+	return goattypes.BigToCompact(target), true
+}
+
+// VerifyDepositProof checks the bridge deposit is included in the tracked bitcoin block and
+// the deposit amount matches the value of the tx output
+func VerifyDepositProof(config *params.GoatConfig, state BitcoinStateReader, tx *goattypes.DepositProofTx) error {
+	header := readCanonicalBitcoinHeader(config, state, tx.Height)
+	if header == nil {
+		return fmt.Errorf("%w: bitcoin block %d is not tracked", ErrGoatDepositProof, tx.Height)
+	}
+	// the deposit block should be deep enough in the canonical chain not to be reorged
+	if tip, _ := ReadBitcoinTip(config, state); tip-tx.Height+1 < config.Bitcoin.MinConfirmations() {
+		return fmt.Errorf("%w: bitcoin block %d has %d confirmations, want %d", ErrGoatDepositProof, tx.Height, tip-tx.Height+1, config.Bitcoin.MinConfirmations())
+	}
+
+	// the 64 bytes tx is rejected since it can be an inner node of the merkle tree
+	if len(tx.RawTx) == 64 {
+		return fmt.Errorf("%w: invalid bitcoin tx length", ErrGoatDepositProof)
+	}
+	if txid := goattypes.DoubleSha256(tx.RawTx); txid != tx.Txid {
+		return fmt.Errorf("%w: txid mismatched: have %x want %x", ErrGoatDepositProof, txid, tx.Txid)
+	}
+	if tx.TxIndex>>len(tx.Branch) != 0 {
+		return fmt.Errorf("%w: tx index %d is out of the merkle branch", ErrGoatDepositProof, tx.TxIndex)
+	}
+	if root := tx.MerkleRoot(); root != header.MerkleRoot {
+		return fmt.Errorf("%w: merkle root mismatched at height %d: have %x want %x", ErrGoatDepositProof, tx.Height, root, header.MerkleRoot)
+	}
+
+	values, err := goattypes.BitcoinTxOutputValues(tx.RawTx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGoatDepositProof, err)
+	}
+	if int(tx.TxOut) >= len(values) {
+		return fmt.Errorf("%w: txout %d is out of range %d", ErrGoatDepositProof, tx.TxOut, len(values))
+	}
+	value := new(big.Int).Mul(new(big.Int).SetUint64(values[tx.TxOut]), big.NewInt(params.Satoshi))
+	if value.Cmp(tx.Amount) != 0 {
+		return fmt.Errorf("%w: amount mismatched: have %s want %s", ErrGoatDepositProof, tx.Amount, value)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// the bitcoin mainnet headers at height 1 and 2
+	btcHeader1 = hexutil.MustDecode("0x010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299")
+	btcHeader2 = hexutil.MustDecode("0x010000004860eb18bf1b1620e37e9490fc8a427514416fd75159ab86688e9a8300000000d5fdcc541e25de1c7a5addedf24858b8bb665c9f36ef744ee42c316022c90f9bb0bc6649ffff001d08d2bd61")
+
+	// the mainnet genesis hash in the internal byte order
+	btcGenesisHash = common.HexToHash("0x6fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000")
+)
+
+func TestAppendBitcoinHeader(t *testing.T) {
+	config := &params.GoatConfig{Bitcoin: &params.GoatBitcoinConfig{Network: "mainnet", StartHash: btcGenesisHash}}
+	if err := config.CheckConfig(); err != nil {
+		t.Fatal(err)
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+
+	if _, ok := ReadBitcoinTip(config, statedb); ok {
+		t.Fatal("unexpected tip before appending")
+	}
+	if _, err := AppendBitcoinHeader(config, statedb, btcHeader2); !errors.Is(err, ErrGoatBitcoinHeader) {
+		t.Fatalf("expect prev hash error, got %v", err)
+	}
+
+	// the nonce is changed, so the hash doesn't meet the target
+	invalid := common.CopyBytes(btcHeader1)
+	invalid[79]++
+	if _, err := AppendBitcoinHeader(config, statedb, invalid); !errors.Is(err, ErrGoatBitcoinHeader) {
+		t.Fatalf("expect pow error, got %v", err)
+	}
+
+	for i, raw := range [][]byte{btcHeader1, btcHeader2} {
+		height, err := AppendBitcoinHeader(config, statedb, raw)
+		if err != nil {
+			t.Fatalf("header %d: %v", i+1, err)
+		}
+		if height != uint64(i+1) {
+			t.Fatalf("header %d: height mismatch %d", i+1, height)
+		}
+	}
+	if tip, ok := ReadBitcoinTip(config, statedb); !ok || tip != 2 {
+		t.Fatalf("tip mismatch: %d %v", tip, ok)
+	}
+	if got := ReadBitcoinHeader(config, statedb, 1); !bytes.Equal(got, btcHeader1) {
+		t.Errorf("header 1 mismatch: %x", got)
+	}
+	for _, height := range []uint64{0, 3} {
+		if got := ReadBitcoinHeader(config, statedb, height); got != nil {
+			t.Errorf("unexpected header at %d: %x", height, got)
+		}
+	}
+	if _, err := AppendBitcoinHeader(config, statedb, btcHeader2); !errors.Is(err, ErrGoatBitcoinHeader) {
+		t.Fatalf("expect error for the duplicate header, got %v", err)
+	}
+}
+
+func TestVerifyDepositProof(t *testing.T) {
+	var (
+		startHash = common.Hash{0x1}
+		config    = &params.GoatConfig{Bitcoin: &params.GoatBitcoinConfig{Network: "regtest", StartHeight: 100, StartHash: startHash}}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+
+	// a tx with 1 input and 2 outputs of 1000 and 2000 satoshi
+	var rawTx []byte
+	rawTx = binary.LittleEndian.AppendUint32(rawTx, 2)
+	rawTx = append(rawTx, 1)
+	rawTx = append(rawTx, make([]byte, 36)...)
+	rawTx = append(rawTx, 0, 0xff, 0xff, 0xff, 0xff)
+	rawTx = append(rawTx, 2)
+	rawTx = binary.LittleEndian.AppendUint64(rawTx, 1000)
+	rawTx = append(rawTx, 1, 0x51)
+	rawTx = binary.LittleEndian.AppendUint64(rawTx, 2000)
+	rawTx = append(rawTx, 1, 0x51)
+	rawTx = binary.LittleEndian.AppendUint32(rawTx, 0)
+
+	var (
+		coinbase = goattypes.DoubleSha256([]byte("coinbase"))
+		txid     = goattypes.DoubleSha256(rawTx)
+		root     = goattypes.DoubleSha256(append(coinbase.Bytes(), txid.Bytes()...))
+		header   = &goattypes.BitcoinHeader{Version: 4, PrevBlock: startHash, MerkleRoot: root, Timestamp: 1700000000, Bits: 0x207fffff}
+	)
+	for goattypes.HashToBig(header.Hash()).Cmp(goattypes.CompactToBig(header.Bits)) > 0 {
+		header.Nonce++
+	}
+	if _, err := AppendBitcoinHeader(config, statedb, header.Encode()); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := &goattypes.DepositProofTx{
+		Txid: txid, TxOut: 1, Target: common.Address{0x2}, Amount: big.NewInt(2000 * 1e10),
+		Height: 101, TxIndex: 1, Branch: []common.Hash{coinbase}, RawTx: rawTx,
+	}
+	if err := VerifyDepositProof(config, statedb, valid); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []func(tx *goattypes.DepositProofTx){
+		func(tx *goattypes.DepositProofTx) { tx.Amount = big.NewInt(1000 * 1e10) },
+		func(tx *goattypes.DepositProofTx) { tx.TxOut = 2 },
+		func(tx *goattypes.DepositProofTx) { tx.TxIndex = 0 },
+		func(tx *goattypes.DepositProofTx) { tx.TxIndex = 3 },
+		func(tx *goattypes.DepositProofTx) { tx.Height = 102 },
+		func(tx *goattypes.DepositProofTx) { tx.Txid = coinbase },
+		func(tx *goattypes.DepositProofTx) { tx.Branch = nil },
+	}
+	for i, mutate := range tests {
+		tx := valid.Copy().(*goattypes.DepositProofTx)
+		mutate(tx)
+		if err := VerifyDepositProof(config, statedb, tx); !errors.Is(err, ErrGoatDepositProof) {
+			t.Errorf("test %d: expect deposit proof error, got %v", i, err)
+		}
+	}
+	// the deposit block needs the confirmations
+	config.Bitcoin.Confirmations = 2
+	if err := VerifyDepositProof(config, statedb, valid); !errors.Is(err, ErrGoatDepositProof) {
+		t.Fatalf("expect error for the unconfirmed block, got %v", err)
+	}
+	if _, err := AppendBitcoinHeader(config, statedb, mineRegtestHeader(header.Hash(), 1).Encode()); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyDepositProof(config, statedb, valid); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// mineRegtestHeader returns a regtest header on top of the parent
+func mineRegtestHeader(parent common.Hash, seed byte) *goattypes.BitcoinHeader {
+	header := &goattypes.BitcoinHeader{Version: 4, PrevBlock: parent, MerkleRoot: common.Hash{seed}, Timestamp: 1700000000, Bits: 0x207fffff}
+	for goattypes.HashToBig(header.Hash()).Cmp(goattypes.CompactToBig(header.Bits)) > 0 {
+		header.Nonce++
+	}
+	return header
+}
+
+func TestAppendBitcoinHeaderReorg(t *testing.T) {
+	var (
+		startHash = common.Hash{0x1}
+		config    = &params.GoatConfig{Bitcoin: &params.GoatBitcoinConfig{Network: "regtest", StartHeight: 100, StartHash: startHash}}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+
+	var (
+		a1 = mineRegtestHeader(startHash, 1)
+		a2 = mineRegtestHeader(a1.Hash(), 2)
+		b2 = mineRegtestHeader(a1.Hash(), 3)
+		b3 = mineRegtestHeader(b2.Hash(), 4)
+		a3 = mineRegtestHeader(a2.Hash(), 5)
+		a4 = mineRegtestHeader(a3.Hash(), 6)
+	)
+	checkChain := func(name string, chain ...*goattypes.BitcoinHeader) {
+		t.Helper()
+		tip, ok := ReadBitcoinTip(config, statedb)
+		if !ok || tip != 100+uint64(len(chain)) {
+			t.Fatalf("%s: tip mismatch: %d %v", name, tip, ok)
+		}
+		for i, header := range chain {
+			if got := ReadBitcoinHeader(config, statedb, 101+uint64(i)); !bytes.Equal(got, header.Encode()) {
+				t.Fatalf("%s: header %d mismatch", name, 101+i)
+			}
+		}
+		if got := ReadBitcoinHeader(config, statedb, 101+uint64(len(chain))); got != nil {
+			t.Fatalf("%s: unexpected header above the tip", name)
+		}
+	}
+	for _, test := range []struct {
+		name   string
+		header *goattypes.BitcoinHeader
+		height uint64
+		chain  []*goattypes.BitcoinHeader
+	}{
+		{"extend", a1, 101, []*goattypes.BitcoinHeader{a1}},
+		{"extend", a2, 102, []*goattypes.BitcoinHeader{a1, a2}},
+		// the first appended branch wins the tie
+		{"side", b2, 102, []*goattypes.BitcoinHeader{a1, a2}},
+		{"reorg", b3, 103, []*goattypes.BitcoinHeader{a1, b2, b3}},
+		{"side", a3, 103, []*goattypes.BitcoinHeader{a1, b2, b3}},
+		{"reorg back", a4, 104, []*goattypes.BitcoinHeader{a1, a2, a3, a4}},
+	} {
+		height, err := AppendBitcoinHeader(config, statedb, test.header.Encode())
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if height != test.height {
+			t.Fatalf("%s: height mismatch: have %d want %d", test.name, height, test.height)
+		}
+		checkChain(test.name, test.chain...)
+	}
+
+	if _, err := AppendBitcoinHeader(config, statedb, b3.Encode()); !errors.Is(err, ErrGoatBitcoinHeader) {
+		t.Fatalf("expect error for the duplicate side header, got %v", err)
+	}
+	if _, err := AppendBitcoinHeader(config, statedb, mineRegtestHeader(common.Hash{0x2}, 7).Encode()); !errors.Is(err, ErrGoatBitcoinHeader) {
+		t.Fatalf("expect error for the unknown prev block, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// ValidateGoatBody checks the goat txs at the beginning of the block against the
+// goat tx count and root in the header extra, and the requests against the requests
+// hash in the header. It doesn't need the state or the ancestors.
+func ValidateGoatBody(config *params.GoatConfig, block *types.Block) error {
+	header := block.Header()
+	extra, err := types.DecodeGoatHeaderExtra(config.HeaderExtraVersion(header.Time), header.Extra)
+	if err != nil {
+		return fmt.Errorf("no goat tx root found (block %d): %w", block.Number(), err)
+	}
+
+	goatTxLen, goatTxRoot := int(extra.TxCount), extra.TxRoot
+	if l := block.Transactions().Len(); l < goatTxLen {
+		return fmt.Errorf("txs length(%d) is less than goat tx length %d", l, goatTxLen)
+	}
+	if limit := config.GoatTxLimitAt(header.Time); uint64(goatTxLen) > limit {
+		return fmt.Errorf("goat tx length %d exceeds the limit %d", goatTxLen, limit)
+	}
+	if hash := types.DeriveSha(block.Transactions()[:goatTxLen], trie.NewStackTrie(nil)); hash != goatTxRoot {
+		return fmt.Errorf("goat tx root hash mismatch (header value %x, calculated %x)", goatTxRoot, hash)
+	}
+	if len(block.Withdrawals()) > 0 {
+		return errors.New("withdrawals not allowed for goat-geth")
+	}
+
+	for i, tx := range block.Transactions() {
+		if i < goatTxLen {
+			if !tx.IsGoatTx() {
+				return fmt.Errorf("transaction %d should be goat tx", i)
+			}
+			if tx.To() == nil {
+				return fmt.Errorf("goat tx %d should have receiver address", i)
+			}
+		} else {
+			if tx.IsGoatTx() {
+				return fmt.Errorf("transaction %d should not be goat tx", i)
+			}
+			if tx.Type() == types.BlobTxType {
+				return fmt.Errorf("blob transaction %d is not allowed", i)
+			}
+		}
+	}
+	// the first nonce of every executor is checked by the state transition
+	if err := VerifyGoatTxs(config, block.Transactions()[:goatTxLen], nil); err != nil {
+		return err
+	}
+
+	if header.RequestsHash != nil {
+		if block.Requests() == nil {
+			return errors.New("missing requests in block body")
+		}
+		if hash := types.DeriveSha(block.Requests(), trie.NewStackTrie(nil)); hash != *header.RequestsHash {
+			return fmt.Errorf("requests root hash mismatch (header value %x, calculated %x)", *header.RequestsHash, hash)
+		}
+	} else if block.Requests() != nil {
+		return errors.New("requests present in block body")
+	}
+	return nil
+}
+
+// VerifyGoatBlock re-derives the goat requests of a stored block from its receipts,
+// and checks them against the requests hash in the header, along with the body checks
+// of ValidateGoatBody. It's an offline check, the block is not re-executed.
+func VerifyGoatBlock(config *params.ChainConfig, block *types.Block, receipts types.Receipts) error {
+	if config.Goat == nil {
+		return errors.New("not a goat chain")
+	}
+	if err := ValidateGoatBody(config.Goat, block); err != nil {
+		return err
+	}
+	header := block.Header()
+	if header.RequestsHash == nil {
+		return errors.New("missing requests hash in header")
+	}
+	if l := len(receipts); l != block.Transactions().Len() {
+		return fmt.Errorf("receipts length %d mismatches txs length %d", l, block.Transactions().Len())
+	}
+
+	var logs []*types.Log
+	for _, receipt := range receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+	fees := CalcGoatBlockFees(header, block.Transactions(), receipts)
+	_, revenue := GoatFoundationSplit(config.Goat, header.Time, fees.Total())
+	requests, err := ProcessGoatRequests(revenue, logs, config, header.Time)
+	if err != nil {
+		return err
+	}
+	if hash := types.DeriveSha(requests, trie.NewStackTrie(nil)); hash != *header.RequestsHash {
+		return fmt.Errorf("derived requests root hash mismatch (header value %x, calculated %x)", *header.RequestsHash, hash)
+	}
+	return nil
+}
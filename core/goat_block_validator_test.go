@@ -0,0 +1,57 @@
+package core
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+func TestVerifyGoatBlock(t *testing.T) {
+	var (
+		config = &params.ChainConfig{ChainID: big.NewInt(1), Goat: &params.GoatConfig{}}
+		goatTx = types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 0,
+			&goattypes.DepositTx{Txid: common.Hash{0x1}, Target: common.Address{0x1}, Amount: big.NewInt(1)}))
+		cancel = &types.Log{Address: goattypes.BridgeContract, Topics: []common.Hash{types.GoatCancel1Topic, {31: 1}}}
+	)
+	logs := []*types.Log{cancel}
+	requests, err := ProcessGoatRequests(new(big.Int), logs, config, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := func(txRoot common.Hash) *types.Block {
+		extra, err := (&types.GoatHeaderExtra{TxCount: 1, TxRoot: txRoot}).Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := &types.Header{Number: big.NewInt(1), Extra: extra}
+		return types.NewBlock(header, &types.Body{Transactions: []*types.Transaction{goatTx}, Requests: requests}, nil, trie.NewStackTrie(nil))
+	}
+	txRoot := types.DeriveSha(types.Transactions{goatTx}, trie.NewStackTrie(nil))
+
+	tests := []struct {
+		block    *types.Block
+		receipts types.Receipts
+		err      string
+	}{
+		{block(txRoot), types.Receipts{{Logs: logs}}, ""},
+		{block(common.Hash{0x1}), types.Receipts{{Logs: logs}}, "goat tx root hash mismatch"},
+		{block(txRoot), types.Receipts{{}}, "derived requests root hash mismatch"},
+		{block(txRoot), types.Receipts{{Logs: logs}, {}}, "receipts length"},
+	}
+	for i, test := range tests {
+		err := VerifyGoatBlock(config, test.block, test.receipts)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("test %d: unexpected error: %v", i, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("test %d: error mismatch: have %v want %q", i, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// The kinds of the goat tx failures, they can be checked with errors.Is on the GoatTxError
+var (
+	// ErrGoatTxReverted is returned if the system contract call of the goat tx is failed
+	ErrGoatTxReverted = errors.New("goat tx reverted")
+
+	// ErrGoatDepositReturn is returned if the bridge doesn't return a valid tax for the deposit
+	ErrGoatDepositReturn = errors.New("invalid goat deposit return")
+
+	// ErrGoatDepositTax is returned if the deposit tax is larger than the deposit amount
+	ErrGoatDepositTax = errors.New("goat deposit tax larger than amount")
+
+	// ErrGoatMintAmount is returned if the amount of the deposit or the reward is not an uint256
+	ErrGoatMintAmount = errors.New("invalid goat mint amount")
+)
+
+// GoatTxError is returned if a goat tx is failed in the state transition
+type GoatTxError struct {
+	Kind   error  // one of the goat tx failure kinds
+	Index  int    // the index of the goat tx in the block, it's set when the tx is applied in a block
+	Err    error  // the vm error if the system contract call is failed
+	Revert []byte // the return data of the reverted call
+
+	detail string
+}
+
+func newGoatTxError(kind error, format string, args ...any) *GoatTxError {
+	return &GoatTxError{Kind: kind, detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *GoatTxError) Error() string { return "goat tx failed (" + e.detail + ")" }
+
+func (e *GoatTxError) Is(target error) bool { return target == e.Kind }
+
+func (e *GoatTxError) Unwrap() error { return e.Err }
+
+// RevertReason returns the decoded revert reason, it's empty if the revert data
+// is not an Error(string)
+func (e *GoatTxError) RevertReason() string {
+	reason, err := abi.UnpackRevert(e.Revert)
+	if err != nil {
+		return ""
+	}
+	return reason
+}
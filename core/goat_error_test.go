@@ -0,0 +1,34 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+func TestGoatTxError(t *testing.T) {
+	reverted := newGoatTxError(ErrGoatTxReverted, "to %s", common.Address{0x1})
+	reverted.Err = vm.ErrExecutionReverted
+	reverted.Revert = common.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"6e6f000000000000000000000000000000000000000000000000000000000000")
+
+	err := error(reverted)
+	if !errors.Is(err, ErrGoatTxReverted) || !errors.Is(err, vm.ErrExecutionReverted) || errors.Is(err, ErrGoatDepositTax) {
+		t.Errorf("error kind mismatch: %v", err)
+	}
+	if reason := reverted.RevertReason(); reason != "no" {
+		t.Errorf("revert reason mismatch: %q", reason)
+	}
+
+	tax := newGoatTxError(ErrGoatDepositTax, "tax is larger than deposit: deposit %d tax %d", 1, 2)
+	if !errors.Is(tax, ErrGoatDepositTax) || tax.RevertReason() != "" {
+		t.Errorf("error kind mismatch: %v", tax)
+	}
+	if want := "goat tx failed (tax is larger than deposit: deposit 1 tax 2)"; tax.Error() != want {
+		t.Errorf("error message mismatch: have %q want %q", tax.Error(), want)
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GoatGenesisSpec is the input of the goat devnet genesis builder
+//
+// The storage layouts of the system contracts depend on the contract release, so
+// the initial parameters are written to the slots given in the spec, the voters are
+// written as address[] and the initial bitcoin block hash as mapping(uint256 => bytes32).
+type GoatGenesisSpec struct {
+	ChainID   uint64             `json:"chainId"`
+	GasLimit  uint64             `json:"gasLimit"`
+	Timestamp uint64             `json:"timestamp"`
+	Faucet    *common.Address    `json:"faucet,omitempty"`
+	Goat      *params.GoatConfig `json:"goat,omitempty"`
+
+	GoatFoundation *GoatPredeploy `json:"goatFoundation,omitempty"`
+	Bridge         *GoatPredeploy `json:"bridge,omitempty"`
+	Locking        *GoatPredeploy `json:"locking,omitempty"`
+	Bitcoin        *GoatPredeploy `json:"bitcoin,omitempty"`
+	Relayer        *GoatPredeploy `json:"relayer,omitempty"`
+
+	Voters     []common.Address `json:"voters,omitempty"`     // The initial relayer voters
+	VotersSlot *common.Hash     `json:"votersSlot,omitempty"` // The slot of the voter array in the relayer contract
+
+	BtcStartHeight uint64       `json:"btcStartHeight"`          // The height of the initial bitcoin block
+	BtcStartHash   common.Hash  `json:"btcStartHash"`            // The initial bitcoin block hash, in the internal byte order
+	BtcHeightSlot  *common.Hash `json:"btcHeightSlot,omitempty"` // The slot of the start height in the bitcoin contract
+	BtcHashesSlot  *common.Hash `json:"btcHashesSlot,omitempty"` // The slot of the block hash mapping in the bitcoin contract
+
+	BridgeParams []*GoatStorageParam `json:"bridgeParams,omitempty"` // The initial bridge parameters
+
+	Alloc types.GenesisAlloc `json:"alloc,omitempty"` // The additional accounts
+}
+
+// GoatPredeploy is a system contract deployed in the genesis
+type GoatPredeploy struct {
+	Code    hexutil.Bytes               `json:"code"`
+	Balance *math.HexOrDecimal256       `json:"balance,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// GoatStorageParam is a contract parameter stored in the given slot, the name is
+// only for the readability of the spec
+type GoatStorageParam struct {
+	Name  string      `json:"name"`
+	Slot  common.Hash `json:"slot"`
+	Value common.Hash `json:"value"`
+}
+
+// ReadGoatGenesisSpec reads the goat genesis spec from the JSON file
+func ReadGoatGenesisSpec(path string) (*GoatGenesisSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := new(GoatGenesisSpec)
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("invalid goat genesis spec: %v", err)
+	}
+	return spec, nil
+}
+
+// DeveloperGoatGenesisBlock returns the goat devnet genesis with the system contracts
+// predeployed and initialized with the given spec.
+func DeveloperGoatGenesisBlock(spec *GoatGenesisSpec) (*Genesis, error) {
+	gasLimit := spec.GasLimit
+	if gasLimit == 0 {
+		gasLimit = params.GenesisGasLimit
+	}
+	genesis := DeveloperGenesisBlock(gasLimit, spec.Faucet)
+	genesis.Timestamp = spec.Timestamp
+	if spec.ChainID != 0 {
+		genesis.Config.ChainID = new(big.Int).SetUint64(spec.ChainID)
+	}
+
+	goat := new(params.GoatConfig)
+	if spec.Goat != nil {
+		cpy := *spec.Goat
+		goat = &cpy
+	}
+	if spec.BtcStartHash != (common.Hash{}) && goat.Bitcoin != nil && goat.Bitcoin.StartHash == (common.Hash{}) {
+		bitcoin := *goat.Bitcoin
+		bitcoin.StartHeight, bitcoin.StartHash = spec.BtcStartHeight, spec.BtcStartHash
+		goat.Bitcoin = &bitcoin
+	}
+	if err := goat.CheckConfig(); err != nil {
+		return nil, err
+	}
+	genesis.Config.Goat = goat
+
+	addrs := goat.SystemAddresses()
+	predeploys := []struct {
+		name string
+		addr common.Address
+		spec *GoatPredeploy
+	}{
+		{"goatFoundation", addrs.GoatFoundation, spec.GoatFoundation},
+		{"bridge", addrs.Bridge, spec.Bridge},
+		{"locking", addrs.Locking, spec.Locking},
+		{"bitcoin", addrs.Bitcoin, spec.Bitcoin},
+		{"relayer", addrs.Relayer, spec.Relayer},
+	}
+	for _, p := range predeploys {
+		if p.spec == nil {
+			continue
+		}
+		if len(p.spec.Code) == 0 {
+			return nil, fmt.Errorf("missing code of the %s contract", p.name)
+		}
+		account := types.Account{Nonce: 1, Code: p.spec.Code, Balance: new(big.Int), Storage: make(map[common.Hash]common.Hash)}
+		if p.spec.Balance != nil {
+			account.Balance = (*big.Int)(p.spec.Balance)
+		}
+		for k, v := range p.spec.Storage {
+			account.Storage[k] = v
+		}
+		genesis.Alloc[p.addr] = account
+	}
+
+	storage := func(name string, addr common.Address) (map[common.Hash]common.Hash, error) {
+		account, ok := genesis.Alloc[addr]
+		if !ok {
+			return nil, fmt.Errorf("the %s contract is not predeployed", name)
+		}
+		return account.Storage, nil
+	}
+	if len(spec.Voters) != 0 {
+		if spec.VotersSlot == nil {
+			return nil, errors.New("missing the voters slot")
+		}
+		s, err := storage("relayer", addrs.Relayer)
+		if err != nil {
+			return nil, err
+		}
+		// address[]: the length is at the slot, and the items start from keccak256(slot)
+		s[*spec.VotersSlot] = common.BigToHash(big.NewInt(int64(len(spec.Voters))))
+		base := crypto.Keccak256Hash(spec.VotersSlot[:]).Big()
+		for i, voter := range spec.Voters {
+			s[common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i))))] = common.BytesToHash(voter[:])
+		}
+	}
+	if spec.BtcHeightSlot != nil || spec.BtcHashesSlot != nil {
+		s, err := storage("bitcoin", addrs.Bitcoin)
+		if err != nil {
+			return nil, err
+		}
+		height := common.BigToHash(new(big.Int).SetUint64(spec.BtcStartHeight))
+		if spec.BtcHeightSlot != nil {
+			s[*spec.BtcHeightSlot] = height
+		}
+		// mapping(uint256 => bytes32): the value is at keccak256(key . slot)
+		if spec.BtcHashesSlot != nil {
+			s[crypto.Keccak256Hash(height[:], spec.BtcHashesSlot[:])] = spec.BtcStartHash
+		}
+	}
+	if len(spec.BridgeParams) != 0 {
+		s, err := storage("bridge", addrs.Bridge)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range spec.BridgeParams {
+			s[p.Slot] = p.Value
+		}
+	}
+
+	for addr, account := range spec.Alloc {
+		if _, exist := genesis.Alloc[addr]; exist {
+			return nil, fmt.Errorf("duplicate genesis account %s", addr)
+		}
+		genesis.Alloc[addr] = account
+	}
+	// the balance is required by the genesis JSON, fill the missing ones so the
+	// written genesis can be loaded by geth init
+	for addr, account := range genesis.Alloc {
+		if account.Balance == nil {
+			account.Balance = new(big.Int)
+			genesis.Alloc[addr] = account
+		}
+	}
+	return genesis, nil
+}
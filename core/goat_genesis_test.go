@@ -0,0 +1,99 @@
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+func TestDeveloperGoatGenesisBlock(t *testing.T) {
+	input := `{
+		"chainId": 2345,
+		"goat": {"gfBasePoint": 100},
+		"bridge": {"code": "0x60206000f3", "storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}},
+		"bitcoin": {"code": "0x00"},
+		"relayer": {"code": "0x00"},
+		"voters": ["0x1000000000000000000000000000000000000001", "0x1000000000000000000000000000000000000002"],
+		"votersSlot": "0x0000000000000000000000000000000000000000000000000000000000000003",
+		"btcStartHeight": 100,
+		"btcStartHash": "0x6fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000",
+		"btcHeightSlot": "0x0000000000000000000000000000000000000000000000000000000000000004",
+		"btcHashesSlot": "0x0000000000000000000000000000000000000000000000000000000000000005",
+		"bridgeParams": [{"name": "depositTax", "slot": "0x0000000000000000000000000000000000000000000000000000000000000006", "value": "0x0000000000000000000000000000000000000000000000000000000000000007"}]
+	}`
+	spec := new(GoatGenesisSpec)
+	if err := json.Unmarshal([]byte(input), spec); err != nil {
+		t.Fatal(err)
+	}
+	genesis, err := DeveloperGoatGenesisBlock(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesis.Config.Goat == nil || genesis.Config.ChainID.Uint64() != 2345 {
+		t.Fatalf("unexpected chain config: %v", genesis.Config)
+	}
+
+	bridge := genesis.Alloc[goattypes.BridgeContract].Storage
+	if bridge[common.BigToHash(big.NewInt(1))] != common.BigToHash(big.NewInt(2)) {
+		t.Errorf("bridge storage mismatch: %v", bridge)
+	}
+	if bridge[common.BigToHash(big.NewInt(6))] != common.BigToHash(big.NewInt(7)) {
+		t.Errorf("bridge params mismatch: %v", bridge)
+	}
+
+	relayer := genesis.Alloc[goattypes.RelayerContract].Storage
+	slot := common.BigToHash(big.NewInt(3))
+	if relayer[slot] != common.BigToHash(big.NewInt(2)) {
+		t.Errorf("voters length mismatch: %v", relayer[slot])
+	}
+	second := common.BigToHash(new(big.Int).Add(crypto.Keccak256Hash(slot[:]).Big(), big.NewInt(1)))
+	if relayer[second] != common.BytesToHash(spec.Voters[1][:]) {
+		t.Errorf("voter mismatch: %v", relayer[second])
+	}
+
+	bitcoin := genesis.Alloc[goattypes.BitcoinContract].Storage
+	height := common.BigToHash(big.NewInt(100))
+	if bitcoin[common.BigToHash(big.NewInt(4))] != height {
+		t.Errorf("bitcoin start height mismatch")
+	}
+	if bitcoin[crypto.Keccak256Hash(height[:], common.BigToHash(big.NewInt(5)).Bytes())] != spec.BtcStartHash {
+		t.Errorf("bitcoin start hash mismatch")
+	}
+
+	// the genesis can be written to the file and initialized
+	enc, err := json.Marshal(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := new(Genesis)
+	if err := json.Unmarshal(enc, dec); err != nil {
+		t.Fatal(err)
+	}
+	db := rawdb.NewMemoryDatabase()
+	block, err := dec.Commit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Hash() != genesis.ToBlock().Hash() {
+		t.Errorf("genesis hash mismatch after the JSON round trip")
+	}
+}
+
+func TestDeveloperGoatGenesisBlockInvalid(t *testing.T) {
+	specs := []*GoatGenesisSpec{
+		{Bridge: &GoatPredeploy{}},        // missing code
+		{Voters: []common.Address{{0x1}}}, // missing relayer
+		{Relayer: &GoatPredeploy{Code: []byte{0}}, Voters: []common.Address{{0x1}}}, // missing slot
+	}
+	for i, spec := range specs {
+		if _, err := DeveloperGoatGenesisBlock(spec); err == nil {
+			t.Errorf("spec %d: expect error", i)
+		}
+	}
+}
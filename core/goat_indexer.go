@@ -0,0 +1,275 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// goatWithdrawalSkippedKey is the key in the index table of the last block skipped
+// by the withdrawal index for its pruned history, the value is the number plus one.
+var goatWithdrawalSkippedKey = []byte("skipped")
+
+// goatWithdrawalTailLimit is the max number of the blocks after the finished index
+// sections scanned by a lookup, the result is incomplete if the indexer lags behind.
+const goatWithdrawalTailLimit = 2 * (params.GoatWithdrawalBlocks + params.GoatWithdrawalConfirms)
+
+type goatTailKey struct {
+	hash   common.Hash
+	bridge common.Address
+}
+
+// goatTailEventsCache caches the withdrawal events of the blocks after the finished
+// index sections by the block hash and the bridge address, they're scanned by every
+// lookup until they're indexed.
+var goatTailEventsCache = lru.NewCache[goatTailKey, map[uint64][]*types.GoatWithdrawalEvent](int(goatWithdrawalTailLimit))
+
+// GoatWithdrawalIndexer implements a core.ChainIndexer, building up an index from
+// the bridge withdrawal id to the blocks and transactions that touched it.
+type GoatWithdrawalIndexer struct {
+	db     ethdb.Database     // database instance to write index data into
+	config *params.GoatConfig // goat chain parameters
+	batch  ethdb.Batch        // batch of the section being processed currently
+
+	section     uint64 // the section being processed currently
+	skipped     uint64 // the number of the blocks skipped in the section for the pruned history
+	lastSkipped uint64 // the last block skipped in the section
+}
+
+// NewGoatWithdrawalIndexer returns a chain indexer that generates the withdrawal
+// lifecycle index for the canonical chain.
+func NewGoatWithdrawalIndexer(db ethdb.Database, config *params.GoatConfig, size, confirms uint64) *ChainIndexer {
+	backend := &GoatWithdrawalIndexer{db: db, config: config}
+	table := rawdb.NewTable(db, string(rawdb.GoatWithdrawalIndexPrefix))
+
+	return NewChainIndexer(db, table, backend, size, confirms, 0, "goatwithdrawal")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new section.
+func (g *GoatWithdrawalIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	g.batch = g.db.NewBatch()
+	g.section, g.skipped, g.lastSkipped = section, 0, 0
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the withdrawal events of
+// a new block into the index. The block whose body or receipts are pruned is
+// skipped, and the index is marked incomplete up to it.
+func (g *GoatWithdrawalIndexer) Process(ctx context.Context, header *types.Header) error {
+	hash, number := header.Hash(), header.Number.Uint64()
+	body, receipts, ok := readGoatWithdrawalBlock(g.db, g.config, header)
+	if !ok {
+		g.skipped, g.lastSkipped = g.skipped+1, number
+		return nil
+	}
+	events := GoatWithdrawalEvents(g.config, hash, number, body.Transactions, receipts)
+	for id, list := range events {
+		rawdb.WriteGoatWithdrawalEvents(g.batch, id, number, hash, list)
+	}
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, writing out the section into the
+// database.
+func (g *GoatWithdrawalIndexer) Commit() error {
+	if err := g.batch.Write(); err != nil {
+		return err
+	}
+	if g.skipped == 0 {
+		return nil
+	}
+	log.Warn("Skipped goat withdrawal index blocks without history", "section", g.section, "blocks", g.skipped, "last", g.lastSkipped)
+	if last, ok := ReadGoatWithdrawalSkipped(g.db); ok && last >= g.lastSkipped {
+		return nil
+	}
+	return rawdb.NewTable(g.db, string(rawdb.GoatWithdrawalIndexPrefix)).Put(goatWithdrawalSkippedKey, binary.BigEndian.AppendUint64(nil, g.lastSkipped+1))
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (g *GoatWithdrawalIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// readGoatWithdrawalBlock reads the block body and the receipts to derive the withdrawal
+// events, the receipts are only required if the bridge contract emitted any log in the
+// block. The false is returned if they're missing, e.g. pruned.
+func readGoatWithdrawalBlock(db ethdb.Reader, config *params.GoatConfig, header *types.Header) (*types.Body, types.Receipts, bool) {
+	hash, number := header.Hash(), header.Number.Uint64()
+	body := rawdb.ReadBody(db, hash, number)
+	if body == nil {
+		return nil, nil, false
+	}
+	var receipts types.Receipts
+	if len(body.Transactions) != 0 && types.BloomLookup(header.Bloom, config.SystemAddresses().Bridge) {
+		receipts = rawdb.ReadRawReceipts(db, hash, number)
+		if len(receipts) != len(body.Transactions) {
+			return nil, nil, false
+		}
+	}
+	return body, receipts, true
+}
+
+// GoatWithdrawalEvents groups the bridge withdrawal events of a block by the withdrawal id
+func GoatWithdrawalEvents(config *params.GoatConfig, hash common.Hash, number uint64, txs types.Transactions, receipts types.Receipts) map[uint64][]*types.GoatWithdrawalEvent {
+	events := make(map[uint64][]*types.GoatWithdrawalEvent)
+	for _, ev := range GoatBridgeEvents(config, hash, number, txs, receipts) {
+		events[ev.Id()] = append(events[ev.Id()], ev)
+	}
+	return events
+}
+
+// GoatBridgeEvents returns the bridge withdrawal events of a block in the block order
+// The withdrawal, rbf and cancel1 events come from the bridge contract logs,
+// the cancel2 and paid events come from the goat transactions
+func GoatBridgeEvents(config *params.GoatConfig, hash common.Hash, number uint64, txs types.Transactions, receipts types.Receipts) []*types.GoatWithdrawalEvent {
+	var (
+		bridge = config.SystemAddresses().Bridge
+		events []*types.GoatWithdrawalEvent
+	)
+	for i, tx := range txs {
+		newEvent := func() *types.GoatWithdrawalEvent {
+			return &types.GoatWithdrawalEvent{BlockHash: hash, BlockNumber: number, TxHash: tx.Hash(), TxIndex: uint64(i)}
+		}
+
+		if goatTx := tx.GoatTx(); goatTx != nil {
+			switch v := goatTx.Inner().(type) {
+			case *goattypes.Cancel2Tx:
+				if v.Id.IsUint64() {
+					ev := newEvent()
+					ev.Cancel2 = v
+					events = append(events, ev)
+				}
+			case *goattypes.PaidTx:
+				if v.Id.IsUint64() {
+					ev := newEvent()
+					ev.Paid = v
+					events = append(events, ev)
+				}
+			}
+			continue
+		}
+
+		if i >= len(receipts) {
+			continue
+		}
+		for _, l := range receipts[i].Logs {
+			if l.Address != bridge {
+				continue
+			}
+			reqs, err := types.GetBridgeRequests(l.Topics, l.Data)
+			if err != nil {
+				log.Warn("Failed to decode bridge event", "number", number, "tx", tx.Hash(), "err", err)
+				continue
+			}
+			for _, req := range reqs {
+				ev := newEvent()
+				switch v := req.Inner().(type) {
+				case *types.BridgeWithdrawal:
+					ev.Withdrawal = v
+				case *types.ReplaceByFee:
+					ev.ReplaceByFee = v
+				case *types.Cancel1:
+					ev.Cancel1 = v
+				default:
+					continue
+				}
+				events = append(events, ev)
+			}
+		}
+	}
+	return events
+}
+
+// goatWithdrawalIndexedBlocks returns the number of the leading blocks covered by
+// the finished sections of the withdrawal index.
+func goatWithdrawalIndexedBlocks(db ethdb.Database) uint64 {
+	data, _ := rawdb.NewTable(db, string(rawdb.GoatWithdrawalIndexPrefix)).Get([]byte("count"))
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data) * params.GoatWithdrawalBlocks
+}
+
+// ReadGoatWithdrawalSkipped returns the last block skipped by the withdrawal index
+// for its pruned history, the false is returned if no block is skipped.
+func ReadGoatWithdrawalSkipped(db ethdb.Database) (uint64, bool) {
+	data, _ := rawdb.NewTable(db, string(rawdb.GoatWithdrawalIndexPrefix)).Get(goatWithdrawalSkippedKey)
+	if len(data) != 8 || binary.BigEndian.Uint64(data) == 0 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data) - 1, true
+}
+
+// ReadGoatWithdrawalEvents retrieves all the events of a bridge withdrawal on the
+// canonical chain in the chain order. The events of the finished index sections
+// are read from the index, the blocks after them are not indexed yet and scanned
+// directly, so the result follows the chain head.
+//
+// The false is returned if the events are possibly incomplete, since the blocks
+// without history are skipped or the unindexed blocks are too many to scan.
+func ReadGoatWithdrawalEvents(db ethdb.Database, config *params.GoatConfig, id uint64) ([]*types.GoatWithdrawalEvent, bool) {
+	var (
+		indexed  = goatWithdrawalIndexedBlocks(db)
+		complete = true
+		events   []*types.GoatWithdrawalEvent
+	)
+	// The data of an unfinished or rolled back section might be left in the index
+	for _, ev := range rawdb.ReadGoatWithdrawalEvents(db, id) {
+		if ev.BlockNumber < indexed {
+			events = append(events, ev)
+		}
+	}
+	if head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadBlockHash(db)); head != nil {
+		from := indexed
+		if *head >= from+goatWithdrawalTailLimit {
+			from, complete = *head-goatWithdrawalTailLimit+1, false
+		}
+		bridge := config.SystemAddresses().Bridge
+		for number := from; number <= *head; number++ {
+			hash := rawdb.ReadCanonicalHash(db, number)
+			if cached, ok := goatTailEventsCache.Get(goatTailKey{hash, bridge}); ok {
+				events = append(events, cached[id]...)
+				continue
+			}
+			header := rawdb.ReadHeader(db, hash, number)
+			if header == nil {
+				break
+			}
+			body, receipts, ok := readGoatWithdrawalBlock(db, config, header)
+			if !ok {
+				complete = false
+				continue
+			}
+			all := GoatWithdrawalEvents(config, hash, number, body.Transactions, receipts)
+			goatTailEventsCache.Add(goatTailKey{hash, bridge}, all)
+			events = append(events, all[id]...)
+		}
+	}
+	// The events can't precede the withdrawal, so the skipped blocks before it don't matter
+	if last, ok := ReadGoatWithdrawalSkipped(db); ok {
+		if len(events) == 0 || events[0].Withdrawal == nil || events[0].BlockNumber <= last {
+			complete = false
+		}
+	}
+	return events, complete
+}
+
+// ReadGoatWithdrawalLifecycle retrieves the current status of a bridge withdrawal
+// on the canonical chain, it returns nil if the withdrawal is not found. The false
+// is returned if the withdrawal history is possibly incomplete.
+func ReadGoatWithdrawalLifecycle(db ethdb.Database, config *params.GoatConfig, id uint64) (*types.GoatWithdrawalLifecycle, bool) {
+	events, complete := ReadGoatWithdrawalEvents(db, config, id)
+	lc := types.NewGoatWithdrawalLifecycle(id, events)
+	if lc != nil {
+		lc.Incomplete = !complete
+	}
+	return lc, complete
+}
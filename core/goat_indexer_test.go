@@ -0,0 +1,159 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newGoatWithdrawalTestChain generates a chain touching the withdrawal 1 in the first
+// index section and after it, and the withdrawal 2 after the first section only
+func newGoatWithdrawalTestChain() (*Genesis, []*types.Block, func(gen *BlockGen, id uint64)) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = params.TestChainConfig
+		signer = types.LatestSigner(config)
+		gspec  = &Genesis{
+			Config: config,
+			Alloc: types.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// emits the cancel1 event with the withdrawal id in the calldata
+				goattypes.BridgeContract: {Code: append(append([]byte{0x60, 0x00, 0x35, 0x7f}, types.GoatCancel1Topic[:]...), 0x60, 0x00, 0x60, 0x00, 0xa2, 0x00)},
+			},
+		}
+		sections = params.GoatWithdrawalBlocks
+		head     = sections + params.GoatWithdrawalConfirms + 10
+		cancel1  = func(gen *BlockGen, id uint64) {
+			tx := types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    gen.TxNonce(addr),
+				To:       &goattypes.BridgeContract,
+				Gas:      100000,
+				GasPrice: gen.BaseFee(),
+				Data:     common.BigToHash(new(big.Int).SetUint64(id)).Bytes(),
+			})
+			gen.AddTx(tx)
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), int(head), func(i int, gen *BlockGen) {
+		switch gen.Number().Uint64() {
+		case 10, sections + 20:
+			cancel1(gen, 1)
+		case sections + 30:
+			cancel1(gen, 2)
+		}
+	})
+	return gspec, blocks, cancel1
+}
+
+// indexGoatWithdrawalTestChain imports the blocks and waits for the first index section,
+// the prune callback is invoked before the indexer starts
+func indexGoatWithdrawalTestChain(t *testing.T, gspec *Genesis, blocks []*types.Block, prune func(db ethdb.Database)) (ethdb.Database, *BlockChain) {
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(chain.Stop)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	if prune != nil {
+		prune(db)
+	}
+	indexer := NewGoatWithdrawalIndexer(db, nil, params.GoatWithdrawalBlocks, params.GoatWithdrawalConfirms)
+	t.Cleanup(func() { indexer.Close() })
+	indexer.Start(chain)
+
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		if count, _, _ := indexer.Sections(); count == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the section is not indexed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return db, chain
+}
+
+func TestGoatWithdrawalIndexer(t *testing.T) {
+	var (
+		gspec, blocks, cancel1 = newGoatWithdrawalTestChain()
+		db, chain              = indexGoatWithdrawalTestChain(t, gspec, blocks, nil)
+		sections               = params.GoatWithdrawalBlocks
+		head                   = uint64(len(blocks))
+	)
+	check := func(id uint64, numbers ...uint64) {
+		t.Helper()
+		events, complete := ReadGoatWithdrawalEvents(db, nil, id)
+		if !complete {
+			t.Fatalf("withdrawal %d: events are incomplete", id)
+		}
+		if len(events) != len(numbers) {
+			t.Fatalf("withdrawal %d: events length mismatch: have %d, want %d", id, len(events), len(numbers))
+		}
+		for i, ev := range events {
+			if ev.BlockNumber != numbers[i] || ev.BlockHash != rawdb.ReadCanonicalHash(db, numbers[i]) {
+				t.Fatalf("withdrawal %d: event %d block mismatch: have %d, want %d", id, i, ev.BlockNumber, numbers[i])
+			}
+			if ev.Cancel1 == nil || ev.Cancel1.Id != id {
+				t.Fatalf("withdrawal %d: event %d mismatch: %+v", id, i, ev)
+			}
+		}
+	}
+	// the blocks after the section are read from the chain
+	if events := rawdb.ReadGoatWithdrawalEvents(db, 1); len(events) != 1 || events[0].BlockNumber != 10 {
+		t.Fatalf("indexed events mismatch: %v", events)
+	}
+	check(1, 10, sections+20)
+	check(2, sections+30)
+	if lc, _ := ReadGoatWithdrawalLifecycle(db, nil, 2); lc == nil || lc.Incomplete || lc.Status != types.GoatWithdrawalCancel1 || len(lc.Events) != 1 {
+		t.Fatalf("lifecycle mismatch: %+v", lc)
+	}
+
+	// reorg out the blocks after the section
+	_, fork, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), int(head)+5, func(i int, gen *BlockGen) {
+		if gen.Number().Uint64() == 10 {
+			cancel1(gen, 1)
+		}
+	})
+	if _, err := chain.InsertChain(fork[sections:]); err != nil {
+		t.Fatal(err)
+	}
+	check(1, 10)
+	check(2)
+}
+
+func TestGoatWithdrawalIndexerPruned(t *testing.T) {
+	var (
+		gspec, blocks, _ = newGoatWithdrawalTestChain()
+		sections         = params.GoatWithdrawalBlocks
+	)
+	// the receipts of the block 10 with the bridge log are pruned
+	db, _ := indexGoatWithdrawalTestChain(t, gspec, blocks, func(db ethdb.Database) {
+		rawdb.DeleteReceipts(db, blocks[9].Hash(), 10)
+	})
+	if last, ok := ReadGoatWithdrawalSkipped(db); !ok || last != 10 {
+		t.Fatalf("skipped block mismatch: have %d %v, want 10", last, ok)
+	}
+	if events, complete := ReadGoatWithdrawalEvents(db, nil, 1); complete || len(events) != 1 || events[0].BlockNumber != sections+20 {
+		t.Fatalf("withdrawal 1: events mismatch: complete %v, %v", complete, events)
+	}
+	if lc, complete := ReadGoatWithdrawalLifecycle(db, nil, 2); complete || lc == nil || !lc.Incomplete {
+		t.Fatalf("withdrawal 2: lifecycle mismatch: complete %v, %+v", complete, lc)
+	}
+	if lc, complete := ReadGoatWithdrawalLifecycle(db, nil, 3); complete || lc != nil {
+		t.Fatalf("withdrawal 3: lifecycle mismatch: complete %v, %+v", complete, lc)
+	}
+}
@@ -1,62 +1,164 @@
 package core
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/types/goattypes/btcaddr"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 )
 
 var (
-	gfBasePoint    = big.NewInt(200)
-	gfMaxBasePoint = big.NewInt(1e4)
+	gfMaxBasePoint = new(big.Int).SetUint64(params.GoatGfMaxBasePoint)
+
+	goatSkippedEventMeter      = metrics.NewRegisteredMeter("goat/events/skipped", nil)
+	goatInvalidWithdrawalMeter = metrics.NewRegisteredMeter("goat/withdrawals/invalid", nil)
 )
 
-func ProcessGoatFoundationReward(statedb *state.StateDB, gasFees *big.Int) *big.Int {
-	if gasFees.BitLen() == 0 {
-		return new(big.Int)
+// GoatBlockFees is the gas fees of a goat block, they're all burnt in the state
+// transition and split into the foundation tax and the gas revenue at the end of the block
+type GoatBlockFees struct {
+	BurntFees *big.Int // the base fees of the gas used
+	Tips      *big.Int // the priority fees of the txs
+	BlobFees  *big.Int // the blob gas fees
+}
+
+// Total returns the total gas fees of the block
+func (f *GoatBlockFees) Total() *big.Int {
+	total := new(big.Int).Add(f.BurntFees, f.Tips)
+	return total.Add(total, f.BlobFees)
+}
+
+// CalcGoatBlockFees computes the gas fees of the block with the receipts of the txs,
+// it's shared by the block builder and the state processor
+func CalcGoatBlockFees(header *types.Header, txs types.Transactions, receipts types.Receipts) *GoatBlockFees {
+	fees := &GoatBlockFees{BurntFees: new(big.Int), Tips: new(big.Int), BlobFees: new(big.Int)}
+	if header.BaseFee != nil && header.GasUsed > 0 {
+		fees.BurntFees.Mul(header.BaseFee, new(big.Int).SetUint64(header.GasUsed))
+	}
+	if header.ExcessBlobGas != nil && header.BlobGasUsed != nil && *header.BlobGasUsed > 0 {
+		fees.BlobFees.SetUint64(*header.BlobGasUsed)
+		fees.BlobFees.Mul(fees.BlobFees, eip4844.CalcBlobFee(*header.ExcessBlobGas))
 	}
+	for i, tx := range txs {
+		gasUsed := receipts[i].GasUsed
+		if gasUsed == 0 { // the goat tx
+			continue
+		}
+		tip := new(big.Int).SetUint64(gasUsed)
+		fees.Tips.Add(fees.Tips, tip.Mul(tip, tx.EffectiveGasTipValue(header.BaseFee)))
+	}
+	return fees
+}
 
-	// foundation tax 2%
-	tax := new(big.Int).Mul(gasFees, gfBasePoint)
-	tax.Div(tax, gfMaxBasePoint)
+// GoatFoundationPayment is a share of the gas fees taken by the foundation tax in a block
+type GoatFoundationPayment struct {
+	Recipient *common.Address // nil if the share is burnt
+	BasePoint uint64
+	Amount    *big.Int
+}
+
+// GoatFoundationSplit splits the gas fees by the foundation tax recipients at the given
+// timestamp, and returns the shares with the rest gas fees as the gas revenue of the validators
+func GoatFoundationSplit(config *params.GoatConfig, time uint64, gasFees *big.Int) ([]*GoatFoundationPayment, *big.Int) {
+	var (
+		shares  = config.GfSharesAt(time)
+		res     = make([]*GoatFoundationPayment, 0, len(shares))
+		revenue = new(big.Int).Set(gasFees)
+	)
+	for _, s := range shares {
+		amount := new(big.Int).Mul(gasFees, new(big.Int).SetUint64(s.BasePoint))
+		amount.Div(amount, gfMaxBasePoint)
+		revenue.Sub(revenue, amount)
+		res = append(res, &GoatFoundationPayment{Recipient: s.Recipient, BasePoint: s.BasePoint, Amount: amount})
+	}
+	return res, revenue
+}
 
-	if tax.BitLen() != 0 {
-		f, _ := uint256.FromBig(tax)
-		statedb.AddBalance(goattypes.GoatFoundationContract, f, tracing.BalanceIncreaseRewardTransactionFee)
+// ProcessGoatFoundationReward pays the foundation tax of the gas fees at the given timestamp,
+// and returns the rest gas fees as the gas revenue of the validators.
+// The burnt shares are left out of both.
+func ProcessGoatFoundationReward(config *params.GoatConfig, time uint64, statedb *state.StateDB, gasFees *big.Int) *big.Int {
+	if gasFees.BitLen() == 0 {
+		return new(big.Int)
 	}
 
-	return new(big.Int).Sub(gasFees, tax)
+	payments, revenue := GoatFoundationSplit(config, time, gasFees)
+	for _, p := range payments {
+		if p.Recipient != nil && p.Amount.BitLen() != 0 {
+			f, _ := uint256.FromBig(p.Amount)
+			statedb.AddBalance(*p.Recipient, f, tracing.BalanceIncreaseRewardTransactionFee)
+		}
+	}
+	return revenue
 }
 
-func ProcessGoatRequests(reward *big.Int, logs []*types.Log, config *params.ChainConfig) (types.Requests, error) {
+// ProcessGoatRequests collects the goat requests from the system contract events of a block
+//
+// The events which carry no request are skipped, they are rejected since the strict
+// events fork unless they're the built-in ignored events of the module or listed in
+// the ignored events of the config.
+func ProcessGoatRequests(reward *big.Int, logs []*types.Log, config *params.ChainConfig, time uint64) (types.Requests, error) {
 	requests := make(types.Requests, 0, 1)
 	requests = append(requests, types.NewRequest(types.NewGoatGasRevenue(reward)))
-	for _, log := range logs {
-		switch log.Address {
-		case goattypes.RelayerContract:
-			reqs, err := types.GetRelayerRequests(log.Topics, log.Data)
-			if err != nil {
-				return nil, err
-			}
-			requests = append(requests, reqs...)
-		case goattypes.BridgeContract:
-			reqs, err := types.GetBridgeRequests(log.Topics, log.Data)
-			if err != nil {
-				return nil, err
-			}
-			requests = append(requests, reqs...)
-		case goattypes.LockingContract:
-			reqs, err := types.GetLockingRequests(log.Topics, log.Data)
-			if err != nil {
-				return nil, err
+	var (
+		addrs  = config.Goat.SystemAddresses()
+		strict = config.Goat.IsStrictEvents(time)
+	)
+	for _, l := range logs {
+		module := types.GoatEventModule(addrs, l.Address)
+		// the events of the module carry no request before its fork
+		if module == nil || !module.IsActive(config.Goat, time) {
+			continue
+		}
+		reqs, err := module.DecodeEvent(strict, l.Topics, l.Data)
+		ignored := module.IsIgnoredEvent(l.Topics) || config.Goat.IsIgnoredEvent(time, l.Topics)
+		if errors.Is(err, types.ErrUnknownGoatEvent) && ignored {
+			err = nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid goat event (tx %s index %d): %w", l.TxHash, l.Index, err)
+		}
+		if len(reqs) == 0 {
+			goatSkippedEventMeter.Mark(1)
+			if ignored {
+				log.Debug("Skipped ignored goat event", "address", l.Address, "tx", l.TxHash, "index", l.Index)
+			} else {
+				log.Warn("Skipped unknown goat event", "address", l.Address, "tx", l.TxHash, "index", l.Index, "topics", len(l.Topics))
 			}
-			requests = append(requests, reqs...)
+			continue
 		}
+		flagGoatWithdrawals(config.Goat, time, l, reqs)
+		requests = append(requests, reqs...)
 	}
 	return requests, nil
 }
+
+// flagGoatWithdrawals flags the bridge withdrawals to the invalid bitcoin addresses
+// since the address check fork, so the consensus layer can cancel them deterministically
+func flagGoatWithdrawals(config *params.GoatConfig, time uint64, l *types.Log, reqs types.Requests) {
+	network, ok := config.BtcAddressNetwork(time)
+	if !ok {
+		return
+	}
+	for _, req := range reqs {
+		withdrawal, ok := req.Inner().(*types.BridgeWithdrawal)
+		if !ok {
+			continue
+		}
+		if err := btcaddr.Validate(withdrawal.Address, network); err != nil {
+			withdrawal.InvalidAddress = true
+			goatInvalidWithdrawalMeter.Mark(1)
+			log.Warn("Flagged goat withdrawal with invalid address", "id", withdrawal.Id, "address", withdrawal.Address, "tx", l.TxHash, "err", err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestProcessGoatRequestsWithdrawalAddress(t *testing.T) {
+	// the withdrawal to bc1qmvs208we3jg7hgczhlh7e9ufw034kfm2vwsvge
+	valid := &types.Log{
+		Address: goattypes.BridgeContract,
+		Topics: []common.Hash{
+			types.GoatWithdrawalTopic,
+			common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000001"),
+			common.HexToHash("0x0000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4"),
+		},
+		Data: hexutil.MustDecode("0x0000000000000000000000000000000000000000000000000000002e90edd00000000000000000000000000000000000000000000000000000000000000003e8000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000002a626331716d76733230387765336a67376867637a686c683765397566773033346b666d3276777376676500000000000000000000000000000000000000000000"),
+	}
+	// the last character is changed, so the checksum is invalid
+	invalid := &types.Log{Address: valid.Address, Topics: valid.Topics, Data: common.CopyBytes(valid.Data)}
+	invalid.Data[160+41] = 'f'
+
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Goat: &params.GoatConfig{
+		BtcAddressCheck: &params.GoatBtcAddressConfig{Time: 10, Network: "mainnet"},
+	}}
+	tests := []struct {
+		log  *types.Log
+		time uint64
+		want bool
+	}{
+		{valid, 0, false},
+		{invalid, 0, false},
+		{valid, 10, false},
+		{invalid, 10, true},
+	}
+	for i, test := range tests {
+		reqs, err := ProcessGoatRequests(new(big.Int), []*types.Log{test.log}, config, test.time)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if len(reqs) != 2 {
+			t.Fatalf("test %d: requests length mismatch: %d", i, len(reqs))
+		}
+		withdrawal, ok := reqs[1].Inner().(*types.BridgeWithdrawal)
+		if !ok {
+			t.Fatalf("test %d: not a withdrawal request", i)
+		}
+		if withdrawal.InvalidAddress != test.want {
+			t.Errorf("test %d: invalid address flag mismatch: have %v want %v", i, withdrawal.InvalidAddress, test.want)
+		}
+	}
+}
+
+func TestProcessGoatRequestsStrictEvents(t *testing.T) {
+	var (
+		ten     = uint64(10)
+		unknown = common.Hash{0x1}
+		config  = &params.ChainConfig{ChainID: big.NewInt(1), Goat: &params.GoatConfig{
+			StrictEventsTime: &ten,
+			Overrides:        []*params.GoatOverride{{Time: 20, GoatParams: params.GoatParams{IgnoredEvents: []common.Hash{unknown}}}},
+		}}
+		event = func(topic common.Hash) *types.Log {
+			return &types.Log{Address: goattypes.BridgeContract, Topics: []common.Hash{topic, {}}}
+		}
+	)
+	tests := []struct {
+		log  *types.Log
+		time uint64
+		ok   bool
+	}{
+		{event(unknown), 0, true},
+		{event(unknown), 10, false},
+		{event(unknown), 20, true},
+		{event(types.GoatDepositTopic), 10, true},
+		{event(types.GoatPaidTopic), 10, true},
+		{event(types.GoatCanceledTopic), 10, true},
+		{event(types.GoatRefundTopic), 10, true},
+		// the events of the other contracts are not checked
+		{&types.Log{Address: common.Address{0x1}, Topics: []common.Hash{unknown}}, 10, true},
+	}
+	for i, test := range tests {
+		reqs, err := ProcessGoatRequests(new(big.Int), []*types.Log{test.log}, config, test.time)
+		if (err == nil) != test.ok {
+			t.Errorf("test %d: error mismatch: have %v, want ok %v", i, err, test.ok)
+		}
+		if err == nil && len(reqs) != 1 {
+			t.Errorf("test %d: requests length mismatch: %d", i, len(reqs))
+		}
+	}
+}
+
+func TestProcessGoatRequestsLockingFork(t *testing.T) {
+	var (
+		ten    = uint64(10)
+		config = &params.ChainConfig{ChainID: big.NewInt(1), Goat: &params.GoatConfig{LockingRequestsTime: &ten}}
+		grant  = &types.Log{Address: goattypes.LockingContract, Topics: []common.Hash{types.GoatGrantTopic}, Data: common.BigToHash(big.NewInt(1)).Bytes()}
+	)
+	for _, test := range []struct {
+		config *params.ChainConfig
+		time   uint64
+		want   int
+	}{
+		{config, 9, 1},
+		{config, 10, 2},
+		// the locking events carry no request without the fork
+		{&params.ChainConfig{ChainID: big.NewInt(1), Goat: &params.GoatConfig{}}, 10, 1},
+	} {
+		reqs, err := ProcessGoatRequests(new(big.Int), []*types.Log{grant}, test.config, test.time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reqs) != test.want {
+			t.Errorf("time %d: requests length mismatch: have %d want %d", test.time, len(reqs), test.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// TestGoatMint checks the deposit and the reward goat txs mint the amounts, and
+// the amounts which are not an uint256 are rejected.
+func TestGoatMint(t *testing.T) {
+	var (
+		config = *params.MergedTestChainConfig
+		target = common.Address{0x2}
+		// the bridge returns the tax 1
+		bridgeCode = common.FromHex("0x600160005260206000f3")
+	)
+	config.Goat = &params.GoatConfig{}
+
+	deposit := func(nonce uint64, amount *big.Int) *types.Transaction {
+		inner := &goattypes.DepositTx{Txid: common.Hash{0x1}, TxOut: uint32(nonce), Target: target, Amount: amount}
+		return types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, nonce, inner))
+	}
+	reward := func(nonce uint64, amount *big.Int) *types.Transaction {
+		inner := &goattypes.DistributeRewardTx{Id: nonce, Recipient: target, Goat: new(big.Int), GasReward: amount}
+		return types.NewTx(types.NewGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, nonce, inner))
+	}
+	overflow := new(big.Int).Lsh(common.Big1, 256)
+
+	tests := []struct {
+		tx       *types.Transaction
+		overflow bool   // the amount is replaced by 2^256 in the message
+		balance  uint64 // the minted amount
+	}{
+		{deposit(0, big.NewInt(100)), false, 99},
+		{deposit(0, big.NewInt(100)), true, 0},
+		{reward(0, big.NewInt(10)), false, 10},
+		{reward(0, big.NewInt(10)), true, 0},
+	}
+	for i, test := range tests {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.SetCode(goattypes.BridgeContract, bridgeCode)
+
+		msg, err := TransactionToMessage(test.tx, types.LatestSigner(&config), nil)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if test.overflow {
+			if msg.Deposit != nil {
+				msg.Deposit.Amount = overflow
+			}
+			if msg.Reward != nil {
+				msg.Reward.Amount = overflow
+			}
+		}
+		blockCtx := vm.BlockContext{
+			CanTransfer: CanTransfer,
+			Transfer:    Transfer,
+			BlockNumber: common.Big1,
+			BaseFee:     common.Big1,
+			GasLimit:    30_000_000,
+			Random:      &common.Hash{},
+		}
+		evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, &config, vm.Config{})
+		_, err = ApplyMessage(evm, msg, new(GasPool).AddGas(blockCtx.GasLimit))
+		if test.overflow {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test %d: goat tx failed: %v", i, err)
+		}
+		if balance := statedb.GetBalance(target); balance.Uint64() != test.balance {
+			t.Errorf("test %d: balance mismatch: have %d want %d", i, balance, test.balance)
+		}
+	}
+}
+
+// TestGoatSystemAddresses checks the goat txs are sent from the configured executor
+// to the configured system contract.
+func TestGoatSystemAddresses(t *testing.T) {
+	var (
+		config = *params.MergedTestChainConfig
+		target = common.Address{0x2}
+		addrs  = &params.GoatAddresses{RelayerExecutor: common.Address{0xe1}, Bridge: common.Address{0xb1}}
+	)
+	config.Goat = &params.GoatConfig{Addresses: addrs}
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetCode(addrs.Bridge, common.FromHex("0x600160005260206000f3"))
+
+	inner := &goattypes.DepositTx{Txid: common.Hash{0x1}, Target: target, Amount: big.NewInt(100)}
+	tx := types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 0, inner))
+	msg, err := TransactionToMessage(tx, types.MakeSigner(&config, common.Big1, 0), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.From != addrs.RelayerExecutor || msg.To == nil || *msg.To != addrs.Bridge {
+		t.Fatalf("goat tx addresses mismatch: from %x to %x", msg.From, msg.To)
+	}
+	blockCtx := vm.BlockContext{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		BlockNumber: common.Big1,
+		BaseFee:     common.Big1,
+		GasLimit:    30_000_000,
+		Random:      &common.Hash{},
+	}
+	evm := vm.NewEVM(blockCtx, NewEVMTxContext(msg), statedb, &config, vm.Config{})
+	if _, err := ApplyMessage(evm, msg, new(GasPool).AddGas(blockCtx.GasLimit)); err != nil {
+		t.Fatalf("goat tx failed: %v", err)
+	}
+	if balance := statedb.GetBalance(target); balance.Uint64() != 99 {
+		t.Errorf("balance mismatch: have %d want 99", balance)
+	}
+	if nonce := statedb.GetNonce(addrs.RelayerExecutor); nonce != 1 {
+		t.Errorf("executor nonce mismatch: have %d want 1", nonce)
+	}
+	if nonce := statedb.GetNonce(goattypes.RelayerExecutor); nonce != 0 {
+		t.Errorf("default executor nonce mismatch: have %d want 0", nonce)
+	}
+}
+
+// TestGoatTxErrorIndex checks the goat tx failures report the index of the tx
+// in the block, which is set by the tx context of the state.
+func TestGoatTxErrorIndex(t *testing.T) {
+	var (
+		config = *params.MergedTestChainConfig
+		// the bridge reverts every deposit
+		bridgeCode = common.FromHex("0x60006000fd")
+	)
+	config.Goat = &params.GoatConfig{}
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetCode(goattypes.BridgeContract, bridgeCode)
+
+	inner := &goattypes.DepositTx{Txid: common.Hash{0x1}, Target: common.Address{0x2}, Amount: big.NewInt(100)}
+	tx := types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 0, inner))
+	msg, err := TransactionToMessage(tx, types.LatestSigner(&config), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blockCtx := vm.BlockContext{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		BlockNumber: common.Big1,
+		BaseFee:     common.Big1,
+		GasLimit:    30_000_000,
+		Random:      &common.Hash{},
+	}
+	evm := vm.NewEVM(blockCtx, vm.TxContext{}, statedb, &config, vm.Config{})
+	statedb.SetTxContext(tx.Hash(), 2)
+
+	var usedGas uint64
+	_, err = ApplyTransactionWithEVM(msg, &config, new(GasPool).AddGas(blockCtx.GasLimit), statedb, common.Big1, common.Hash{}, tx, &usedGas, evm)
+	var goatErr *GoatTxError
+	if !errors.As(err, &goatErr) || !errors.Is(err, ErrGoatTxReverted) {
+		t.Fatalf("expected goat tx reverted error, got %v", err)
+	}
+	if goatErr.Index != 2 {
+		t.Errorf("goat tx error index mismatch: have %d want %d", goatErr.Index, 2)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GoatNonceReader is the state to read the executor nonces from
+type GoatNonceReader interface {
+	GetNonce(common.Address) uint64
+}
+
+// goatDeposit is the bitcoin utxo of a bridge deposit
+type goatDeposit struct {
+	txid  common.Hash
+	txout uint32
+}
+
+// VerifyGoatTxs checks that the goat txs of every executor are in a strict nonce
+// sequence and that a bridge deposit is included once at most.
+//
+// The sequence starts from the executor nonce in the given state, or from the
+// first goat tx of the executor if the state is nil.
+func VerifyGoatTxs(config *params.GoatConfig, txs []*types.Transaction, state GoatNonceReader) error {
+	var (
+		nonces   = make(map[common.Address]uint64)
+		deposits = make(map[goatDeposit]struct{})
+	)
+	for i, tx := range txs {
+		goatTx := tx.GoatTx()
+		if goatTx == nil {
+			continue
+		}
+		sender := config.SystemAddress(goatTx.Sender())
+		expected, ok := nonces[sender]
+		if !ok && state != nil {
+			expected, ok = state.GetNonce(sender), true
+		}
+		if ok && goatTx.Nonce != expected {
+			return fmt.Errorf("%w: goat tx %d from %s, expect %d got %d", ErrGoatTxNonce, i, sender, expected, goatTx.Nonce)
+		}
+		nonces[sender] = goatTx.Nonce + 1
+
+		var key *goatDeposit
+		switch deposit := goatTx.Inner().(type) {
+		case *goattypes.DepositTx:
+			key = &goatDeposit{deposit.Txid, deposit.TxOut}
+		case *goattypes.DepositProofTx:
+			key = &goatDeposit{deposit.Txid, deposit.TxOut}
+		}
+		if key != nil {
+			if _, exist := deposits[*key]; exist {
+				return fmt.Errorf("%w: goat tx %d txid %s txout %d", ErrGoatDuplicateDeposit, i, key.txid, key.txout)
+			}
+			deposits[*key] = struct{}{}
+		}
+	}
+	return nil
+}
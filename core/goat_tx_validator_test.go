@@ -0,0 +1,48 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+type goatNonces map[common.Address]uint64
+
+func (n goatNonces) GetNonce(addr common.Address) uint64 { return n[addr] }
+
+func TestVerifyGoatTxs(t *testing.T) {
+	deposit := func(nonce uint64, txout uint32) *types.Transaction {
+		inner := &goattypes.DepositTx{Txid: common.Hash{0x1}, TxOut: txout, Target: common.Address{0x2}, Amount: big.NewInt(1)}
+		return types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, nonce, inner))
+	}
+	reward := func(nonce uint64) *types.Transaction {
+		inner := &goattypes.DistributeRewardTx{Id: nonce, Recipient: common.Address{0x3}, Goat: big.NewInt(1), GasReward: big.NewInt(1)}
+		return types.NewTx(types.NewGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, nonce, inner))
+	}
+	state := goatNonces{goattypes.RelayerExecutor: 5, goattypes.LockingExecutor: 2}
+
+	tests := []struct {
+		txs   []*types.Transaction
+		state GoatNonceReader
+		err   error
+	}{
+		{[]*types.Transaction{deposit(5, 0), reward(2), deposit(6, 1), reward(3)}, state, nil},
+		{[]*types.Transaction{deposit(4, 0)}, state, ErrGoatTxNonce},
+		{[]*types.Transaction{deposit(5, 0), deposit(7, 1)}, state, ErrGoatTxNonce},
+		{[]*types.Transaction{reward(2), reward(2)}, state, ErrGoatTxNonce},
+		{[]*types.Transaction{deposit(5, 0), deposit(6, 0)}, state, ErrGoatDuplicateDeposit},
+		// the first nonce is not checked without state
+		{[]*types.Transaction{deposit(10, 0), reward(0), deposit(11, 1)}, nil, nil},
+		{[]*types.Transaction{deposit(10, 0), deposit(10, 1)}, nil, ErrGoatTxNonce},
+	}
+	for i, test := range tests {
+		err := VerifyGoatTxs(nil, test.txs, test.state)
+		if !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v want %v", i, err, test.err)
+		}
+	}
+}
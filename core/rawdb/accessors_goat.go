@@ -0,0 +1,50 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// WriteGoatWithdrawalEvents stores the events of a bridge withdrawal in the given block.
+func WriteGoatWithdrawalEvents(db ethdb.KeyValueWriter, id uint64, number uint64, hash common.Hash, events []*types.GoatWithdrawalEvent) {
+	data, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Crit("Failed to encode goat withdrawal events", "err", err)
+	}
+	if err := db.Put(goatWithdrawalKey(id, number, hash), data); err != nil {
+		log.Crit("Failed to store goat withdrawal events", "err", err)
+	}
+}
+
+// ReadGoatWithdrawalEvents retrieves all the events of a bridge withdrawal on the
+// canonical chain, the events are in the chain order. The events of the blocks
+// which are reorged out are skipped.
+func ReadGoatWithdrawalEvents(db ethdb.Database, id uint64) []*types.GoatWithdrawalEvent {
+	prefix := goatWithdrawalIdKey(id)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var events []*types.GoatWithdrawalEvent
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix) : len(prefix)+8])
+		if ReadCanonicalHash(db, number) != common.BytesToHash(key[len(prefix)+8:]) {
+			continue
+		}
+		var list []*types.GoatWithdrawalEvent
+		if err := rlp.DecodeBytes(it.Value(), &list); err != nil {
+			log.Error("Invalid goat withdrawal events RLP", "id", id, "number", number, "err", err)
+			return nil
+		}
+		events = append(events, list...)
+	}
+	return events
+}
@@ -0,0 +1,52 @@
+package rawdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestGoatWithdrawalEventsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		canon1 = common.Hash{0x1}
+		canon2 = common.Hash{0x2}
+		side2  = common.Hash{0x3}
+		event  = func(hash common.Hash, number uint64, id uint64) *types.GoatWithdrawalEvent {
+			return &types.GoatWithdrawalEvent{BlockHash: hash, BlockNumber: number, TxHash: common.Hash{byte(id)}, Cancel1: &types.Cancel1{Id: id}}
+		}
+	)
+	if events := ReadGoatWithdrawalEvents(db, 1); len(events) != 0 {
+		t.Fatalf("non existent events returned: %v", events)
+	}
+	WriteCanonicalHash(db, canon1, 1)
+	WriteCanonicalHash(db, canon2, 2)
+
+	// the events of the block 2 are written before the block 1, and the events
+	// of the other ids and the side block are ignored
+	WriteGoatWithdrawalEvents(db, 1, 2, canon2, []*types.GoatWithdrawalEvent{event(canon2, 2, 1)})
+	WriteGoatWithdrawalEvents(db, 1, 1, canon1, []*types.GoatWithdrawalEvent{event(canon1, 1, 1), event(canon1, 1, 1)})
+	WriteGoatWithdrawalEvents(db, 1, 2, side2, []*types.GoatWithdrawalEvent{event(side2, 2, 1)})
+	WriteGoatWithdrawalEvents(db, 2, 2, canon2, []*types.GoatWithdrawalEvent{event(canon2, 2, 2)})
+	WriteGoatWithdrawalEvents(db, 256, 1, canon1, []*types.GoatWithdrawalEvent{event(canon1, 1, 256)})
+
+	want := []*types.GoatWithdrawalEvent{event(canon1, 1, 1), event(canon1, 1, 1), event(canon2, 2, 1)}
+	if events := ReadGoatWithdrawalEvents(db, 1); !reflect.DeepEqual(events, want) {
+		t.Fatalf("events mismatch: have %v, want %v", events, want)
+	}
+	// reorg the block 2 to the side block
+	WriteCanonicalHash(db, side2, 2)
+	want = []*types.GoatWithdrawalEvent{event(canon1, 1, 1), event(canon1, 1, 1), event(side2, 2, 1)}
+	if events := ReadGoatWithdrawalEvents(db, 1); !reflect.DeepEqual(events, want) {
+		t.Fatalf("reorged events mismatch: have %v, want %v", events, want)
+	}
+	if events := ReadGoatWithdrawalEvents(db, 2); len(events) != 0 {
+		t.Fatalf("reorged events returned: %v", events)
+	}
+	if events := ReadGoatWithdrawalEvents(db, 256); len(events) != 1 || events[0].Cancel1.Id != 256 {
+		t.Fatalf("events mismatch: %v", events)
+	}
+}
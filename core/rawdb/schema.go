@@ -112,6 +112,8 @@ var (
 	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
 	skeletonHeaderPrefix  = []byte("S") // skeletonHeaderPrefix + num (uint64 big endian) -> header
 
+	goatWithdrawalPrefix = []byte("gw") // goatWithdrawalPrefix + id (uint64 big endian) + num (uint64 big endian) + hash -> withdrawal events
+
 	// Path-based storage scheme of merkle patricia trie.
 	TrieNodeAccountPrefix = []byte("A") // TrieNodeAccountPrefix + hexPath -> trie node
 	TrieNodeStoragePrefix = []byte("O") // TrieNodeStoragePrefix + accountHash + hexPath -> trie node
@@ -131,6 +133,9 @@ var (
 	// BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 	BloomBitsIndexPrefix = []byte("iB")
 
+	// GoatWithdrawalIndexPrefix is the data table of a chain indexer to track the goat withdrawal index progress
+	GoatWithdrawalIndexPrefix = []byte("iG")
+
 	ChtPrefix           = []byte("chtRootV2-") // ChtPrefix + chtNum (uint64 big endian) -> trie root hash
 	ChtTablePrefix      = []byte("cht-")
 	ChtIndexTablePrefix = []byte("chtIndexV2-")
@@ -233,6 +238,20 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// goatWithdrawalKey = goatWithdrawalPrefix + id (uint64 big endian) + num (uint64 big endian) + hash
+func goatWithdrawalKey(id uint64, number uint64, hash common.Hash) []byte {
+	key := make([]byte, 0, len(goatWithdrawalPrefix)+8+8+common.HashLength)
+	key = append(key, goatWithdrawalPrefix...)
+	key = binary.BigEndian.AppendUint64(key, id)
+	key = binary.BigEndian.AppendUint64(key, number)
+	return append(key, hash.Bytes()...)
+}
+
+// goatWithdrawalIdKey = goatWithdrawalPrefix + id (uint64 big endian)
+func goatWithdrawalIdKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, goatWithdrawalPrefix...), id)
+}
+
 // skeletonHeaderKey = skeletonHeaderPrefix + num (uint64 big endian)
 func skeletonHeaderKey(number uint64) []byte {
 	return append(skeletonHeaderPrefix, encodeBlockNumber(number)...)
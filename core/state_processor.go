@@ -17,6 +17,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -62,9 +63,6 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		blockNumber = block.Number()
 		allLogs     []*types.Log
 		gp          = new(GasPool).AddGas(block.GasLimit())
-
-		// gas reward to validators and delegators
-		gasReward = new(big.Int)
 	)
 
 	// Mutate the block and state according to any hard-fork specs
@@ -98,29 +96,15 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		}
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
-
-		if receipt.GasUsed > 0 { // non-goatTx case
-			tipFee := new(big.Int).SetUint64(receipt.GasUsed)
-			tipFee.Mul(tipFee, tx.EffectiveGasTipValue(context.BaseFee))
-			gasReward.Add(gasReward, tipFee)
-		}
 	}
 
 	// Read requests if Prague is enabled.
 	var requests types.Requests
 
 	if p.config.Goat != nil {
-		burntFees := new(big.Int)
-		if context.BaseFee != nil && header.GasUsed > 0 {
-			burntFees.Mul(context.BaseFee, new(big.Int).SetUint64(header.GasUsed))
-		}
-		if gasUsed := header.BlobGasUsed; context.BlobBaseFee != nil && gasUsed != nil && *gasUsed > 0 {
-			blobUsed := new(big.Int).SetUint64(*gasUsed)
-			burntFees.Add(burntFees, blobUsed.Mul(blobUsed, context.BlobBaseFee))
-		}
-		gasReward.Add(gasReward, burntFees)
-		reward := ProcessGoatFoundationReward(statedb, gasReward)
-		requests, err = ProcessGoatRequests(reward, allLogs, p.config)
+		fees := CalcGoatBlockFees(header, block.Transactions(), receipts)
+		reward := ProcessGoatFoundationReward(p.config.Goat, header.Time, statedb, fees.Total())
+		requests, err = ProcessGoatRequests(reward, allLogs, p.config, header.Time)
 		if err != nil {
 			return nil, err
 		}
@@ -163,6 +147,10 @@ func ApplyTransactionWithEVM(msg *Message, config *params.ChainConfig, gp *GasPo
 	// Apply the transaction to the current state (included in the env).
 	result, err := ApplyMessage(evm, msg, gp)
 	if err != nil {
+		var goatErr *GoatTxError
+		if errors.As(err, &goatErr) {
+			goatErr.Index = statedb.TxIndex()
+		}
 		return nil, err
 	}
 
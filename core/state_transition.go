@@ -17,6 +17,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -40,6 +41,15 @@ type ExecutionResult struct {
 	RefundedGas uint64 // Total gas refunded after execution
 	Err         error  // Any error encountered during the execution(listed in core/vm/errors.go)
 	ReturnData  []byte // Returned data from evm(function result or data supplied with revert opcode)
+
+	GoatMint *GoatMint // The balances minted by the goat tx, nil for the other txs
+}
+
+// GoatMint is the balances minted by a goat tx
+type GoatMint struct {
+	Deposit *goattypes.Mint // the deposit minted to the target, the tax is excluded
+	Tax     *big.Int        // the deposit tax paid to the goat foundation
+	Reward  *goattypes.Mint // the reward distributed to the validator or the delegator
 }
 
 // Unwrap returns the internal evm error which allows us for further
@@ -156,6 +166,9 @@ type Message struct {
 	IsGoatTx bool            // goat tx has no gas consumed
 	Deposit  *goattypes.Mint // deposit from L1
 	Reward   *goattypes.Mint // reward and un-delegation from consensus layer
+
+	BitcoinHeader []byte                    // the bitcoin header appended to the tracked chain
+	DepositProof  *goattypes.DepositProofTx // the deposit with the bitcoin inclusion proof
 }
 
 // TransactionToMessage converts a transaction into a Message.
@@ -179,11 +192,20 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		IsGoatTx: tx.IsGoatTx(),
 		Deposit:  tx.Deposit(),
 		Reward:   tx.Reward(),
+
+		BitcoinHeader: tx.BitcoinHeader(),
+		DepositProof:  tx.DepositProof(),
 	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
 		msg.GasPrice = cmath.BigMin(msg.GasPrice.Add(msg.GasTipCap, baseFee), msg.GasFeeCap)
 	}
+	// The goat txs are addressed to the default system contract, the signer of the
+	// chain resolves it and the executor to the configured ones
+	if msg.IsGoatTx {
+		to := types.GoatSystemAddress(s, *msg.To)
+		msg.To = &to
+	}
 	var err error
 	msg.From, err = types.Sender(s, tx)
 	return msg, err
@@ -251,8 +273,8 @@ func (st *StateTransition) to() common.Address {
 
 func (st *StateTransition) buyGas() error {
 	if st.msg.IsGoatTx {
-		st.initialGas = params.GoatTxGasLimit
-		st.gasRemaining = params.GoatTxGasLimit
+		st.initialGas = st.evm.ChainConfig().Goat.GoatTxGasLimitAt(st.evm.Context.Time)
+		st.gasRemaining = st.initialGas
 
 		if st.evm.Config.Tracer != nil && st.evm.Config.Tracer.OnGasChange != nil {
 			st.evm.Config.Tracer.OnGasChange(0, st.gasRemaining, tracing.GasChangeTxInitialBalance)
@@ -303,10 +325,45 @@ func (st *StateTransition) buyGas() error {
 	return nil
 }
 
+// appendBitcoinHeader checks and tracks the bitcoin header before the goat tx is applied,
+// the bare block hashes are rejected since the header tracking is enabled
+func (st *StateTransition) appendBitcoinHeader() error {
+	goat := st.evm.ChainConfig().Goat
+	if st.msg.To == nil || *st.msg.To != goat.SystemAddresses().Bitcoin {
+		return nil
+	}
+	enabled := goat.IsBitcoinHeaders(st.evm.Context.Time)
+	if st.msg.BitcoinHeader == nil {
+		if enabled {
+			return fmt.Errorf("%w: block hash without header", ErrGoatBitcoinHeader)
+		}
+		return nil
+	}
+	if !enabled {
+		return fmt.Errorf("%w: header tracking is not enabled", ErrGoatBitcoinHeader)
+	}
+	height, err := AppendBitcoinHeader(goat, st.state, st.msg.BitcoinHeader)
+	if err != nil {
+		return err
+	}
+	log.Debug("Appended bitcoin header", "height", height, "hash", goattypes.DoubleSha256(st.msg.BitcoinHeader))
+	return nil
+}
+
 func (st *StateTransition) preCheck() error {
 	// Only check transactions that are not fake
 	msg := st.msg
-	if !msg.SkipNonceChecks {
+	if msg.IsGoatTx && st.evm.ChainConfig().Goat == nil {
+		return fmt.Errorf("%w: goat tx on the non-goat chain", types.ErrTxTypeNotSupported)
+	}
+	if msg.IsGoatTx {
+		// The goat txs should be in the strict nonce sequence of the executor,
+		// it's only skipped for the simulated goat txs of the RPC calls
+		if stNonce := st.state.GetNonce(msg.From); stNonce != msg.Nonce && !msg.SkipNonceChecks {
+			return fmt.Errorf("%w: executor %v, tx: %d state: %d", ErrGoatTxNonce,
+				msg.From.Hex(), msg.Nonce, stNonce)
+		}
+	} else if !msg.SkipNonceChecks {
 		// Make sure this transaction's nonce is correct.
 		stNonce := st.state.GetNonce(msg.From)
 		if msgNonce := msg.Nonce; stNonce < msgNonce {
@@ -413,6 +470,20 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if err := st.preCheck(); err != nil {
 		return nil, err
 	}
+	if st.msg.IsGoatTx {
+		if err := st.appendBitcoinHeader(); err != nil {
+			return nil, err
+		}
+		if proof := st.msg.DepositProof; proof != nil {
+			goat := st.evm.ChainConfig().Goat
+			if !goat.IsBitcoinHeaders(st.evm.Context.Time) {
+				return nil, fmt.Errorf("%w: header tracking is not enabled", ErrGoatDepositProof)
+			}
+			if err := VerifyDepositProof(goat, st.state, proof); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	var (
 		msg              = st.msg
@@ -475,49 +546,58 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 
 	if msg.IsGoatTx {
 		if vmerr != nil {
-			return nil, fmt.Errorf("goat tx failed (to %s data %x err %s)", msg.To, msg.Data, vmerr)
+			err := newGoatTxError(ErrGoatTxReverted, "to %s data %x err %s", msg.To, msg.Data, vmerr)
+			err.Err = vmerr
+			if errors.Is(vmerr, vm.ErrExecutionReverted) {
+				err.Revert = common.CopyBytes(ret)
+			}
+			return nil, err
 		}
 
+		mint := new(GoatMint)
+
 		// deposit
 		if v := msg.Deposit; v != nil {
-			amount, ok := uint256.FromBig(v.Amount)
-			if !ok {
-				return nil, fmt.Errorf("goat tx failed (invalid amount to mint: %s)", v.Amount)
+			amount, overflow := uint256.FromBig(v.Amount)
+			if overflow {
+				return nil, newGoatTxError(ErrGoatMintAmount, "invalid amount to mint: %s", v.Amount)
 			}
 
 			// get the tax from call returns
 			if len(ret) != 32 {
-				return nil, fmt.Errorf("goat tx failed (deposit should return uint256 but got %x)", ret)
+				return nil, newGoatTxError(ErrGoatDepositReturn, "deposit should return uint256 but got %x", ret)
 			}
-			tax, ok := uint256.FromBig(new(big.Int).SetBytes(ret))
-			if !ok {
-				return nil, fmt.Errorf("goat tx failed (invalid amount to pay tax: %s)", v.Amount)
+			tax, overflow := uint256.FromBig(new(big.Int).SetBytes(ret))
+			if overflow {
+				return nil, newGoatTxError(ErrGoatDepositReturn, "invalid amount to pay tax: %x", ret)
 			}
 
 			// sub the tax and pay the tax to GF
 			if tax.BitLen() > 0 {
 				if amount.Cmp(tax) < 0 {
-					return nil, fmt.Errorf("goat tx failed (tax is larger than deposit: deposit %s tax %s)", v.Amount, tax)
+					return nil, newGoatTxError(ErrGoatDepositTax, "tax is larger than deposit: deposit %s tax %s", v.Amount, tax)
 				}
 				amount.Sub(amount, tax)
-				st.state.AddBalance(goattypes.GoatFoundationContract, tax, tracing.BalanceGoatTax)
+				st.state.AddBalance(st.evm.ChainConfig().Goat.SystemAddresses().GoatFoundation, tax, tracing.BalanceGoatTax)
 			}
 
 			// add the deposit value(withtout tax) to the target
 			log.Debug("NewDeposit", "address", v.Address, "amount", amount, "tax", tax)
 			st.state.AddBalance(v.Address, amount, tracing.BalanceGoatDepoist)
+			mint.Deposit, mint.Tax = &goattypes.Mint{Address: v.Address, Amount: amount.ToBig()}, tax.ToBig()
 		}
 
 		// distribute reward to a validator or a delegator
 		if v := msg.Reward; v != nil {
-			amount, ok := uint256.FromBig(v.Amount)
-			if !ok {
-				return nil, fmt.Errorf("goat tx failed (invalid amount to distribute reward: %s)", v.Amount)
+			amount, overflow := uint256.FromBig(v.Amount)
+			if overflow {
+				return nil, newGoatTxError(ErrGoatMintAmount, "invalid amount to distribute reward: %s", v.Amount)
 			}
 
 			// add the reward value to the target
 			log.Debug("NewReward", "address", v.Address, "amount", amount)
 			st.state.AddBalance(v.Address, amount, tracing.BalanceIncreaseWithdrawal)
+			mint.Reward = &goattypes.Mint{Address: v.Address, Amount: amount.ToBig()}
 		}
 
 		gasUsed := st.gasUsed()
@@ -533,6 +613,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 			RefundedGas: gasUsed,
 			Err:         vmerr,
 			ReturnData:  ret,
+			GoatMint:    mint,
 		}, nil
 	}
 
@@ -0,0 +1,49 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*claimMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (c Claim) MarshalJSON() ([]byte, error) {
+	type Claim struct {
+		Id        hexutil.Uint64 `json:"id"`
+		Validator common.Address `json:"validator"`
+		Recipient common.Address `json:"recipient"`
+	}
+	var enc Claim
+	enc.Id = hexutil.Uint64(c.Id)
+	enc.Validator = c.Validator
+	enc.Recipient = c.Recipient
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (c *Claim) UnmarshalJSON(input []byte) error {
+	type Claim struct {
+		Id        *hexutil.Uint64 `json:"id"`
+		Validator *common.Address `json:"validator"`
+		Recipient *common.Address `json:"recipient"`
+	}
+	var dec Claim
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		c.Id = uint64(*dec.Id)
+	}
+	if dec.Validator != nil {
+		c.Validator = *dec.Validator
+	}
+	if dec.Recipient != nil {
+		c.Recipient = *dec.Recipient
+	}
+	return nil
+}
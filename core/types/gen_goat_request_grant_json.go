@@ -0,0 +1,37 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*grantMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (g Grant) MarshalJSON() ([]byte, error) {
+	type Grant struct {
+		Amount *hexutil.Big `json:"amount"`
+	}
+	var enc Grant
+	enc.Amount = (*hexutil.Big)(g.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (g *Grant) UnmarshalJSON(input []byte) error {
+	type Grant struct {
+		Amount *hexutil.Big `json:"amount"`
+	}
+	var dec Grant
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Amount != nil {
+		g.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*lockMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (l Lock) MarshalJSON() ([]byte, error) {
+	type Lock struct {
+		Validator common.Address `json:"validator"`
+		Token     common.Address `json:"token"`
+		Amount    *hexutil.Big   `json:"amount"`
+	}
+	var enc Lock
+	enc.Validator = l.Validator
+	enc.Token = l.Token
+	enc.Amount = (*hexutil.Big)(l.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (l *Lock) UnmarshalJSON(input []byte) error {
+	type Lock struct {
+		Validator *common.Address `json:"validator"`
+		Token     *common.Address `json:"token"`
+		Amount    *hexutil.Big    `json:"amount"`
+	}
+	var dec Lock
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Validator != nil {
+		l.Validator = *dec.Validator
+	}
+	if dec.Token != nil {
+		l.Token = *dec.Token
+	}
+	if dec.Amount != nil {
+		l.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
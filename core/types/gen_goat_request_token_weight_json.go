@@ -0,0 +1,43 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*updateTokenWeightMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (u UpdateTokenWeight) MarshalJSON() ([]byte, error) {
+	type UpdateTokenWeight struct {
+		Token  common.Address `json:"token"`
+		Weight hexutil.Uint64 `json:"weight"`
+	}
+	var enc UpdateTokenWeight
+	enc.Token = u.Token
+	enc.Weight = hexutil.Uint64(u.Weight)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (u *UpdateTokenWeight) UnmarshalJSON(input []byte) error {
+	type UpdateTokenWeight struct {
+		Token  *common.Address `json:"token"`
+		Weight *hexutil.Uint64 `json:"weight"`
+	}
+	var dec UpdateTokenWeight
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Token != nil {
+		u.Token = *dec.Token
+	}
+	if dec.Weight != nil {
+		u.Weight = uint64(*dec.Weight)
+	}
+	return nil
+}
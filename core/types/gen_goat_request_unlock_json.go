@@ -0,0 +1,62 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*unlockMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (u Unlock) MarshalJSON() ([]byte, error) {
+	type Unlock struct {
+		Id        hexutil.Uint64 `json:"id"`
+		Validator common.Address `json:"validator"`
+		Recipient common.Address `json:"recipient"`
+		Token     common.Address `json:"token"`
+		Amount    *hexutil.Big   `json:"amount"`
+	}
+	var enc Unlock
+	enc.Id = hexutil.Uint64(u.Id)
+	enc.Validator = u.Validator
+	enc.Recipient = u.Recipient
+	enc.Token = u.Token
+	enc.Amount = (*hexutil.Big)(u.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (u *Unlock) UnmarshalJSON(input []byte) error {
+	type Unlock struct {
+		Id        *hexutil.Uint64 `json:"id"`
+		Validator *common.Address `json:"validator"`
+		Recipient *common.Address `json:"recipient"`
+		Token     *common.Address `json:"token"`
+		Amount    *hexutil.Big    `json:"amount"`
+	}
+	var dec Unlock
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		u.Id = uint64(*dec.Id)
+	}
+	if dec.Validator != nil {
+		u.Validator = *dec.Validator
+	}
+	if dec.Recipient != nil {
+		u.Recipient = *dec.Recipient
+	}
+	if dec.Token != nil {
+		u.Token = *dec.Token
+	}
+	if dec.Amount != nil {
+		u.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
@@ -13,26 +13,29 @@ var _ = (*bridgeWithdrawMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (b BridgeWithdrawal) MarshalJSON() ([]byte, error) {
 	type BridgeWithdrawal struct {
-		Id         hexutil.Uint64 `json:"id"`
-		Amount     hexutil.Uint64 `json:"amount_in_satoshi"`
-		MaxTxPrice hexutil.Uint64 `json:"max_tx_price"`
-		Address    string         `json:"address"`
+		Id             hexutil.Uint64 `json:"id"`
+		Amount         hexutil.Uint64 `json:"amount_in_satoshi"`
+		MaxTxPrice     hexutil.Uint64 `json:"max_tx_price"`
+		Address        string         `json:"address"`
+		InvalidAddress bool           `json:"invalid_address,omitempty" rlp:"optional"`
 	}
 	var enc BridgeWithdrawal
 	enc.Id = hexutil.Uint64(b.Id)
 	enc.Amount = hexutil.Uint64(b.Amount)
 	enc.MaxTxPrice = hexutil.Uint64(b.MaxTxPrice)
 	enc.Address = b.Address
+	enc.InvalidAddress = b.InvalidAddress
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (b *BridgeWithdrawal) UnmarshalJSON(input []byte) error {
 	type BridgeWithdrawal struct {
-		Id         *hexutil.Uint64 `json:"id"`
-		Amount     *hexutil.Uint64 `json:"amount_in_satoshi"`
-		MaxTxPrice *hexutil.Uint64 `json:"max_tx_price"`
-		Address    *string         `json:"address"`
+		Id             *hexutil.Uint64 `json:"id"`
+		Amount         *hexutil.Uint64 `json:"amount_in_satoshi"`
+		MaxTxPrice     *hexutil.Uint64 `json:"max_tx_price"`
+		Address        *string         `json:"address"`
+		InvalidAddress *bool           `json:"invalid_address,omitempty" rlp:"optional"`
 	}
 	var dec BridgeWithdrawal
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -50,5 +53,8 @@ func (b *BridgeWithdrawal) UnmarshalJSON(input []byte) error {
 	if dec.Address != nil {
 		b.Address = *dec.Address
 	}
+	if dec.InvalidAddress != nil {
+		b.InvalidAddress = *dec.InvalidAddress
+	}
 	return nil
 }
@@ -0,0 +1,121 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*goatRequestsMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (g GoatRequests) MarshalJSON() ([]byte, error) {
+	type GoatRequests struct {
+		BlockHash             common.Hash           `json:"blockHash"`
+		BlockNumber           hexutil.Uint64        `json:"blockNumber"`
+		GasRevenues           GasRevenues           `json:"gasRevenueRequests"`
+		AddVoters             AddVoters             `json:"addVoterRequests"`
+		RemoveVoters          RemoveVoters          `json:"removeVoterRequests"`
+		BridgeWithdrawals     BridgeWithdrawals     `json:"bridgeWithdrawalsRequests"`
+		ReplaceByFees         ReplaceByFees         `json:"rbfRequests"`
+		Cancel1s              Cancel1s              `json:"cancel1Requests"`
+		CreateValidators      CreateValidators      `json:"createValidatorRequests"`
+		Locks                 Locks                 `json:"lockRequests"`
+		Unlocks               Unlocks               `json:"unlockRequests"`
+		Claims                Claims                `json:"claimRequests"`
+		UpdateTokenWeights    UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+		UpdateTokenThresholds UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+		Grants                Grants                `json:"grantRequests"`
+	}
+	var enc GoatRequests
+	enc.BlockHash = g.BlockHash
+	enc.BlockNumber = hexutil.Uint64(g.BlockNumber)
+	enc.GasRevenues = g.GasRevenues
+	enc.AddVoters = g.AddVoters
+	enc.RemoveVoters = g.RemoveVoters
+	enc.BridgeWithdrawals = g.BridgeWithdrawals
+	enc.ReplaceByFees = g.ReplaceByFees
+	enc.Cancel1s = g.Cancel1s
+	enc.CreateValidators = g.CreateValidators
+	enc.Locks = g.Locks
+	enc.Unlocks = g.Unlocks
+	enc.Claims = g.Claims
+	enc.UpdateTokenWeights = g.UpdateTokenWeights
+	enc.UpdateTokenThresholds = g.UpdateTokenThresholds
+	enc.Grants = g.Grants
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (g *GoatRequests) UnmarshalJSON(input []byte) error {
+	type GoatRequests struct {
+		BlockHash             *common.Hash           `json:"blockHash"`
+		BlockNumber           *hexutil.Uint64        `json:"blockNumber"`
+		GasRevenues           *GasRevenues           `json:"gasRevenueRequests"`
+		AddVoters             *AddVoters             `json:"addVoterRequests"`
+		RemoveVoters          *RemoveVoters          `json:"removeVoterRequests"`
+		BridgeWithdrawals     *BridgeWithdrawals     `json:"bridgeWithdrawalsRequests"`
+		ReplaceByFees         *ReplaceByFees         `json:"rbfRequests"`
+		Cancel1s              *Cancel1s              `json:"cancel1Requests"`
+		CreateValidators      *CreateValidators      `json:"createValidatorRequests"`
+		Locks                 *Locks                 `json:"lockRequests"`
+		Unlocks               *Unlocks               `json:"unlockRequests"`
+		Claims                *Claims                `json:"claimRequests"`
+		UpdateTokenWeights    *UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+		UpdateTokenThresholds *UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+		Grants                *Grants                `json:"grantRequests"`
+	}
+	var dec GoatRequests
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.BlockHash != nil {
+		g.BlockHash = *dec.BlockHash
+	}
+	if dec.BlockNumber != nil {
+		g.BlockNumber = uint64(*dec.BlockNumber)
+	}
+	if dec.GasRevenues != nil {
+		g.GasRevenues = *dec.GasRevenues
+	}
+	if dec.AddVoters != nil {
+		g.AddVoters = *dec.AddVoters
+	}
+	if dec.RemoveVoters != nil {
+		g.RemoveVoters = *dec.RemoveVoters
+	}
+	if dec.BridgeWithdrawals != nil {
+		g.BridgeWithdrawals = *dec.BridgeWithdrawals
+	}
+	if dec.ReplaceByFees != nil {
+		g.ReplaceByFees = *dec.ReplaceByFees
+	}
+	if dec.Cancel1s != nil {
+		g.Cancel1s = *dec.Cancel1s
+	}
+	if dec.CreateValidators != nil {
+		g.CreateValidators = *dec.CreateValidators
+	}
+	if dec.Locks != nil {
+		g.Locks = *dec.Locks
+	}
+	if dec.Unlocks != nil {
+		g.Unlocks = *dec.Unlocks
+	}
+	if dec.Claims != nil {
+		g.Claims = *dec.Claims
+	}
+	if dec.UpdateTokenWeights != nil {
+		g.UpdateTokenWeights = *dec.UpdateTokenWeights
+	}
+	if dec.UpdateTokenThresholds != nil {
+		g.UpdateTokenThresholds = *dec.UpdateTokenThresholds
+	}
+	if dec.Grants != nil {
+		g.Grants = *dec.Grants
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+var _ = (*goatWithdrawalEventMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (g GoatWithdrawalEvent) MarshalJSON() ([]byte, error) {
+	type GoatWithdrawalEvent struct {
+		BlockHash    common.Hash          `json:"blockHash"`
+		BlockNumber  hexutil.Uint64       `json:"blockNumber"`
+		TxHash       common.Hash          `json:"transactionHash"`
+		TxIndex      hexutil.Uint64       `json:"transactionIndex"`
+		Withdrawal   *BridgeWithdrawal    `json:"withdrawal,omitempty" rlp:"nil"`
+		ReplaceByFee *ReplaceByFee        `json:"rbf,omitempty" rlp:"nil"`
+		Cancel1      *Cancel1             `json:"cancel1,omitempty" rlp:"nil"`
+		Cancel2      *goattypes.Cancel2Tx `json:"cancel2,omitempty" rlp:"nil"`
+		Paid         *goattypes.PaidTx    `json:"paid,omitempty" rlp:"nil"`
+	}
+	var enc GoatWithdrawalEvent
+	enc.BlockHash = g.BlockHash
+	enc.BlockNumber = hexutil.Uint64(g.BlockNumber)
+	enc.TxHash = g.TxHash
+	enc.TxIndex = hexutil.Uint64(g.TxIndex)
+	enc.Withdrawal = g.Withdrawal
+	enc.ReplaceByFee = g.ReplaceByFee
+	enc.Cancel1 = g.Cancel1
+	enc.Cancel2 = g.Cancel2
+	enc.Paid = g.Paid
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (g *GoatWithdrawalEvent) UnmarshalJSON(input []byte) error {
+	type GoatWithdrawalEvent struct {
+		BlockHash    *common.Hash         `json:"blockHash"`
+		BlockNumber  *hexutil.Uint64      `json:"blockNumber"`
+		TxHash       *common.Hash         `json:"transactionHash"`
+		TxIndex      *hexutil.Uint64      `json:"transactionIndex"`
+		Withdrawal   *BridgeWithdrawal    `json:"withdrawal,omitempty" rlp:"nil"`
+		ReplaceByFee *ReplaceByFee        `json:"rbf,omitempty" rlp:"nil"`
+		Cancel1      *Cancel1             `json:"cancel1,omitempty" rlp:"nil"`
+		Cancel2      *goattypes.Cancel2Tx `json:"cancel2,omitempty" rlp:"nil"`
+		Paid         *goattypes.PaidTx    `json:"paid,omitempty" rlp:"nil"`
+	}
+	var dec GoatWithdrawalEvent
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.BlockHash != nil {
+		g.BlockHash = *dec.BlockHash
+	}
+	if dec.BlockNumber != nil {
+		g.BlockNumber = uint64(*dec.BlockNumber)
+	}
+	if dec.TxHash != nil {
+		g.TxHash = *dec.TxHash
+	}
+	if dec.TxIndex != nil {
+		g.TxIndex = uint64(*dec.TxIndex)
+	}
+	if dec.Withdrawal != nil {
+		g.Withdrawal = dec.Withdrawal
+	}
+	if dec.ReplaceByFee != nil {
+		g.ReplaceByFee = dec.ReplaceByFee
+	}
+	if dec.Cancel1 != nil {
+		g.Cancel1 = dec.Cancel1
+	}
+	if dec.Cancel2 != nil {
+		g.Cancel2 = dec.Cancel2
+	}
+	if dec.Paid != nil {
+		g.Paid = dec.Paid
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+var _ = (*goatWithdrawalLifecycleMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (g GoatWithdrawalLifecycle) MarshalJSON() ([]byte, error) {
+	type GoatWithdrawalLifecycle struct {
+		Id         hexutil.Uint64         `json:"id"`
+		Status     string                 `json:"status"`
+		MaxTxPrice hexutil.Uint64         `json:"max_tx_price"`
+		Withdrawal *BridgeWithdrawal      `json:"withdrawal"`
+		Paid       *goattypes.PaidTx      `json:"paid,omitempty"`
+		Events     []*GoatWithdrawalEvent `json:"events"`
+		Incomplete bool                   `json:"incomplete,omitempty"`
+	}
+	var enc GoatWithdrawalLifecycle
+	enc.Id = hexutil.Uint64(g.Id)
+	enc.Status = g.Status
+	enc.MaxTxPrice = hexutil.Uint64(g.MaxTxPrice)
+	enc.Withdrawal = g.Withdrawal
+	enc.Paid = g.Paid
+	enc.Events = g.Events
+	enc.Incomplete = g.Incomplete
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (g *GoatWithdrawalLifecycle) UnmarshalJSON(input []byte) error {
+	type GoatWithdrawalLifecycle struct {
+		Id         *hexutil.Uint64        `json:"id"`
+		Status     *string                `json:"status"`
+		MaxTxPrice *hexutil.Uint64        `json:"max_tx_price"`
+		Withdrawal *BridgeWithdrawal      `json:"withdrawal"`
+		Paid       *goattypes.PaidTx      `json:"paid,omitempty"`
+		Events     []*GoatWithdrawalEvent `json:"events"`
+		Incomplete *bool                  `json:"incomplete,omitempty"`
+	}
+	var dec GoatWithdrawalLifecycle
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		g.Id = uint64(*dec.Id)
+	}
+	if dec.Status != nil {
+		g.Status = *dec.Status
+	}
+	if dec.MaxTxPrice != nil {
+		g.MaxTxPrice = uint64(*dec.MaxTxPrice)
+	}
+	if dec.Withdrawal != nil {
+		g.Withdrawal = dec.Withdrawal
+	}
+	if dec.Paid != nil {
+		g.Paid = dec.Paid
+	}
+	if dec.Events != nil {
+		g.Events = dec.Events
+	}
+	if dec.Incomplete != nil {
+		g.Incomplete = *dec.Incomplete
+	}
+	return nil
+}
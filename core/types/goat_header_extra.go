@@ -0,0 +1,102 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The versions of the goat header extra
+//
+// V0: goat tx count (uint8) + goat tx root
+// V1: version + goat tx count (uint16 big endian) + goat tx root + consensus block hash
+//
+// The later versions should only append the new commitments to the previous layout,
+// so the older parsers can still read the known fields.
+const (
+	GoatHeaderExtraV0 uint8 = iota
+	GoatHeaderExtraV1
+)
+
+var (
+	errGoatHeaderExtraLength  = errors.New("invalid goat header extra length")
+	errGoatHeaderExtraVersion = errors.New("unsupported goat header extra version")
+)
+
+// GoatHeaderExtra is the goat commitments in the header extra
+type GoatHeaderExtra struct {
+	Version            uint8
+	TxCount            uint64      // the number of goat txs at the beginning of the block
+	TxRoot             common.Hash // the root hash of the goat txs
+	ConsensusBlockHash common.Hash // the consensus layer block hash, since V1
+}
+
+// Encode encodes the goat header extra with its version
+func (e *GoatHeaderExtra) Encode() ([]byte, error) {
+	switch e.Version {
+	case GoatHeaderExtraV0:
+		if e.TxCount > math.MaxUint8 {
+			return nil, fmt.Errorf("too many goat txs for header extra v0: %d", e.TxCount)
+		}
+		b := make([]byte, 0, params.GoatHeaderExtraLengthV0)
+		b = append(b, uint8(e.TxCount))
+		return append(b, e.TxRoot[:]...), nil
+	case GoatHeaderExtraV1:
+		if e.TxCount > math.MaxUint16 {
+			return nil, fmt.Errorf("too many goat txs for header extra v1: %d", e.TxCount)
+		}
+		b := make([]byte, 0, params.GoatHeaderExtraLengthV1)
+		b = append(b, e.Version)
+		b = binary.BigEndian.AppendUint16(b, uint16(e.TxCount))
+		b = append(b, e.TxRoot[:]...)
+		return append(b, e.ConsensusBlockHash[:]...), nil
+	}
+	return nil, fmt.Errorf("%w: %d", errGoatHeaderExtraVersion, e.Version)
+}
+
+// DecodeGoatHeaderExtra decodes the header extra with the version activated at the block,
+// it's used by the consensus rules, so the extra must be exactly in the given version
+func DecodeGoatHeaderExtra(version uint8, extra []byte) (*GoatHeaderExtra, error) {
+	switch version {
+	case GoatHeaderExtraV0:
+		if len(extra) != params.GoatHeaderExtraLengthV0 {
+			return nil, fmt.Errorf("%w: expect %d got %d", errGoatHeaderExtraLength, params.GoatHeaderExtraLengthV0, len(extra))
+		}
+	case GoatHeaderExtraV1:
+		if len(extra) != params.GoatHeaderExtraLengthV1 {
+			return nil, fmt.Errorf("%w: expect %d got %d", errGoatHeaderExtraLength, params.GoatHeaderExtraLengthV1, len(extra))
+		}
+		if extra[0] != version {
+			return nil, fmt.Errorf("%w: expect %d got %d", errGoatHeaderExtraVersion, version, extra[0])
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", errGoatHeaderExtraVersion, version)
+	}
+	return ParseGoatHeaderExtra(extra)
+}
+
+// ParseGoatHeaderExtra parses the header extra without knowing the chain config
+// The V0 extra is detected by its length, and the known fields are read from
+// the extra of the newer versions with the unknown commitments ignored
+func ParseGoatHeaderExtra(extra []byte) (*GoatHeaderExtra, error) {
+	if len(extra) == params.GoatHeaderExtraLengthV0 {
+		return &GoatHeaderExtra{
+			Version: GoatHeaderExtraV0,
+			TxCount: uint64(extra[0]),
+			TxRoot:  common.BytesToHash(extra[1:]),
+		}, nil
+	}
+	if len(extra) < params.GoatHeaderExtraLengthV1 || extra[0] < GoatHeaderExtraV1 {
+		return nil, fmt.Errorf("%w: %d", errGoatHeaderExtraLength, len(extra))
+	}
+	return &GoatHeaderExtra{
+		Version:            extra[0],
+		TxCount:            uint64(binary.BigEndian.Uint16(extra[1:3])),
+		TxRoot:             common.BytesToHash(extra[3:35]),
+		ConsensusBlockHash: common.BytesToHash(extra[35:67]),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGoatHeaderExtra(t *testing.T) {
+	tests := []*GoatHeaderExtra{
+		{Version: GoatHeaderExtraV0, TxCount: 2, TxRoot: common.Hash{0x1}},
+		{Version: GoatHeaderExtraV0, TxCount: 255, TxRoot: common.Hash{0x1}},
+		{Version: GoatHeaderExtraV1, TxCount: 300, TxRoot: common.Hash{0x1}, ConsensusBlockHash: common.Hash{0x2}},
+	}
+	for i, test := range tests {
+		enc, err := test.Encode()
+		if err != nil {
+			t.Fatalf("test %d: failed to encode: %v", i, err)
+		}
+		got, err := DecodeGoatHeaderExtra(test.Version, enc)
+		if err != nil {
+			t.Fatalf("test %d: failed to decode: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, test) {
+			t.Errorf("test %d: decoded mismatched: got %v want %v", i, got, test)
+		}
+	}
+
+	// the legacy layout
+	v0 := append([]byte{3}, common.Hash{0x1}.Bytes()...)
+	if got, err := DecodeGoatHeaderExtra(GoatHeaderExtraV0, v0); err != nil || got.TxCount != 3 || got.TxRoot != (common.Hash{0x1}) {
+		t.Errorf("failed to decode legacy extra: %v %v", got, err)
+	}
+
+	if _, err := (&GoatHeaderExtra{Version: GoatHeaderExtraV0, TxCount: 256}).Encode(); err == nil {
+		t.Error("expect error for too many goat txs in v0")
+	}
+	if _, err := (&GoatHeaderExtra{Version: 2}).Encode(); err == nil {
+		t.Error("expect error for unknown version")
+	}
+}
+
+func TestGoatHeaderExtraVersionMismatch(t *testing.T) {
+	v1, _ := (&GoatHeaderExtra{Version: GoatHeaderExtraV1, TxCount: 1}).Encode()
+	if _, err := DecodeGoatHeaderExtra(GoatHeaderExtraV0, v1); err == nil {
+		t.Error("expect error for v1 extra before the fork")
+	}
+	v0, _ := (&GoatHeaderExtra{Version: GoatHeaderExtraV0, TxCount: 1}).Encode()
+	if _, err := DecodeGoatHeaderExtra(GoatHeaderExtraV1, v0); err == nil {
+		t.Error("expect error for v0 extra after the fork")
+	}
+	if _, err := DecodeGoatHeaderExtra(GoatHeaderExtraV1, append(v1, 0x1)); err == nil {
+		t.Error("expect error for trailing bytes")
+	}
+}
+
+func TestParseGoatHeaderExtraForwardCompatible(t *testing.T) {
+	want := &GoatHeaderExtra{Version: GoatHeaderExtraV1, TxCount: 1, TxRoot: common.Hash{0x1}, ConsensusBlockHash: common.Hash{0x2}}
+	enc, _ := want.Encode()
+
+	// a future version appends the new commitments
+	future := bytes.Clone(enc)
+	future[0] = 2
+	future = append(future, common.Hash{0x3}.Bytes()...)
+
+	got, err := ParseGoatHeaderExtra(future)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Version = 2
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsed mismatched: got %v want %v", got, want)
+	}
+	if _, err := DecodeGoatHeaderExtra(2, future); err == nil {
+		t.Error("expect error for unsupported version in consensus decoding")
+	}
+	if _, err := ParseGoatHeaderExtra(make([]byte, params.GoatHeaderExtraLengthV0+1)); err == nil {
+		t.Error("expect error for invalid length")
+	}
+}
@@ -0,0 +1,145 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// GoatEventDecoder decodes a system contract event into a goat request
+type GoatEventDecoder func(topics []common.Hash, data []byte) (RequestData, error)
+
+// GoatRequestKind declares a goat request type of a module
+type GoatRequestKind struct {
+	Type byte
+	Name string             // the name in the apis and tracers, e.g. "withdrawal"
+	Key  string             // the JSON key of the request list in the engine payloads before V5, e.g. "bridgeWithdrawalsRequests"
+	New  func() RequestData // creates an empty request to decode into
+}
+
+// GoatModule declares a goat module, the system contract events it decodes into the
+// goat requests and the request types. The goat tx actions of the module are declared
+// by goattypes.RegisterModule.
+type GoatModule struct {
+	Name   string
+	Module goattypes.Module // the goat tx module, zero if the module has no goat txs
+
+	// Contract returns the system contract emitting the request events, it's nil if
+	// the requests are not emitted by the events.
+	Contract func(addrs params.GoatAddresses) common.Address
+	// Events are the request events by topic, the unknown or malformed events are
+	// rejected with the strict events fork.
+	Events map[common.Hash]GoatEventDecoder
+	// Lenient decodes the events before the strict events fork, the unknown events
+	// are skipped. It's optional for the modules introduced after the fork.
+	Lenient func(topics []common.Hash, data []byte) (Requests, error)
+	// Ignored are the topics of the events which are known to carry no request,
+	// they're skipped in both modes.
+	Ignored []common.Hash
+	// Active returns whether the events of the module carry requests at the given
+	// timestamp, the events are skipped before. It's nil if the module is active
+	// since the genesis.
+	Active func(config *params.GoatConfig, time uint64) bool
+
+	Requests []*GoatRequestKind
+}
+
+// IsIgnoredEvent returns whether the event is known to carry no request
+func (m *GoatModule) IsIgnoredEvent(topics []common.Hash) bool {
+	return len(topics) != 0 && slices.Contains(m.Ignored, topics[0])
+}
+
+// IsActive returns whether the events of the module carry requests at the given timestamp
+func (m *GoatModule) IsActive(config *params.GoatConfig, time uint64) bool {
+	return m.Active == nil || m.Active(config, time)
+}
+
+// DecodeEvent decodes the requests of the system contract event
+func (m *GoatModule) DecodeEvent(strict bool, topics []common.Hash, data []byte) (Requests, error) {
+	if m.IsIgnoredEvent(topics) {
+		return nil, nil
+	}
+	if strict || m.Lenient == nil {
+		return getGoatRequestsStrict(m.Events, topics, data)
+	}
+	return m.Lenient(topics, data)
+}
+
+var (
+	goatModulesLock  sync.RWMutex
+	goatModules      []*GoatModule
+	goatRequestKinds = make(map[byte]*GoatRequestKind)
+)
+
+// RegisterGoatModule registers a goat module, it should be called in the init function
+// of the package which defines the module. It panics if the module is invalid or any of
+// its request types is already registered.
+func RegisterGoatModule(m *GoatModule) {
+	if m.Name == "" {
+		panic("goat module without name")
+	}
+	if m.Module != 0 && goattypes.LookupModule(m.Module) == nil {
+		panic(fmt.Sprintf("goat module %s: unregistered goat tx module %d", m.Name, m.Module))
+	}
+	if m.Contract != nil && len(m.Events) == 0 {
+		panic(fmt.Sprintf("goat module %s: no events", m.Name))
+	}
+
+	goatModulesLock.Lock()
+	defer goatModulesLock.Unlock()
+	for _, kind := range m.Requests {
+		if kind.Type == DepositRequestType || kind.Name == "" || kind.Key == "" || kind.New == nil {
+			panic(fmt.Sprintf("goat module %s: invalid request type %#x", m.Name, kind.Type))
+		}
+		if _, ok := goatRequestKinds[kind.Type]; ok {
+			panic(fmt.Sprintf("goat module %s: duplicate request type %#x", m.Name, kind.Type))
+		}
+	}
+	for _, kind := range m.Requests {
+		goatRequestKinds[kind.Type] = kind
+	}
+	goatModules = append(goatModules, m)
+}
+
+// GoatModules returns the registered goat modules
+func GoatModules() []*GoatModule {
+	goatModulesLock.RLock()
+	defer goatModulesLock.RUnlock()
+	return slices.Clone(goatModules)
+}
+
+// GoatEventModule returns the goat module whose system contract is the given address,
+// or nil if the address is not a system contract emitting the request events.
+func GoatEventModule(addrs params.GoatAddresses, address common.Address) *GoatModule {
+	goatModulesLock.RLock()
+	defer goatModulesLock.RUnlock()
+	for _, m := range goatModules {
+		if m.Contract != nil && m.Contract(addrs) == address {
+			return m
+		}
+	}
+	return nil
+}
+
+// GoatRequestKinds returns the registered goat request types in the type order
+func GoatRequestKinds() []*GoatRequestKind {
+	goatModulesLock.RLock()
+	defer goatModulesLock.RUnlock()
+	res := make([]*GoatRequestKind, 0, len(goatRequestKinds))
+	for _, kind := range goatRequestKinds {
+		res = append(res, kind)
+	}
+	slices.SortFunc(res, func(a, b *GoatRequestKind) int { return int(a.Type) - int(b.Type) })
+	return res
+}
+
+// LookupGoatRequestKind returns the registered goat request type, or nil if it's unknown
+func LookupGoatRequestKind(typ byte) *GoatRequestKind {
+	goatModulesLock.RLock()
+	defer goatModulesLock.RUnlock()
+	return goatRequestKinds[typ]
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGoatModules(t *testing.T) {
+	names := make(map[string]bool)
+	for typ := GoatGasRevenueRequestType; typ <= GoatGrantRequestType; typ++ {
+		kind := LookupGoatRequestKind(byte(typ))
+		if kind == nil {
+			t.Fatalf("request type %#x is not registered", typ)
+		}
+		if names[kind.Name] {
+			t.Fatalf("duplicate request name %s", kind.Name)
+		}
+		names[kind.Name] = true
+		if have := kind.New().requestType(); have != byte(typ) {
+			t.Fatalf("request type %s mismatch: have %#x want %#x", kind.Name, have, typ)
+		}
+	}
+	if kinds := GoatRequestKinds(); len(kinds) != len(names) || kinds[0].Type != GoatGasRevenueRequestType {
+		t.Fatalf("request types mismatch: %d", len(kinds))
+	}
+
+	addrs := (&params.GoatConfig{Addresses: &params.GoatAddresses{Bridge: common.Address{0x1}}}).SystemAddresses()
+	if m := GoatEventModule(addrs, common.Address{0x1}); m == nil || m.Module != goattypes.BirdgeModule {
+		t.Fatalf("bridge module not found by the configured address")
+	}
+	if m := GoatEventModule(addrs, goattypes.BridgeContract); m != nil {
+		t.Fatalf("unexpected module %s for the default bridge address", m.Name)
+	}
+	if m := GoatEventModule(addrs, goattypes.LockingContract); m == nil || m.Name != "locking" {
+		t.Fatalf("locking module not found")
+	}
+
+	// the registered request types are decoded by the request
+	enc, err := NewRequest(&Grant{Amount: big.NewInt(1)}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var req Request
+	if err := req.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if grant, ok := req.Inner().(*Grant); !ok || grant.Amount.Uint64() != 1 {
+		t.Fatalf("decoded request mismatch: %v", req.Inner())
+	}
+	if err := req.UnmarshalBinary([]byte{0x7f, 0xc0}); err != ErrRequestTypeNotSupported {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("duplicate request type is registered")
+		}
+	}()
+	RegisterGoatModule(&GoatModule{Name: "dup", Requests: []*GoatRequestKind{{Type: GoatGrantRequestType, Name: "dup", New: func() RequestData { return new(Grant) }}}})
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -17,6 +19,10 @@ type BridgeWithdrawal struct {
 	Amount     uint64 `json:"amount_in_satoshi"`
 	MaxTxPrice uint64 `json:"max_tx_price"`
 	Address    string `json:"address"`
+
+	// InvalidAddress is set if the address is not a valid bitcoin address since the
+	// address check fork, the withdrawal should be cancelled by the consensus layer
+	InvalidAddress bool `json:"invalid_address,omitempty" rlp:"optional"`
 }
 
 type bridgeWithdrawMarshaling struct {
@@ -28,7 +34,6 @@ type bridgeWithdrawMarshaling struct {
 var (
 	withdrawalAddressLocation = big.NewInt(128)
 	maxWithdrawalAddressLen   = big.NewInt(90)
-	satoshi                   = big.NewInt(1e10)
 )
 
 func UnpackIntoBridgeWithdraw(topics []common.Hash, data []byte) (*BridgeWithdrawal, error) {
@@ -46,7 +51,7 @@ func UnpackIntoBridgeWithdraw(topics []common.Hash, data []byte) (*BridgeWithdra
 	}
 
 	amount := new(big.Int).SetBytes(data[:32]) // amount
-	_, dust := amount.DivMod(amount, satoshi, new(big.Int))
+	_, dust := amount.DivMod(amount, big.NewInt(params.Satoshi), new(big.Int))
 	if !amount.IsUint64() {
 		return nil, fmt.Errorf("withdrawal amount is too large: %d", amount)
 	}
@@ -109,6 +114,8 @@ func (d *BridgeWithdrawal) copy() RequestData {
 		Amount:     d.Amount,
 		MaxTxPrice: d.MaxTxPrice,
 		Address:    d.Address,
+
+		InvalidAddress: d.InvalidAddress,
 	}
 }
 
@@ -256,3 +263,19 @@ func GetBridgeRequests(topics []common.Hash, data []byte) (Requests, error) {
 
 	return reqs, nil
 }
+
+func init() {
+	RegisterGoatModule(&GoatModule{
+		Name:     "bridge",
+		Module:   goattypes.BirdgeModule,
+		Contract: func(addrs params.GoatAddresses) common.Address { return addrs.Bridge },
+		Events:   bridgeEventUnpackers,
+		Lenient:  GetBridgeRequests,
+		Ignored:  []common.Hash{GoatDepositTopic, GoatPaidTopic, GoatCanceledTopic, GoatRefundTopic},
+		Requests: []*GoatRequestKind{
+			{Type: GoatWithdrawalRequestType, Name: "withdrawal", Key: "bridgeWithdrawalsRequests", New: func() RequestData { return new(BridgeWithdrawal) }},
+			{Type: GoatReplaceByFeeRequestType, Name: "replaceByFee", Key: "rbfRequests", New: func() RequestData { return new(ReplaceByFee) }},
+			{Type: GoatCancel1RequestType, Name: "cancel1", Key: "cancel1Requests", New: func() RequestData { return new(Cancel1) }},
+		},
+	})
+}
@@ -2,14 +2,18 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
 //go:generate go run github.com/fjl/gencodec -type GasRevenue -field-override gasRevenueMarshaling -out gen_goat_request_gas_revenue.go
+
 type GasRevenue struct {
 	Amount *big.Int `json:"amount"`
 }
@@ -21,7 +25,6 @@ type gasRevenueMarshaling struct {
 type GasRevenues []*GasRevenue
 
 func (s GasRevenues) Len() int { return len(s) }
-
 func (s GasRevenues) EncodeIndex(i int, w *bytes.Buffer) {
 	rlp.Encode(w, s[i])
 }
@@ -48,6 +51,435 @@ func (d *GasRevenue) copy() RequestData {
 	}
 }
 
+//go:generate go run github.com/fjl/gencodec -type CreateValidator -field-override createValidatorMarshaling -out gen_goat_request_create_validator_json.go
+
+type CreateValidator struct {
+	Validator common.Address `json:"validator"`
+	Pubkey    []byte         `json:"pubkey"`
+}
+
+type createValidatorMarshaling struct {
+	Pubkey hexutil.Bytes
+}
+
+type CreateValidators []*CreateValidator
+
+func (s CreateValidators) Len() int { return len(s) }
+func (s CreateValidators) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// CreateValidators requests as Request objects.
+func (s CreateValidators) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *CreateValidator) requestType() byte            { return GoatCreateValidatorRequestType }
+func (d *CreateValidator) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *CreateValidator) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *CreateValidator) copy() RequestData {
+	return &CreateValidator{
+		Validator: d.Validator,
+		Pubkey:    common.CopyBytes(d.Pubkey),
+	}
+}
+
+func UnpackIntoCreateValidator(topics []common.Hash, data []byte) (*CreateValidator, error) {
+	if len(topics) != 2 {
+		return nil, fmt.Errorf("invalid CreateValidator event topics length: expect 2 got %d", len(topics))
+	}
+	if len(data) != 64 {
+		return nil, fmt.Errorf("CreateValidator wrong length: want 64, have %d", len(data))
+	}
+	return &CreateValidator{
+		Validator: common.BytesToAddress(topics[1][:]),
+		Pubkey:    common.CopyBytes(data),
+	}, nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type Lock -field-override lockMarshaling -out gen_goat_request_lock_json.go
+
+type Lock struct {
+	Validator common.Address `json:"validator"`
+	Token     common.Address `json:"token"`
+	Amount    *big.Int       `json:"amount"`
+}
+
+type lockMarshaling struct {
+	Amount *hexutil.Big
+}
+
+type Locks []*Lock
+
+func (s Locks) Len() int { return len(s) }
+func (s Locks) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// Locks requests as Request objects.
+func (s Locks) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *Lock) requestType() byte            { return GoatLockRequestType }
+func (d *Lock) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *Lock) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *Lock) copy() RequestData {
+	return &Lock{
+		Validator: d.Validator,
+		Token:     d.Token,
+		Amount:    new(big.Int).Set(d.Amount),
+	}
+}
+
+func UnpackIntoLock(topics []common.Hash, data []byte) (*Lock, error) {
+	if len(topics) != 2 {
+		return nil, fmt.Errorf("invalid Lock event topics length: expect 2 got %d", len(topics))
+	}
+	if len(data) != 64 {
+		return nil, fmt.Errorf("Lock wrong length: want 64, have %d", len(data))
+	}
+	return &Lock{
+		Validator: common.BytesToAddress(topics[1][:]),
+		Token:     common.BytesToAddress(data[:32]),
+		Amount:    new(big.Int).SetBytes(data[32:64]),
+	}, nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type Unlock -field-override unlockMarshaling -out gen_goat_request_unlock_json.go
+
+type Unlock struct {
+	Id        uint64         `json:"id"`
+	Validator common.Address `json:"validator"`
+	Recipient common.Address `json:"recipient"`
+	Token     common.Address `json:"token"`
+	Amount    *big.Int       `json:"amount"`
+}
+
+type unlockMarshaling struct {
+	Id     hexutil.Uint64
+	Amount *hexutil.Big
+}
+
+type Unlocks []*Unlock
+
+func (s Unlocks) Len() int { return len(s) }
+func (s Unlocks) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// Unlocks requests as Request objects.
+func (s Unlocks) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *Unlock) requestType() byte            { return GoatUnlockRequestType }
+func (d *Unlock) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *Unlock) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *Unlock) copy() RequestData {
+	return &Unlock{
+		Id:        d.Id,
+		Validator: d.Validator,
+		Recipient: d.Recipient,
+		Token:     d.Token,
+		Amount:    new(big.Int).Set(d.Amount),
+	}
+}
+
+func UnpackIntoUnlock(topics []common.Hash, data []byte) (*Unlock, error) {
+	if len(topics) != 2 {
+		return nil, fmt.Errorf("invalid Unlock event topics length: expect 2 got %d", len(topics))
+	}
+	if len(data) != 128 {
+		return nil, fmt.Errorf("Unlock wrong length: want 128, have %d", len(data))
+	}
+	id := new(big.Int).SetBytes(data[:32])
+	if !id.IsUint64() {
+		return nil, fmt.Errorf("unlock id is too large")
+	}
+	return &Unlock{
+		Id:        id.Uint64(),
+		Validator: common.BytesToAddress(topics[1][:]),
+		Recipient: common.BytesToAddress(data[32:64]),
+		Token:     common.BytesToAddress(data[64:96]),
+		Amount:    new(big.Int).SetBytes(data[96:128]),
+	}, nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type Claim -field-override claimMarshaling -out gen_goat_request_claim_json.go
+
+type Claim struct {
+	Id        uint64         `json:"id"`
+	Validator common.Address `json:"validator"`
+	Recipient common.Address `json:"recipient"`
+}
+
+type claimMarshaling struct {
+	Id hexutil.Uint64
+}
+
+type Claims []*Claim
+
+func (s Claims) Len() int { return len(s) }
+func (s Claims) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// Claims requests as Request objects.
+func (s Claims) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *Claim) requestType() byte            { return GoatClaimRequestType }
+func (d *Claim) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *Claim) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *Claim) copy() RequestData {
+	return &Claim{
+		Id:        d.Id,
+		Validator: d.Validator,
+		Recipient: d.Recipient,
+	}
+}
+
+func UnpackIntoClaim(topics []common.Hash, data []byte) (*Claim, error) {
+	if len(topics) != 2 {
+		return nil, fmt.Errorf("invalid Claim event topics length: expect 2 got %d", len(topics))
+	}
+	if len(data) != 64 {
+		return nil, fmt.Errorf("Claim wrong length: want 64, have %d", len(data))
+	}
+	id := new(big.Int).SetBytes(data[:32])
+	if !id.IsUint64() {
+		return nil, fmt.Errorf("claim id is too large")
+	}
+	return &Claim{
+		Id:        id.Uint64(),
+		Validator: common.BytesToAddress(topics[1][:]),
+		Recipient: common.BytesToAddress(data[32:64]),
+	}, nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type UpdateTokenWeight -field-override updateTokenWeightMarshaling -out gen_goat_request_token_weight_json.go
+
+type UpdateTokenWeight struct {
+	Token  common.Address `json:"token"`
+	Weight uint64         `json:"weight"`
+}
+
+type updateTokenWeightMarshaling struct {
+	Weight hexutil.Uint64
+}
+
+type UpdateTokenWeights []*UpdateTokenWeight
+
+func (s UpdateTokenWeights) Len() int { return len(s) }
+func (s UpdateTokenWeights) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// UpdateTokenWeights requests as Request objects.
+func (s UpdateTokenWeights) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *UpdateTokenWeight) requestType() byte            { return GoatUpdateTokenWeightRequestType }
+func (d *UpdateTokenWeight) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *UpdateTokenWeight) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *UpdateTokenWeight) copy() RequestData {
+	return &UpdateTokenWeight{
+		Token:  d.Token,
+		Weight: d.Weight,
+	}
+}
+
+func UnpackIntoUpdateTokenWeight(topics []common.Hash, data []byte) (*UpdateTokenWeight, error) {
+	if len(topics) != 1 {
+		return nil, fmt.Errorf("invalid UpdateTokenWeight event topics length: expect 1 got %d", len(topics))
+	}
+	if len(data) != 64 {
+		return nil, fmt.Errorf("UpdateTokenWeight wrong length: want 64, have %d", len(data))
+	}
+	weight := new(big.Int).SetBytes(data[32:64])
+	if !weight.IsUint64() {
+		return nil, fmt.Errorf("token weight is too large")
+	}
+	return &UpdateTokenWeight{
+		Token:  common.BytesToAddress(data[:32]),
+		Weight: weight.Uint64(),
+	}, nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type UpdateTokenThreshold -field-override updateTokenThresholdMarshaling -out gen_goat_request_token_threshold_json.go
+
+type UpdateTokenThreshold struct {
+	Token     common.Address `json:"token"`
+	Threshold *big.Int       `json:"threshold"`
+}
+
+type updateTokenThresholdMarshaling struct {
+	Threshold *hexutil.Big
+}
+
+type UpdateTokenThresholds []*UpdateTokenThreshold
+
+func (s UpdateTokenThresholds) Len() int { return len(s) }
+func (s UpdateTokenThresholds) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// UpdateTokenThresholds requests as Request objects.
+func (s UpdateTokenThresholds) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *UpdateTokenThreshold) requestType() byte            { return GoatUpdateTokenThresholdRequestType }
+func (d *UpdateTokenThreshold) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *UpdateTokenThreshold) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *UpdateTokenThreshold) copy() RequestData {
+	return &UpdateTokenThreshold{
+		Token:     d.Token,
+		Threshold: new(big.Int).Set(d.Threshold),
+	}
+}
+
+func UnpackIntoUpdateTokenThreshold(topics []common.Hash, data []byte) (*UpdateTokenThreshold, error) {
+	if len(topics) != 1 {
+		return nil, fmt.Errorf("invalid UpdateTokenThreshold event topics length: expect 1 got %d", len(topics))
+	}
+	if len(data) != 64 {
+		return nil, fmt.Errorf("UpdateTokenThreshold wrong length: want 64, have %d", len(data))
+	}
+	return &UpdateTokenThreshold{
+		Token:     common.BytesToAddress(data[:32]),
+		Threshold: new(big.Int).SetBytes(data[32:64]),
+	}, nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type Grant -field-override grantMarshaling -out gen_goat_request_grant_json.go
+
+type Grant struct {
+	Amount *big.Int `json:"amount"`
+}
+
+type grantMarshaling struct {
+	Amount *hexutil.Big
+}
+
+type Grants []*Grant
+
+func (s Grants) Len() int { return len(s) }
+func (s Grants) EncodeIndex(i int, w *bytes.Buffer) {
+	rlp.Encode(w, s[i])
+}
+
+// Requests creates a deep copy of each deposit and returns a slice of the
+// Grants requests as Request objects.
+func (s Grants) Requests() (reqs Requests) {
+	for _, d := range s {
+		reqs = append(reqs, NewRequest(d))
+	}
+	return
+}
+
+func (d *Grant) requestType() byte            { return GoatGrantRequestType }
+func (d *Grant) encode(b *bytes.Buffer) error { return rlp.Encode(b, d) }
+func (d *Grant) decode(input []byte) error    { return rlp.DecodeBytes(input, d) }
+func (d *Grant) copy() RequestData {
+	return &Grant{
+		Amount: new(big.Int).Set(d.Amount),
+	}
+}
+
+func UnpackIntoGrant(topics []common.Hash, data []byte) (*Grant, error) {
+	if len(topics) != 1 {
+		return nil, fmt.Errorf("invalid Grant event topics length: expect 1 got %d", len(topics))
+	}
+	if len(data) != 32 {
+		return nil, fmt.Errorf("Grant wrong length: want 32, have %d", len(data))
+	}
+	return &Grant{Amount: new(big.Int).SetBytes(data)}, nil
+}
+
 func GetLockingRequests(topics []common.Hash, data []byte) (Requests, error) {
-	return nil, nil
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	var (
+		req RequestData
+		err error
+	)
+	switch topics[0] {
+	case GoatCreateValidatorTopic:
+		req, err = UnpackIntoCreateValidator(topics, data)
+	case GoatLockTopic:
+		req, err = UnpackIntoLock(topics, data)
+	case GoatUnlockTopic:
+		req, err = UnpackIntoUnlock(topics, data)
+	case GoatClaimTopic:
+		req, err = UnpackIntoClaim(topics, data)
+	case GoatUpdateTokenWeightTopic:
+		req, err = UnpackIntoUpdateTokenWeight(topics, data)
+	case GoatUpdateTokenThresholdTopic:
+		req, err = UnpackIntoUpdateTokenThreshold(topics, data)
+	case GoatGrantTopic:
+		req, err = UnpackIntoGrant(topics, data)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Requests{NewRequest(req)}, nil
+}
+
+func init() {
+	// the gas revenue is not emitted by the system contracts, it's appended by the state processor
+	RegisterGoatModule(&GoatModule{
+		Name: "gas",
+		Requests: []*GoatRequestKind{
+			{Type: GoatGasRevenueRequestType, Name: "gasRevenue", Key: "gasRevenueRequests", New: func() RequestData { return new(GasRevenue) }},
+		},
+	})
+	RegisterGoatModule(&GoatModule{
+		Name:     "locking",
+		Module:   goattypes.LockingModule,
+		Contract: func(addrs params.GoatAddresses) common.Address { return addrs.Locking },
+		Events:   lockingEventUnpackers,
+		Lenient:  GetLockingRequests,
+		Active:   (*params.GoatConfig).IsLockingRequests,
+		Requests: []*GoatRequestKind{
+			{Type: GoatCreateValidatorRequestType, Name: "createValidator", Key: "createValidatorRequests", New: func() RequestData { return new(CreateValidator) }},
+			{Type: GoatLockRequestType, Name: "lock", Key: "lockRequests", New: func() RequestData { return new(Lock) }},
+			{Type: GoatUnlockRequestType, Name: "unlock", Key: "unlockRequests", New: func() RequestData { return new(Unlock) }},
+			{Type: GoatClaimRequestType, Name: "claim", Key: "claimRequests", New: func() RequestData { return new(Claim) }},
+			{Type: GoatUpdateTokenWeightRequestType, Name: "updateTokenWeight", Key: "updateTokenWeightRequests", New: func() RequestData { return new(UpdateTokenWeight) }},
+			{Type: GoatUpdateTokenThresholdRequestType, Name: "updateTokenThreshold", Key: "updateTokenThresholdRequests", New: func() RequestData { return new(UpdateTokenThreshold) }},
+			{Type: GoatGrantRequestType, Name: "grant", Key: "grantRequests", New: func() RequestData { return new(Grant) }},
+		},
+	})
 }
@@ -4,6 +4,9 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 func TestNewGoatGasRevenue(t *testing.T) {
@@ -34,3 +37,341 @@ func TestNewGoatGasRevenue(t *testing.T) {
 		})
 	}
 }
+
+func TestUnpackIntoCreateValidator(t *testing.T) {
+	type args struct {
+		topics []common.Hash
+		data   []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *CreateValidator
+		wantErr bool
+	}{
+		{
+			name: "1",
+			args: args{
+				topics: []common.Hash{
+					GoatCreateValidatorTopic,
+					common.HexToHash("0x000000000000000000000000d12a5a92d4621fbe3068914988d538c410245443"),
+				},
+				data: hexutil.MustDecode("0x023504e3cadac49656b8f0ac939b1665870c5eb60cd47541e401babb7ff99f2315bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e"),
+			},
+			want: &CreateValidator{
+				Validator: common.HexToAddress("0xd12a5a92D4621fBE3068914988D538c410245443"),
+				Pubkey:    hexutil.MustDecode("0x023504e3cadac49656b8f0ac939b1665870c5eb60cd47541e401babb7ff99f2315bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e"),
+			},
+		},
+		{
+			name: "short",
+			args: args{
+				topics: []common.Hash{
+					GoatCreateValidatorTopic,
+					common.HexToHash("0x000000000000000000000000d12a5a92d4621fbe3068914988d538c410245443"),
+				},
+				data: hexutil.MustDecode("0x023504e3cadac49656b8f0ac939b1665870c5eb60cd47541e401babb7ff99f23"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnpackIntoCreateValidator(tt.args.topics, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnpackIntoCreateValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnpackIntoCreateValidator() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			reqs, err := GetLockingRequests(tt.args.topics, tt.args.data)
+			if err != nil {
+				t.Errorf("UnpackIntoCreateValidator(): GetLockingRequests error = %v", err)
+				return
+			}
+			if len(reqs) != 1 {
+				t.Errorf("UnpackIntoCreateValidator(): GetLockingRequests length(1) != %d", len(reqs))
+				return
+			}
+			ty, ok := reqs[0].inner.(*CreateValidator)
+			if !ok {
+				t.Errorf("UnpackIntoCreateValidator(): GetLockingRequests not CreateValidator")
+				return
+			}
+			if !reflect.DeepEqual(got, ty) {
+				t.Errorf("UnpackIntoCreateValidator() = %v, want %v", got, tt.want)
+			}
+			if ty.requestType() != GoatCreateValidatorRequestType {
+				t.Errorf("UnpackIntoCreateValidator() = not GoatCreateValidatorRequestType")
+			}
+			if !reflect.DeepEqual(ty.copy(), ty) {
+				t.Errorf("UnpackIntoCreateValidator(): copy is not DeepEqual")
+			}
+		})
+	}
+}
+
+func TestUnpackIntoLock(t *testing.T) {
+	type args struct {
+		topics []common.Hash
+		data   []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Lock
+		wantErr bool
+	}{
+		{
+			name: "1",
+			args: args{
+				topics: []common.Hash{
+					GoatLockTopic,
+					common.HexToHash("0x000000000000000000000000d12a5a92d4621fbe3068914988d538c410245443"),
+				},
+				data: hexutil.MustDecode("0x0000000000000000000000007594e474ae8ee2e70f67401c466a9415610e02120000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+			},
+			want: &Lock{
+				Validator: common.HexToAddress("0xd12a5a92D4621fBE3068914988D538c410245443"),
+				Token:     common.HexToAddress("0x7594e474ae8ee2e70f67401c466a9415610e0212"),
+				Amount:    big.NewInt(1e18),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnpackIntoLock(tt.args.topics, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnpackIntoLock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnpackIntoLock() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			reqs, err := GetLockingRequests(tt.args.topics, tt.args.data)
+			if err != nil {
+				t.Errorf("UnpackIntoLock(): GetLockingRequests error = %v", err)
+				return
+			}
+			if len(reqs) != 1 {
+				t.Errorf("UnpackIntoLock(): GetLockingRequests length(1) != %d", len(reqs))
+				return
+			}
+			ty, ok := reqs[0].inner.(*Lock)
+			if !ok {
+				t.Errorf("UnpackIntoLock(): GetLockingRequests not Lock")
+				return
+			}
+			if !reflect.DeepEqual(got, ty) {
+				t.Errorf("UnpackIntoLock() = %v, want %v", got, tt.want)
+			}
+			if ty.requestType() != GoatLockRequestType {
+				t.Errorf("UnpackIntoLock() = not GoatLockRequestType")
+			}
+			if !reflect.DeepEqual(ty.copy(), ty) {
+				t.Errorf("UnpackIntoLock(): copy is not DeepEqual")
+			}
+		})
+	}
+}
+
+func TestUnpackIntoUnlock(t *testing.T) {
+	type args struct {
+		topics []common.Hash
+		data   []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Unlock
+		wantErr bool
+	}{
+		{
+			name: "1",
+			args: args{
+				topics: []common.Hash{
+					GoatUnlockTopic,
+					common.HexToHash("0x000000000000000000000000d12a5a92d4621fbe3068914988d538c410245443"),
+				},
+				data: hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000000000000070000000000000000000000005e4e4d79f08120352f04d638adec7d3892b2804500000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+			},
+			want: &Unlock{
+				Id:        7,
+				Validator: common.HexToAddress("0xd12a5a92D4621fBE3068914988D538c410245443"),
+				Recipient: common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+				Token:     common.Address{},
+				Amount:    big.NewInt(1e18),
+			},
+		},
+		{
+			name: "id-overflow",
+			args: args{
+				topics: []common.Hash{
+					GoatUnlockTopic,
+					common.HexToHash("0x000000000000000000000000d12a5a92d4621fbe3068914988d538c410245443"),
+				},
+				data: hexutil.MustDecode("0x00000000000000000000000000000000000000000000000100000000000000070000000000000000000000005e4e4d79f08120352f04d638adec7d3892b2804500000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnpackIntoUnlock(tt.args.topics, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnpackIntoUnlock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnpackIntoUnlock() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			reqs, err := GetLockingRequests(tt.args.topics, tt.args.data)
+			if err != nil {
+				t.Errorf("UnpackIntoUnlock(): GetLockingRequests error = %v", err)
+				return
+			}
+			if len(reqs) != 1 {
+				t.Errorf("UnpackIntoUnlock(): GetLockingRequests length(1) != %d", len(reqs))
+				return
+			}
+			ty, ok := reqs[0].inner.(*Unlock)
+			if !ok {
+				t.Errorf("UnpackIntoUnlock(): GetLockingRequests not Unlock")
+				return
+			}
+			if !reflect.DeepEqual(got, ty) {
+				t.Errorf("UnpackIntoUnlock() = %v, want %v", got, tt.want)
+			}
+			if ty.requestType() != GoatUnlockRequestType {
+				t.Errorf("UnpackIntoUnlock() = not GoatUnlockRequestType")
+			}
+			if !reflect.DeepEqual(ty.copy(), ty) {
+				t.Errorf("UnpackIntoUnlock(): copy is not DeepEqual")
+			}
+		})
+	}
+}
+
+func TestGetLockingRequests(t *testing.T) {
+	type args struct {
+		topics []common.Hash
+		data   []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    RequestData
+		wantErr bool
+	}{
+		{
+			name: "claim",
+			args: args{
+				topics: []common.Hash{
+					GoatClaimTopic,
+					common.HexToHash("0x000000000000000000000000d12a5a92d4621fbe3068914988d538c410245443"),
+				},
+				data: hexutil.MustDecode("0x00000000000000000000000000000000000000000000000000000000000000090000000000000000000000005e4e4d79f08120352f04d638adec7d3892b28045"),
+			},
+			want: &Claim{
+				Id:        9,
+				Validator: common.HexToAddress("0xd12a5a92D4621fBE3068914988D538c410245443"),
+				Recipient: common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+			},
+		},
+		{
+			name: "token-weight",
+			args: args{
+				topics: []common.Hash{GoatUpdateTokenWeightTopic},
+				data:   hexutil.MustDecode("0x0000000000000000000000007594e474ae8ee2e70f67401c466a9415610e02120000000000000000000000000000000000000000000000000000000000000064"),
+			},
+			want: &UpdateTokenWeight{
+				Token:  common.HexToAddress("0x7594e474ae8ee2e70f67401c466a9415610e0212"),
+				Weight: 100,
+			},
+		},
+		{
+			name: "token-threshold",
+			args: args{
+				topics: []common.Hash{GoatUpdateTokenThresholdTopic},
+				data:   hexutil.MustDecode("0x0000000000000000000000007594e474ae8ee2e70f67401c466a9415610e02120000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+			},
+			want: &UpdateTokenThreshold{
+				Token:     common.HexToAddress("0x7594e474ae8ee2e70f67401c466a9415610e0212"),
+				Threshold: big.NewInt(1e18),
+			},
+		},
+		{
+			name: "grant",
+			args: args{
+				topics: []common.Hash{GoatGrantTopic},
+				data:   hexutil.MustDecode("0x0000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+			},
+			want: &Grant{Amount: big.NewInt(1e18)},
+		},
+		{
+			name: "grant-invalid",
+			args: args{
+				topics: []common.Hash{GoatGrantTopic},
+				data:   nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown",
+			args: args{
+				topics: []common.Hash{GoatWithdrawalTopic},
+				data:   nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs, err := GetLockingRequests(tt.args.topics, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLockingRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want == nil {
+				if len(reqs) != 0 {
+					t.Errorf("GetLockingRequests() length(0) != %d", len(reqs))
+				}
+				return
+			}
+			if len(reqs) != 1 {
+				t.Errorf("GetLockingRequests() length(1) != %d", len(reqs))
+				return
+			}
+			if !reflect.DeepEqual(reqs[0].inner, tt.want) {
+				t.Errorf("GetLockingRequests() = %v, want %v", reqs[0].inner, tt.want)
+			}
+			if reqs[0].Type() != tt.want.requestType() {
+				t.Errorf("GetLockingRequests() = request type mismatched")
+			}
+
+			// check the rlp round trip
+			enc, err := reqs[0].MarshalBinary()
+			if err != nil {
+				t.Errorf("GetLockingRequests(): MarshalBinary error = %v", err)
+				return
+			}
+			dec := new(Request)
+			if err := dec.UnmarshalBinary(enc); err != nil {
+				t.Errorf("GetLockingRequests(): UnmarshalBinary error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(dec.inner, tt.want) {
+				t.Errorf("GetLockingRequests(): UnmarshalBinary = %v, want %v", dec.inner, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -118,3 +119,16 @@ func GetRelayerRequests(topics []common.Hash, data []byte) (Requests, error) {
 
 	return reqs, nil
 }
+
+func init() {
+	RegisterGoatModule(&GoatModule{
+		Name:     "relayer",
+		Contract: func(addrs params.GoatAddresses) common.Address { return addrs.Relayer },
+		Events:   relayerEventUnpackers,
+		Lenient:  GetRelayerRequests,
+		Requests: []*GoatRequestKind{
+			{Type: GoatAddVoterRequestType, Name: "addVoter", Key: "addVoterRequests", New: func() RequestData { return new(AddVoter) }},
+			{Type: GoatRemoveVoterRequestType, Name: "removeVoter", Key: "removeVoterRequests", New: func() RequestData { return new(RemoveVoter) }},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrUnknownGoatEvent is returned by the strict decoding if the system contract event
+// is not a known goat request event
+var ErrUnknownGoatEvent = errors.New("unknown goat event")
+
+var (
+	relayerEventUnpackers = map[common.Hash]GoatEventDecoder{
+		GoatAddVoterTopoic:   func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoAddVoter(t, d) },
+		GoatRemoveVoterTopic: func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoRemoveVoter(t, d) },
+	}
+
+	bridgeEventUnpackers = map[common.Hash]GoatEventDecoder{
+		GoatWithdrawalTopic:   unpackIntoBridgeWithdrawStrict,
+		GoatReplaceByFeeTopic: func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoReplaceByFee(t, d) },
+		GoatCancel1Topic:      func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoCancel1(t, d) },
+	}
+
+	lockingEventUnpackers = map[common.Hash]GoatEventDecoder{
+		GoatCreateValidatorTopic:      func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoCreateValidator(t, d) },
+		GoatLockTopic:                 func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoLock(t, d) },
+		GoatUnlockTopic:               func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoUnlock(t, d) },
+		GoatClaimTopic:                func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoClaim(t, d) },
+		GoatUpdateTokenWeightTopic:    func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoUpdateTokenWeight(t, d) },
+		GoatUpdateTokenThresholdTopic: func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoUpdateTokenThreshold(t, d) },
+		GoatGrantTopic:                func(t []common.Hash, d []byte) (RequestData, error) { return UnpackIntoGrant(t, d) },
+	}
+)
+
+// unpackIntoBridgeWithdrawStrict rejects the trailing data after the padded receiver address
+func unpackIntoBridgeWithdrawStrict(topics []common.Hash, data []byte) (RequestData, error) {
+	req, err := UnpackIntoBridgeWithdraw(topics, data)
+	if err != nil {
+		return nil, err
+	}
+	if size := 160 + (len(req.Address)+31)/32*32; len(data) != size {
+		return nil, fmt.Errorf("invalid Withdraw event data length: expect %d got %d", size, len(data))
+	}
+	return req, nil
+}
+
+func getGoatRequestsStrict(unpackers map[common.Hash]GoatEventDecoder, topics []common.Hash, data []byte) (Requests, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("%w: anonymous event", ErrUnknownGoatEvent)
+	}
+	unpack, ok := unpackers[topics[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w: topic %s", ErrUnknownGoatEvent, topics[0])
+	}
+	req, err := unpack(topics, data)
+	if err != nil {
+		return nil, err
+	}
+	return Requests{NewRequest(req)}, nil
+}
+
+// GetRelayerRequestsStrict is the strict version of GetRelayerRequests, the unknown
+// or malformed events are rejected instead of being skipped
+func GetRelayerRequestsStrict(topics []common.Hash, data []byte) (Requests, error) {
+	return getGoatRequestsStrict(relayerEventUnpackers, topics, data)
+}
+
+// GetBridgeRequestsStrict is the strict version of GetBridgeRequests, the unknown
+// or malformed events are rejected instead of being skipped
+func GetBridgeRequestsStrict(topics []common.Hash, data []byte) (Requests, error) {
+	return getGoatRequestsStrict(bridgeEventUnpackers, topics, data)
+}
+
+// GetLockingRequestsStrict is the strict version of GetLockingRequests, the unknown
+// or malformed events are rejected instead of being skipped
+func GetLockingRequestsStrict(topics []common.Hash, data []byte) (Requests, error) {
+	return getGoatRequestsStrict(lockingEventUnpackers, topics, data)
+}
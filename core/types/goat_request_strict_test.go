@@ -0,0 +1,54 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestGetGoatRequestsStrict(t *testing.T) {
+	topics := []common.Hash{
+		GoatWithdrawalTopic,
+		common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000001"),
+		common.HexToHash("0x0000000000000000000000005b38da6a701c568545dcfcb03fcb875f56beddc4"),
+	}
+	data := hexutil.MustDecode("0x0000000000000000000000000000000000000000000000000000002e90edd00000000000000000000000000000000000000000000000000000000000000003e8000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000002a626331716d76733230387765336a67376867637a686c683765397566773033346b666d3276777376676500000000000000000000000000000000000000000000")
+
+	reqs, err := GetBridgeRequestsStrict(topics, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 1 || reqs[0].Type() != GoatWithdrawalRequestType {
+		t.Fatalf("unexpected requests: %v", reqs)
+	}
+
+	// the lenient decoding accepts the trailing data
+	trailing := append(common.CopyBytes(data), make([]byte, 32)...)
+	if _, err := GetBridgeRequests(topics, trailing); err != nil {
+		t.Fatalf("lenient decoding failed: %v", err)
+	}
+	if _, err := GetBridgeRequestsStrict(topics, trailing); err == nil {
+		t.Error("expect error for trailing withdrawal data")
+	}
+
+	unknown := []common.Hash{{0x1}, {0x2}}
+	for name, get := range map[string]func([]common.Hash, []byte) (Requests, error){
+		"relayer": GetRelayerRequestsStrict,
+		"bridge":  GetBridgeRequestsStrict,
+		"locking": GetLockingRequestsStrict,
+	} {
+		if _, err := get(unknown, nil); !errors.Is(err, ErrUnknownGoatEvent) {
+			t.Errorf("%s: expect unknown event error, got %v", name, err)
+		}
+		if _, err := get(nil, nil); !errors.Is(err, ErrUnknownGoatEvent) {
+			t.Errorf("%s: expect unknown event error for anonymous event, got %v", name, err)
+		}
+	}
+
+	// the malformed event is not an unknown one
+	if _, err := GetLockingRequestsStrict([]common.Hash{GoatLockTopic}, nil); err == nil || errors.Is(err, ErrUnknownGoatEvent) {
+		t.Errorf("expect malformed event error, got %v", err)
+	}
+}
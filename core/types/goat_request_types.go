@@ -9,6 +9,13 @@ const (
 	GoatWithdrawalRequestType
 	GoatReplaceByFeeRequestType
 	GoatCancel1RequestType
+	GoatCreateValidatorRequestType
+	GoatLockRequestType
+	GoatUnlockRequestType
+	GoatClaimRequestType
+	GoatUpdateTokenWeightRequestType
+	GoatUpdateTokenThresholdRequestType
+	GoatGrantRequestType
 )
 
 var (
@@ -18,3 +25,21 @@ var (
 	GoatReplaceByFeeTopic = common.HexToHash("0x19875a7124af51c604454b74336ce2168c45bceade9d9a1e6dfae9ba7d31b7fa")
 	GoatCancel1Topic      = common.HexToHash("0x0106f4416537efff55311ef5e2f9c2a48204fcf84731f2b9d5091d23fc52160c")
 )
+
+// The topics of the bridge events which carry no request
+var (
+	GoatDepositTopic  = common.HexToHash("0xbc0e2d4f64f63e9c6b07a1665a26f689b20e42e836968119499db41c2d315efa") // Deposit(address,uint256,bytes32,uint32,uint256)
+	GoatPaidTopic     = common.HexToHash("0xb74f5dbf34aabe02f20ff775b898acf1a9f70e4fbd48ad50548acae86e1ccd78") // Paid(uint256,bytes32,uint32,uint256)
+	GoatCanceledTopic = common.HexToHash("0x829a8683c544ad289ce92d3ce06e9ebad69b18a6916e60ec766c2c217461d8e9") // Canceled(uint256)
+	GoatRefundTopic   = common.HexToHash("0x2e1897b0591d764356194f7a795238a87c1987c7a877568e50d829d547c92b97") // Refund(uint256)
+)
+
+var (
+	GoatCreateValidatorTopic      = common.HexToHash("0x4318a39458bb251eea2504a2ece46bc108bdda2f07a83675874a7f95c34e7390") // CreateValidator(address,bytes32[2])
+	GoatLockTopic                 = common.HexToHash("0xec36c0364d931187a76cf66d7eee08fad0ec2e8b7458a8d8b26b36769d4d13f3") // Lock(address,address,uint256)
+	GoatUnlockTopic               = common.HexToHash("0x40f2a8c5e2e2a9ad2f4e4dfc69825595b526178445c3eb22b02edfd190601db7") // Unlock(uint64,address,address,address,uint256)
+	GoatClaimTopic                = common.HexToHash("0xa983a6cfc4bd1095dac7b145ae020ba08e16cc7efa2051cc6b77e4011b9ee99b") // Claim(uint64,address,address)
+	GoatUpdateTokenWeightTopic    = common.HexToHash("0xb59bf4596e5415117fb4625044cb5b0ca5b273742825b026d06afe82a48e6217") // UpdateTokenWeight(address,uint64)
+	GoatUpdateTokenThresholdTopic = common.HexToHash("0x326e29ab1c62c7d77fdfb302916e82e1a54f3b9961db75ee7e18afe488a0e92d") // UpdateTokenThreshold(address,uint256)
+	GoatGrantTopic                = common.HexToHash("0x41891e803e84c188180caa0f073ce4235b8002dac887a69fcdcae1d295951fa0") // Grant(uint256)
+)
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+//go:generate go run github.com/fjl/gencodec -type GoatRequests -field-override goatRequestsMarshaling -out gen_goat_requests_json.go
+
+// GoatRequests groups the goat requests of a block by request type
+type GoatRequests struct {
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+
+	GasRevenues       GasRevenues       `json:"gasRevenueRequests"`
+	AddVoters         AddVoters         `json:"addVoterRequests"`
+	RemoveVoters      RemoveVoters      `json:"removeVoterRequests"`
+	BridgeWithdrawals BridgeWithdrawals `json:"bridgeWithdrawalsRequests"`
+	ReplaceByFees     ReplaceByFees     `json:"rbfRequests"`
+	Cancel1s          Cancel1s          `json:"cancel1Requests"`
+
+	CreateValidators      CreateValidators      `json:"createValidatorRequests"`
+	Locks                 Locks                 `json:"lockRequests"`
+	Unlocks               Unlocks               `json:"unlockRequests"`
+	Claims                Claims                `json:"claimRequests"`
+	UpdateTokenWeights    UpdateTokenWeights    `json:"updateTokenWeightRequests"`
+	UpdateTokenThresholds UpdateTokenThresholds `json:"updateTokenThresholdRequests"`
+	Grants                Grants                `json:"grantRequests"`
+}
+
+type goatRequestsMarshaling struct {
+	BlockNumber hexutil.Uint64
+}
+
+// NewGoatRequests groups the goat requests from the block
+func NewGoatRequests(block *Block) *GoatRequests {
+	res := &GoatRequests{
+		BlockHash:   block.Hash(),
+		BlockNumber: block.NumberU64(),
+
+		GasRevenues:       make(GasRevenues, 0),
+		AddVoters:         make(AddVoters, 0),
+		RemoveVoters:      make(RemoveVoters, 0),
+		BridgeWithdrawals: make(BridgeWithdrawals, 0),
+		ReplaceByFees:     make(ReplaceByFees, 0),
+		Cancel1s:          make(Cancel1s, 0),
+
+		CreateValidators:      make(CreateValidators, 0),
+		Locks:                 make(Locks, 0),
+		Unlocks:               make(Unlocks, 0),
+		Claims:                make(Claims, 0),
+		UpdateTokenWeights:    make(UpdateTokenWeights, 0),
+		UpdateTokenThresholds: make(UpdateTokenThresholds, 0),
+		Grants:                make(Grants, 0),
+	}
+	for _, r := range block.Requests() {
+		switch v := r.Inner().(type) {
+		case *GasRevenue:
+			res.GasRevenues = append(res.GasRevenues, v)
+		case *AddVoter:
+			res.AddVoters = append(res.AddVoters, v)
+		case *RemoveVoter:
+			res.RemoveVoters = append(res.RemoveVoters, v)
+		case *BridgeWithdrawal:
+			res.BridgeWithdrawals = append(res.BridgeWithdrawals, v)
+		case *ReplaceByFee:
+			res.ReplaceByFees = append(res.ReplaceByFees, v)
+		case *Cancel1:
+			res.Cancel1s = append(res.Cancel1s, v)
+		case *CreateValidator:
+			res.CreateValidators = append(res.CreateValidators, v)
+		case *Lock:
+			res.Locks = append(res.Locks, v)
+		case *Unlock:
+			res.Unlocks = append(res.Unlocks, v)
+		case *Claim:
+			res.Claims = append(res.Claims, v)
+		case *UpdateTokenWeight:
+			res.UpdateTokenWeights = append(res.UpdateTokenWeights, v)
+		case *UpdateTokenThreshold:
+			res.UpdateTokenThresholds = append(res.UpdateTokenThresholds, v)
+		case *Grant:
+			res.Grants = append(res.Grants, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestNewGoatRequests(t *testing.T) {
+	var (
+		revenue    = &GasRevenue{Amount: big.NewInt(100)}
+		addVoter   = &AddVoter{Voter: common.Address{0x1}, Pubkey: common.Hash{0x2}}
+		withdrawal = &BridgeWithdrawal{Id: 1, Amount: 20, MaxTxPrice: 10, Address: "bc1qmvs208we3jg7hgczhlh7e9ufw034kfm2vwsvge"}
+		cancel1    = &Cancel1{Id: 2}
+		lock       = &Lock{Validator: common.Address{0x3}, Token: common.Address{}, Amount: big.NewInt(1e18)}
+	)
+	requests := Requests{NewRequest(revenue), NewRequest(addVoter), NewRequest(withdrawal), NewRequest(cancel1), NewRequest(lock)}
+	header := &Header{Number: big.NewInt(10), RequestsHash: &EmptyRequestsHash}
+	block := NewBlockWithHeader(header).WithBody(Body{Requests: requests})
+
+	got := NewGoatRequests(block)
+	if got.BlockHash != block.Hash() || got.BlockNumber != 10 {
+		t.Fatalf("NewGoatRequests() block mismatched: %x %d", got.BlockHash, got.BlockNumber)
+	}
+	if !reflect.DeepEqual(got.GasRevenues, GasRevenues{revenue}) {
+		t.Errorf("NewGoatRequests() GasRevenues = %v", got.GasRevenues)
+	}
+	if !reflect.DeepEqual(got.AddVoters, AddVoters{addVoter}) {
+		t.Errorf("NewGoatRequests() AddVoters = %v", got.AddVoters)
+	}
+	if !reflect.DeepEqual(got.BridgeWithdrawals, BridgeWithdrawals{withdrawal}) {
+		t.Errorf("NewGoatRequests() BridgeWithdrawals = %v", got.BridgeWithdrawals)
+	}
+	if !reflect.DeepEqual(got.Cancel1s, Cancel1s{cancel1}) {
+		t.Errorf("NewGoatRequests() Cancel1s = %v", got.Cancel1s)
+	}
+	if !reflect.DeepEqual(got.Locks, Locks{lock}) {
+		t.Errorf("NewGoatRequests() Locks = %v", got.Locks)
+	}
+	if got.RemoveVoters == nil || len(got.RemoveVoters) != 0 {
+		t.Errorf("NewGoatRequests() RemoveVoters should be an empty list")
+	}
+
+	enc, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("NewGoatRequests(): MarshalJSON error = %v", err)
+	}
+	dec := new(GoatRequests)
+	if err := json.Unmarshal(enc, dec); err != nil {
+		t.Fatalf("NewGoatRequests(): UnmarshalJSON error = %v", err)
+	}
+	if !reflect.DeepEqual(dec, got) {
+		t.Errorf("NewGoatRequests(): json round trip = %v, want %v", dec, got)
+	}
+}
+
+func TestBridgeWithdrawalInvalidAddressEncoding(t *testing.T) {
+	withdrawal := &BridgeWithdrawal{Id: 1, Amount: 20, MaxTxPrice: 10, Address: "bc1q"}
+	legacy, err := rlp.EncodeToBytes([]interface{}{withdrawal.Id, withdrawal.Amount, withdrawal.MaxTxPrice, withdrawal.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the valid withdrawals are encoded as before the address check
+	if enc, _ := rlp.EncodeToBytes(withdrawal); !bytes.Equal(enc, legacy) {
+		t.Errorf("encoding mismatch: have %x want %x", enc, legacy)
+	}
+
+	withdrawal.InvalidAddress = true
+	enc, _ := rlp.EncodeToBytes(withdrawal)
+	dec := new(BridgeWithdrawal)
+	if err := rlp.DecodeBytes(enc, dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec, withdrawal) {
+		t.Errorf("decoded mismatch: have %v want %v", dec, withdrawal)
+	}
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+// The status of a bridge withdrawal
+const (
+	GoatWithdrawalPending   = "pending"
+	GoatWithdrawalRBF       = "rbf"
+	GoatWithdrawalCancel1   = "cancel1"
+	GoatWithdrawalCancelled = "cancelled"
+	GoatWithdrawalPaid      = "paid"
+)
+
+//go:generate go run github.com/fjl/gencodec -type GoatWithdrawalEvent -field-override goatWithdrawalEventMarshaling -out gen_goat_withdrawal_event_json.go
+
+// GoatWithdrawalEvent is a block and transaction that touched a bridge withdrawal
+// Only one of the event fields is set
+type GoatWithdrawalEvent struct {
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"transactionHash"`
+	TxIndex     uint64      `json:"transactionIndex"`
+
+	Withdrawal   *BridgeWithdrawal    `json:"withdrawal,omitempty" rlp:"nil"`
+	ReplaceByFee *ReplaceByFee        `json:"rbf,omitempty" rlp:"nil"`
+	Cancel1      *Cancel1             `json:"cancel1,omitempty" rlp:"nil"`
+	Cancel2      *goattypes.Cancel2Tx `json:"cancel2,omitempty" rlp:"nil"`
+	Paid         *goattypes.PaidTx    `json:"paid,omitempty" rlp:"nil"`
+}
+
+type goatWithdrawalEventMarshaling struct {
+	BlockNumber hexutil.Uint64
+	TxIndex     hexutil.Uint64
+}
+
+// Id returns the withdrawal id of the event
+func (ev *GoatWithdrawalEvent) Id() uint64 {
+	switch {
+	case ev.Withdrawal != nil:
+		return ev.Withdrawal.Id
+	case ev.ReplaceByFee != nil:
+		return ev.ReplaceByFee.Id
+	case ev.Cancel1 != nil:
+		return ev.Cancel1.Id
+	case ev.Cancel2 != nil:
+		return ev.Cancel2.Id.Uint64()
+	case ev.Paid != nil:
+		return ev.Paid.Id.Uint64()
+	}
+	return 0
+}
+
+// Kind returns the name of the event, it's the json field name of the event
+func (ev *GoatWithdrawalEvent) Kind() string {
+	switch {
+	case ev.Withdrawal != nil:
+		return "withdrawal"
+	case ev.ReplaceByFee != nil:
+		return "rbf"
+	case ev.Cancel1 != nil:
+		return "cancel1"
+	case ev.Cancel2 != nil:
+		return "cancel2"
+	case ev.Paid != nil:
+		return "paid"
+	}
+	return ""
+}
+
+//go:generate go run github.com/fjl/gencodec -type GoatWithdrawalLifecycle -field-override goatWithdrawalLifecycleMarshaling -out gen_goat_withdrawal_lifecycle_json.go
+
+// GoatWithdrawalLifecycle is the current status of a bridge withdrawal with its history
+type GoatWithdrawalLifecycle struct {
+	Id         uint64                 `json:"id"`
+	Status     string                 `json:"status"`
+	MaxTxPrice uint64                 `json:"max_tx_price"`
+	Withdrawal *BridgeWithdrawal      `json:"withdrawal"`
+	Paid       *goattypes.PaidTx      `json:"paid,omitempty"`
+	Events     []*GoatWithdrawalEvent `json:"events"`
+	Incomplete bool                   `json:"incomplete,omitempty"` // the history might miss the events in the blocks without history
+}
+
+type goatWithdrawalLifecycleMarshaling struct {
+	Id         hexutil.Uint64
+	MaxTxPrice hexutil.Uint64
+}
+
+// NewGoatWithdrawalLifecycle derives the withdrawal status from its events
+// The events should be in the chain order, it returns nil if there is no event
+func NewGoatWithdrawalLifecycle(id uint64, events []*GoatWithdrawalEvent) *GoatWithdrawalLifecycle {
+	if len(events) == 0 {
+		return nil
+	}
+	res := &GoatWithdrawalLifecycle{Id: id, Events: events}
+	for _, ev := range events {
+		switch {
+		case ev.Withdrawal != nil:
+			res.Status = GoatWithdrawalPending
+			res.Withdrawal = ev.Withdrawal
+			res.MaxTxPrice = ev.Withdrawal.MaxTxPrice
+		case ev.ReplaceByFee != nil:
+			res.Status = GoatWithdrawalRBF
+			res.MaxTxPrice = ev.ReplaceByFee.MaxTxPrice
+		case ev.Cancel1 != nil:
+			res.Status = GoatWithdrawalCancel1
+		case ev.Cancel2 != nil:
+			res.Status = GoatWithdrawalCancelled
+		case ev.Paid != nil:
+			res.Status = GoatWithdrawalPaid
+			res.Paid = ev.Paid
+		}
+	}
+	return res
+}
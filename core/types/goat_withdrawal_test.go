@@ -0,0 +1,87 @@
+package types
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestGoatWithdrawalEventRLP(t *testing.T) {
+	events := []*GoatWithdrawalEvent{
+		{
+			BlockHash:   common.Hash{0x1},
+			BlockNumber: 10,
+			TxHash:      common.Hash{0x2},
+			TxIndex:     1,
+			Withdrawal:  &BridgeWithdrawal{Id: 1, Amount: 20, MaxTxPrice: 10, Address: "bc1qmvs208we3jg7hgczhlh7e9ufw034kfm2vwsvge"},
+		},
+		{
+			BlockHash:   common.Hash{0x3},
+			BlockNumber: 11,
+			TxHash:      common.Hash{0x4},
+			Paid:        &goattypes.PaidTx{Id: big.NewInt(1), Txid: common.Hash{0x5}, TxOut: 1, Amount: big.NewInt(19)},
+		},
+	}
+	enc, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*GoatWithdrawalEvent
+	if err := rlp.DecodeBytes(enc, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Fatalf("GoatWithdrawalEvent RLP mismatched: got %v want %v", got, events)
+	}
+}
+
+func TestNewGoatWithdrawalLifecycle(t *testing.T) {
+	withdrawal := &BridgeWithdrawal{Id: 1, Amount: 20, MaxTxPrice: 10, Address: "bc1qmvs208we3jg7hgczhlh7e9ufw034kfm2vwsvge"}
+	paid := &goattypes.PaidTx{Id: big.NewInt(1), Txid: common.Hash{0x5}, TxOut: 1, Amount: big.NewInt(19)}
+
+	var (
+		initiated = &GoatWithdrawalEvent{BlockNumber: 1, Withdrawal: withdrawal}
+		rbf       = &GoatWithdrawalEvent{BlockNumber: 2, ReplaceByFee: &ReplaceByFee{Id: 1, MaxTxPrice: 20}}
+		cancel1   = &GoatWithdrawalEvent{BlockNumber: 3, Cancel1: &Cancel1{Id: 1}}
+		cancel2   = &GoatWithdrawalEvent{BlockNumber: 4, Cancel2: &goattypes.Cancel2Tx{Id: big.NewInt(1)}}
+		settled   = &GoatWithdrawalEvent{BlockNumber: 4, Paid: paid}
+	)
+
+	tests := []struct {
+		events     []*GoatWithdrawalEvent
+		status     string
+		maxTxPrice uint64
+		paid       *goattypes.PaidTx
+	}{
+		{[]*GoatWithdrawalEvent{initiated}, GoatWithdrawalPending, 10, nil},
+		{[]*GoatWithdrawalEvent{initiated, rbf}, GoatWithdrawalRBF, 20, nil},
+		{[]*GoatWithdrawalEvent{initiated, rbf, cancel1}, GoatWithdrawalCancel1, 20, nil},
+		{[]*GoatWithdrawalEvent{initiated, cancel1, cancel2}, GoatWithdrawalCancelled, 10, nil},
+		{[]*GoatWithdrawalEvent{initiated, rbf, settled}, GoatWithdrawalPaid, 20, paid},
+	}
+	for i, test := range tests {
+		got := NewGoatWithdrawalLifecycle(1, test.events)
+		if got.Status != test.status {
+			t.Errorf("test %d: status mismatched: got %s want %s", i, got.Status, test.status)
+		}
+		if got.MaxTxPrice != test.maxTxPrice {
+			t.Errorf("test %d: max tx price mismatched: got %d want %d", i, got.MaxTxPrice, test.maxTxPrice)
+		}
+		if got.Withdrawal != withdrawal {
+			t.Errorf("test %d: withdrawal mismatched", i)
+		}
+		if got.Paid != test.paid {
+			t.Errorf("test %d: paid mismatched", i)
+		}
+		if len(got.Events) != len(test.events) {
+			t.Errorf("test %d: events length mismatched: got %d want %d", i, len(got.Events), len(test.events))
+		}
+	}
+	if NewGoatWithdrawalLifecycle(1, nil) != nil {
+		t.Error("expect nil lifecycle without events")
+	}
+}
@@ -0,0 +1,270 @@
+// Package btcaddr implements the bitcoin address validation for the bridge withdrawals.
+//
+// The supported addresses are P2PKH and P2SH in base58check, P2WPKH and P2WSH in bech32
+// and P2TR in bech32m.
+package btcaddr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Type is the bitcoin address type
+type Type uint8
+
+const (
+	P2PKH Type = iota + 1
+	P2SH
+	P2WPKH
+	P2WSH
+	P2TR
+)
+
+func (t Type) String() string {
+	switch t {
+	case P2PKH:
+		return "p2pkh"
+	case P2SH:
+		return "p2sh"
+	case P2WPKH:
+		return "p2wpkh"
+	case P2WSH:
+		return "p2wsh"
+	case P2TR:
+		return "p2tr"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// Network is the address parameters of a bitcoin network
+type Network struct {
+	Bech32HRP        string
+	PubKeyHashAddrID byte
+	ScriptHashAddrID byte
+}
+
+// Networks is the address parameters of the supported bitcoin networks
+var Networks = map[string]*Network{
+	"mainnet":  {Bech32HRP: "bc", PubKeyHashAddrID: 0x00, ScriptHashAddrID: 0x05},
+	"testnet3": {Bech32HRP: "tb", PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4},
+	"signet":   {Bech32HRP: "tb", PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4},
+	"regtest":  {Bech32HRP: "bcrt", PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4},
+}
+
+var (
+	ErrUnknownNetwork = errors.New("unknown bitcoin network")
+	ErrInvalidAddress = errors.New("invalid bitcoin address")
+)
+
+// Address is a decoded bitcoin address
+type Address struct {
+	Type    Type
+	Program []byte // the hash or the witness program
+}
+
+// Decode decodes the bitcoin address of the given network
+func Decode(addr string, network string) (*Address, error) {
+	params, ok := Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+	if strings.HasPrefix(strings.ToLower(addr), params.Bech32HRP+"1") {
+		return decodeSegwit(addr, params)
+	}
+	return decodeBase58(addr, params)
+}
+
+// Validate returns an error if the bitcoin address is not valid on the given network
+func Validate(addr string, network string) error {
+	_, err := Decode(addr, network)
+	return err
+}
+
+func decodeBase58(addr string, params *Network) (*Address, error) {
+	raw, err := base58Decode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != 25 {
+		return nil, fmt.Errorf("%w: invalid base58 payload length %d", ErrInvalidAddress, len(raw))
+	}
+	first := sha256.Sum256(raw[:21])
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], raw[21:]) {
+		return nil, fmt.Errorf("%w: invalid base58 checksum", ErrInvalidAddress)
+	}
+	switch raw[0] {
+	case params.PubKeyHashAddrID:
+		return &Address{Type: P2PKH, Program: raw[1:21]}, nil
+	case params.ScriptHashAddrID:
+		return &Address{Type: P2SH, Program: raw[1:21]}, nil
+	}
+	return nil, fmt.Errorf("%w: unexpected base58 version %#x", ErrInvalidAddress, raw[0])
+}
+
+func decodeSegwit(addr string, params *Network) (*Address, error) {
+	hrp, data, variant, err := bech32Decode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if hrp != params.Bech32HRP {
+		return nil, fmt.Errorf("%w: unexpected hrp %q", ErrInvalidAddress, hrp)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty witness program", ErrInvalidAddress)
+	}
+	version := data[0]
+	program, err := convertBits(data[1:])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case version == 0 && variant == bech32:
+		switch len(program) {
+		case 20:
+			return &Address{Type: P2WPKH, Program: program}, nil
+		case 32:
+			return &Address{Type: P2WSH, Program: program}, nil
+		}
+		return nil, fmt.Errorf("%w: invalid witness v0 program length %d", ErrInvalidAddress, len(program))
+	case version == 1 && variant == bech32m:
+		if len(program) != 32 {
+			return nil, fmt.Errorf("%w: invalid taproot program length %d", ErrInvalidAddress, len(program))
+		}
+		return &Address{Type: P2TR, Program: program}, nil
+	case version == 0 || version == 1:
+		return nil, fmt.Errorf("%w: invalid checksum variant for witness v%d", ErrInvalidAddress, version)
+	}
+	return nil, fmt.Errorf("%w: unsupported witness version %d", ErrInvalidAddress, version)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Decode(s string) ([]byte, error) {
+	if len(s) == 0 || len(s) > 64 {
+		return nil, fmt.Errorf("%w: invalid base58 length %d", ErrInvalidAddress, len(s))
+	}
+	var (
+		n     = new(big.Int)
+		radix = big.NewInt(58)
+	)
+	for _, c := range []byte(s) {
+		idx := strings.IndexByte(base58Alphabet, c)
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: invalid base58 character %q", ErrInvalidAddress, c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+type bech32Variant uint8
+
+const (
+	bech32 bech32Variant = iota + 1
+	bech32m
+)
+
+const (
+	bech32Charset   = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32Const     = 1
+	bech32mConst    = 0x2bc830a3
+	bech32MaxLength = 90
+)
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	res := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]>>5)
+	}
+	res = append(res, 0)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]&31)
+	}
+	return res
+}
+
+// bech32Decode decodes the bech32 or bech32m string, it returns the data without the checksum
+func bech32Decode(s string) (string, []byte, bech32Variant, error) {
+	if len(s) > bech32MaxLength {
+		return "", nil, 0, fmt.Errorf("%w: bech32 string is too long", ErrInvalidAddress)
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, fmt.Errorf("%w: mixed case bech32 string", ErrInvalidAddress)
+	}
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, fmt.Errorf("%w: invalid bech32 separator position", ErrInvalidAddress)
+	}
+	hrp := s[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("%w: invalid bech32 hrp character", ErrInvalidAddress)
+		}
+	}
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		idx := strings.IndexByte(bech32Charset, s[i])
+		if idx < 0 {
+			return "", nil, 0, fmt.Errorf("%w: invalid bech32 character %q", ErrInvalidAddress, s[i])
+		}
+		data = append(data, byte(idx))
+	}
+
+	var variant bech32Variant
+	switch bech32Polymod(append(bech32HrpExpand(hrp), data...)) {
+	case bech32Const:
+		variant = bech32
+	case bech32mConst:
+		variant = bech32m
+	default:
+		return "", nil, 0, fmt.Errorf("%w: invalid bech32 checksum", ErrInvalidAddress)
+	}
+	return hrp, data[:len(data)-6], variant, nil
+}
+
+// convertBits regroups the 5-bit bech32 data into bytes
+func convertBits(data []byte) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		res  = make([]byte, 0, len(data)*5/8)
+	)
+	for _, v := range data {
+		acc = acc<<5 | uint32(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			res = append(res, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 || acc&(1<<bits-1) != 0 {
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidAddress)
+	}
+	return res, nil
+}
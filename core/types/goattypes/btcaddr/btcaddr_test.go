@@ -0,0 +1,83 @@
+package btcaddr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		typ     Type
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "mainnet", P2PKH},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "mainnet", P2SH},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "mainnet", P2WPKH},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "mainnet", P2WPKH},
+		{"bc1qmvs208we3jg7hgczhlh7e9ufw034kfm2vwsvge", "mainnet", P2WPKH},
+		{"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3", "mainnet", P2WSH},
+		{"bc1qen5kv3c0epd9yfqvu2q059qsjpwu9hdjywx2v9p5p9l8msxn88fs9y5kx6", "mainnet", P2WSH},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "mainnet", P2TR},
+		{"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", "testnet3", P2WPKH},
+		{"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", "signet", P2WPKH},
+		{"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn", "testnet3", P2PKH},
+		{"2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc", "testnet3", P2SH},
+	}
+	for _, tt := range tests {
+		addr, err := Decode(tt.addr, tt.network)
+		if err != nil {
+			t.Errorf("Decode(%s, %s): %v", tt.addr, tt.network, err)
+			continue
+		}
+		if addr.Type != tt.typ {
+			t.Errorf("Decode(%s, %s) type = %s, want %s", tt.addr, tt.network, addr.Type, tt.typ)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+	}{
+		{"", "mainnet"},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", "mainnet"},                             // bad checksum
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "testnet3"},                            // wrong network
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN0", "mainnet"},                             // invalid character
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", "mainnet"},                     // bad checksum
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "testnet3"},                    // wrong hrp
+		{"bc1QW508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "mainnet"},                     // mixed case
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", "mainnet"},                     // v0 with bech32m
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", "mainnet"}, // v1 with bech32
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", "mainnet"},                           // unsupported version
+		{"bc1rw5uspcuh", "mainnet"},                                                   // short program
+		{"bc1qr508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "mainnet"},                     // invalid program length
+		{"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3pjxtptv", "mainnet"}, // non-zero padding
+	}
+	for _, tt := range tests {
+		if _, err := Decode(tt.addr, tt.network); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("Decode(%s, %s): expect invalid address, got %v", tt.addr, tt.network, err)
+		}
+	}
+	if err := Validate("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "unknown"); !errors.Is(err, ErrUnknownNetwork) {
+		t.Errorf("expect unknown network error, got %v", err)
+	}
+}
+
+// TestNetworks checks the address parameters are defined for every bitcoin network
+// which the goat config accepts.
+func TestNetworks(t *testing.T) {
+	for name := range params.BitcoinNetworks {
+		if Networks[name] == nil {
+			t.Errorf("missing address parameters of the bitcoin network %q", name)
+		}
+	}
+	for name := range Networks {
+		if params.BitcoinNetworks[name] == nil {
+			t.Errorf("unknown bitcoin network %q", name)
+		}
+	}
+}
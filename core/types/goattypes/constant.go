@@ -1,18 +1,18 @@
 package goattypes
 
 import (
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var (
-	RelayerExecutor = common.HexToAddress("0xBc10000000000000000000000000000000001000")
-	LockingExecutor = common.HexToAddress("0xBC10000000000000000000000000000000001001")
+	RelayerExecutor = params.GoatRelayerExecutor
+	LockingExecutor = params.GoatLockingExecutor
 )
 
 var (
-	GoatFoundationContract = common.HexToAddress("0xBc10000000000000000000000000000000000002")
-	BridgeContract         = common.HexToAddress("0xBC10000000000000000000000000000000000003")
-	LockingContract        = common.HexToAddress("0xbC10000000000000000000000000000000000004")
-	BitcoinContract        = common.HexToAddress("0xbc10000000000000000000000000000000000005")
-	RelayerContract        = common.HexToAddress("0xBC10000000000000000000000000000000000006")
+	GoatFoundationContract = params.GoatFoundationContract
+	BridgeContract         = params.GoatBridgeContract
+	LockingContract        = params.GoatLockingContract
+	BitcoinContract        = params.GoatBitcoinContract
+	RelayerContract        = params.GoatRelayerContract
 )
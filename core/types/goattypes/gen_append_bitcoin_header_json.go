@@ -0,0 +1,36 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*appendBitcoinHeaderMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (a AppendBitcoinHeader) MarshalJSON() ([]byte, error) {
+	type AppendBitcoinHeader struct {
+		Header hexutil.Bytes `json:"header"`
+	}
+	var enc AppendBitcoinHeader
+	enc.Header = a.Header
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (a *AppendBitcoinHeader) UnmarshalJSON(input []byte) error {
+	type AppendBitcoinHeader struct {
+		Header *hexutil.Bytes `json:"header"`
+	}
+	var dec AppendBitcoinHeader
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Header != nil {
+		a.Header = *dec.Header
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*cancel2TxMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (c Cancel2Tx) MarshalJSON() ([]byte, error) {
+	type Cancel2Tx struct {
+		Id *hexutil.Big `json:"id"`
+	}
+	var enc Cancel2Tx
+	enc.Id = (*hexutil.Big)(c.Id)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (c *Cancel2Tx) UnmarshalJSON(input []byte) error {
+	type Cancel2Tx struct {
+		Id *hexutil.Big `json:"id"`
+	}
+	var dec Cancel2Tx
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		c.Id = (*big.Int)(dec.Id)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*completeUnlockTxMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (c CompleteUnlockTx) MarshalJSON() ([]byte, error) {
+	type CompleteUnlockTx struct {
+		Id        hexutil.Uint64 `json:"id"`
+		Recipient common.Address `json:"recipient"`
+		Token     common.Address `json:"token"`
+		Amount    *hexutil.Big   `json:"amount"`
+	}
+	var enc CompleteUnlockTx
+	enc.Id = hexutil.Uint64(c.Id)
+	enc.Recipient = c.Recipient
+	enc.Token = c.Token
+	enc.Amount = (*hexutil.Big)(c.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (c *CompleteUnlockTx) UnmarshalJSON(input []byte) error {
+	type CompleteUnlockTx struct {
+		Id        *hexutil.Uint64 `json:"id"`
+		Recipient *common.Address `json:"recipient"`
+		Token     *common.Address `json:"token"`
+		Amount    *hexutil.Big    `json:"amount"`
+	}
+	var dec CompleteUnlockTx
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		c.Id = uint64(*dec.Id)
+	}
+	if dec.Recipient != nil {
+		c.Recipient = *dec.Recipient
+	}
+	if dec.Token != nil {
+		c.Token = *dec.Token
+	}
+	if dec.Amount != nil {
+		c.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
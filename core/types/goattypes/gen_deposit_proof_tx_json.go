@@ -0,0 +1,80 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*depositProofTxMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (d DepositProofTx) MarshalJSON() ([]byte, error) {
+	type DepositProofTx struct {
+		Txid    common.Hash    `json:"txid"`
+		TxOut   hexutil.Uint64 `json:"txout"`
+		Target  common.Address `json:"target"`
+		Amount  *hexutil.Big   `json:"amount"`
+		Height  hexutil.Uint64 `json:"height"`
+		TxIndex hexutil.Uint64 `json:"txIndex"`
+		Branch  []common.Hash  `json:"branch"`
+		RawTx   hexutil.Bytes  `json:"rawTx"`
+	}
+	var enc DepositProofTx
+	enc.Txid = d.Txid
+	enc.TxOut = hexutil.Uint64(d.TxOut)
+	enc.Target = d.Target
+	enc.Amount = (*hexutil.Big)(d.Amount)
+	enc.Height = hexutil.Uint64(d.Height)
+	enc.TxIndex = hexutil.Uint64(d.TxIndex)
+	enc.Branch = d.Branch
+	enc.RawTx = d.RawTx
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (d *DepositProofTx) UnmarshalJSON(input []byte) error {
+	type DepositProofTx struct {
+		Txid    *common.Hash    `json:"txid"`
+		TxOut   *hexutil.Uint64 `json:"txout"`
+		Target  *common.Address `json:"target"`
+		Amount  *hexutil.Big    `json:"amount"`
+		Height  *hexutil.Uint64 `json:"height"`
+		TxIndex *hexutil.Uint64 `json:"txIndex"`
+		Branch  []common.Hash   `json:"branch"`
+		RawTx   *hexutil.Bytes  `json:"rawTx"`
+	}
+	var dec DepositProofTx
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Txid != nil {
+		d.Txid = *dec.Txid
+	}
+	if dec.TxOut != nil {
+		d.TxOut = uint32(*dec.TxOut)
+	}
+	if dec.Target != nil {
+		d.Target = *dec.Target
+	}
+	if dec.Amount != nil {
+		d.Amount = (*big.Int)(dec.Amount)
+	}
+	if dec.Height != nil {
+		d.Height = uint64(*dec.Height)
+	}
+	if dec.TxIndex != nil {
+		d.TxIndex = uint32(*dec.TxIndex)
+	}
+	if dec.Branch != nil {
+		d.Branch = dec.Branch
+	}
+	if dec.RawTx != nil {
+		d.RawTx = *dec.RawTx
+	}
+	return nil
+}
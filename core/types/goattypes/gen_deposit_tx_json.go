@@ -0,0 +1,56 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*depositTxMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (d DepositTx) MarshalJSON() ([]byte, error) {
+	type DepositTx struct {
+		Txid   common.Hash    `json:"txid"`
+		TxOut  hexutil.Uint64 `json:"txout"`
+		Target common.Address `json:"target"`
+		Amount *hexutil.Big   `json:"amount"`
+	}
+	var enc DepositTx
+	enc.Txid = d.Txid
+	enc.TxOut = hexutil.Uint64(d.TxOut)
+	enc.Target = d.Target
+	enc.Amount = (*hexutil.Big)(d.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (d *DepositTx) UnmarshalJSON(input []byte) error {
+	type DepositTx struct {
+		Txid   *common.Hash    `json:"txid"`
+		TxOut  *hexutil.Uint64 `json:"txout"`
+		Target *common.Address `json:"target"`
+		Amount *hexutil.Big    `json:"amount"`
+	}
+	var dec DepositTx
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Txid != nil {
+		d.Txid = *dec.Txid
+	}
+	if dec.TxOut != nil {
+		d.TxOut = uint32(*dec.TxOut)
+	}
+	if dec.Target != nil {
+		d.Target = *dec.Target
+	}
+	if dec.Amount != nil {
+		d.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*distributeRewardTxMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (d DistributeRewardTx) MarshalJSON() ([]byte, error) {
+	type DistributeRewardTx struct {
+		Id        hexutil.Uint64 `json:"id"`
+		Recipient common.Address `json:"recipient"`
+		Goat      *hexutil.Big   `json:"goat"`
+		GasReward *hexutil.Big   `json:"gasReward"`
+	}
+	var enc DistributeRewardTx
+	enc.Id = hexutil.Uint64(d.Id)
+	enc.Recipient = d.Recipient
+	enc.Goat = (*hexutil.Big)(d.Goat)
+	enc.GasReward = (*hexutil.Big)(d.GasReward)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (d *DistributeRewardTx) UnmarshalJSON(input []byte) error {
+	type DistributeRewardTx struct {
+		Id        *hexutil.Uint64 `json:"id"`
+		Recipient *common.Address `json:"recipient"`
+		Goat      *hexutil.Big    `json:"goat"`
+		GasReward *hexutil.Big    `json:"gasReward"`
+	}
+	var dec DistributeRewardTx
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		d.Id = uint64(*dec.Id)
+	}
+	if dec.Recipient != nil {
+		d.Recipient = *dec.Recipient
+	}
+	if dec.Goat != nil {
+		d.Goat = (*big.Int)(dec.Goat)
+	}
+	if dec.GasReward != nil {
+		d.GasReward = (*big.Int)(dec.GasReward)
+	}
+	return nil
+}
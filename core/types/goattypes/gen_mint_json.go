@@ -0,0 +1,44 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*mintMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (m Mint) MarshalJSON() ([]byte, error) {
+	type Mint struct {
+		Address common.Address `json:"address"`
+		Amount  *hexutil.Big   `json:"amount"`
+	}
+	var enc Mint
+	enc.Address = m.Address
+	enc.Amount = (*hexutil.Big)(m.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (m *Mint) UnmarshalJSON(input []byte) error {
+	type Mint struct {
+		Address *common.Address `json:"address"`
+		Amount  *hexutil.Big    `json:"amount"`
+	}
+	var dec Mint
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Address != nil {
+		m.Address = *dec.Address
+	}
+	if dec.Amount != nil {
+		m.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*paidTxMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (p PaidTx) MarshalJSON() ([]byte, error) {
+	type PaidTx struct {
+		Id     *hexutil.Big   `json:"id"`
+		Txid   common.Hash    `json:"txid"`
+		TxOut  hexutil.Uint64 `json:"txout"`
+		Amount *hexutil.Big   `json:"amount"`
+	}
+	var enc PaidTx
+	enc.Id = (*hexutil.Big)(p.Id)
+	enc.Txid = p.Txid
+	enc.TxOut = hexutil.Uint64(p.TxOut)
+	enc.Amount = (*hexutil.Big)(p.Amount)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (p *PaidTx) UnmarshalJSON(input []byte) error {
+	type PaidTx struct {
+		Id     *hexutil.Big    `json:"id"`
+		Txid   *common.Hash    `json:"txid"`
+		TxOut  *hexutil.Uint64 `json:"txout"`
+		Amount *hexutil.Big    `json:"amount"`
+	}
+	var dec PaidTx
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.Id != nil {
+		p.Id = (*big.Int)(dec.Id)
+	}
+	if dec.Txid != nil {
+		p.Txid = *dec.Txid
+	}
+	if dec.TxOut != nil {
+		p.TxOut = uint32(*dec.TxOut)
+	}
+	if dec.Amount != nil {
+		p.Amount = (*big.Int)(dec.Amount)
+	}
+	return nil
+}
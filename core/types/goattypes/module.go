@@ -0,0 +1,80 @@
+package goattypes
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ActionInfo declares a goat tx action of a module
+type ActionInfo struct {
+	Name string    // the name in the apis and tracers, e.g. "deposit"
+	New  func() Tx // creates an empty inner tx to decode into
+}
+
+// ModuleInfo declares a goat module and its goat tx actions
+type ModuleInfo struct {
+	Module  Module
+	Name    string // the name in the apis and tracers, e.g. "bridge"
+	Actions map[Action]*ActionInfo
+}
+
+// TxBase can be embedded by the goat txs which are defined outside of this package
+type TxBase struct{}
+
+func (TxBase) isGoatTx() {}
+
+var (
+	modulesLock sync.RWMutex
+	modules     = make(map[Module]*ModuleInfo)
+)
+
+// RegisterModule registers the goat tx actions of a module, it should be called in
+// the init function of the package which defines the module. It panics if the module
+// is invalid or it's already registered.
+func RegisterModule(info *ModuleInfo) {
+	if info.Module == 0 || info.Name == "" {
+		panic(fmt.Sprintf("invalid goat module %d %q", info.Module, info.Name))
+	}
+	for action, v := range info.Actions {
+		if v == nil || v.Name == "" || v.New == nil {
+			panic(fmt.Sprintf("invalid action %d of goat module %s", action, info.Name))
+		}
+	}
+	modulesLock.Lock()
+	defer modulesLock.Unlock()
+	if _, ok := modules[info.Module]; ok {
+		panic(fmt.Sprintf("duplicate goat module %d", info.Module))
+	}
+	modules[info.Module] = info
+}
+
+// LookupModule returns the registered goat module, or nil if it's unknown
+func LookupModule(module Module) *ModuleInfo {
+	modulesLock.RLock()
+	defer modulesLock.RUnlock()
+	return modules[module]
+}
+
+// LookupAction returns the registered goat tx action, or nil if it's unknown
+func LookupAction(module Module, action Action) *ActionInfo {
+	if info := LookupModule(module); info != nil {
+		return info.Actions[action]
+	}
+	return nil
+}
+
+// ModuleName returns the name of the goat module, or an empty string if it's unknown
+func ModuleName(module Module) string {
+	if info := LookupModule(module); info != nil {
+		return info.Name
+	}
+	return ""
+}
+
+// ActionName returns the name of the goat tx action, or an empty string if it's unknown
+func ActionName(module Module, action Action) string {
+	if info := LookupAction(module, action); info != nil {
+		return info.Name
+	}
+	return ""
+}
@@ -0,0 +1,287 @@
+package goattypes
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BitcoinHeaderLength is the length of the serialized bitcoin block header
+const BitcoinHeaderLength = 80
+
+// BitcoinHeader is the bitcoin block header
+//
+// The hashes are in the internal byte order, which is the reverse of the displayed hex
+type BitcoinHeader struct {
+	Version    int32
+	PrevBlock  common.Hash
+	MerkleRoot common.Hash
+	Timestamp  uint32
+	Bits       uint32
+	Nonce      uint32
+}
+
+// DecodeBitcoinHeader decodes the serialized bitcoin block header
+func DecodeBitcoinHeader(raw []byte) (*BitcoinHeader, error) {
+	if len(raw) != BitcoinHeaderLength {
+		return nil, errors.New("invalid bitcoin header length")
+	}
+	return &BitcoinHeader{
+		Version:    int32(binary.LittleEndian.Uint32(raw[0:4])),
+		PrevBlock:  common.BytesToHash(raw[4:36]),
+		MerkleRoot: common.BytesToHash(raw[36:68]),
+		Timestamp:  binary.LittleEndian.Uint32(raw[68:72]),
+		Bits:       binary.LittleEndian.Uint32(raw[72:76]),
+		Nonce:      binary.LittleEndian.Uint32(raw[76:80]),
+	}, nil
+}
+
+// Encode serializes the bitcoin block header
+func (h *BitcoinHeader) Encode() []byte {
+	b := make([]byte, 0, BitcoinHeaderLength)
+	b = binary.LittleEndian.AppendUint32(b, uint32(h.Version))
+	b = append(b, h.PrevBlock[:]...)
+	b = append(b, h.MerkleRoot[:]...)
+	b = binary.LittleEndian.AppendUint32(b, h.Timestamp)
+	b = binary.LittleEndian.AppendUint32(b, h.Bits)
+	return binary.LittleEndian.AppendUint32(b, h.Nonce)
+}
+
+// Hash returns the double sha256 hash of the header in the internal byte order
+func (h *BitcoinHeader) Hash() common.Hash {
+	return DoubleSha256(h.Encode())
+}
+
+// DoubleSha256 returns sha256(sha256(data))
+func DoubleSha256(data []byte) common.Hash {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
+
+// CompactToBig converts the compact representation of the target to a big integer
+func CompactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	negative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var n *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		n = new(big.Int).SetUint64(uint64(mantissa))
+	} else {
+		n = new(big.Int).SetUint64(uint64(mantissa))
+		n.Lsh(n, 8*(exponent-3))
+	}
+	if negative {
+		n.Neg(n)
+	}
+	return n
+}
+
+// BigToCompact converts the target to its compact representation
+func BigToCompact(n *big.Int) uint32 {
+	if n.Sign() == 0 {
+		return 0
+	}
+
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		tn := new(big.Int).Abs(n)
+		mantissa = uint32(tn.Rsh(tn, 8*(exponent-3)).Bits()[0])
+	}
+
+	// the sign bit is set, shift the mantissa and increase the exponent
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+	return compact
+}
+
+// HashToBig converts the block hash in the internal byte order to a big integer for the pow check
+func HashToBig(hash common.Hash) *big.Int {
+	var rev [32]byte
+	for i := range hash {
+		rev[31-i] = hash[i]
+	}
+	return new(big.Int).SetBytes(rev[:])
+}
+
+//go:generate go run github.com/fjl/gencodec -type AppendBitcoinHeader -field-override appendBitcoinHeaderMarshaling -out gen_append_bitcoin_header_json.go
+
+// AppendBitcoinHeader appends a new block to the bitcoin header chain tracked by the execution layer
+//
+// The input is compatible with newBlockHash(bytes32), the raw header is appended after the hash,
+// it's ignored by the contract and verified by the execution layer before the tx is applied.
+type AppendBitcoinHeader struct {
+	Header []byte `json:"header"`
+}
+
+type appendBitcoinHeaderMarshaling struct {
+	Header hexutil.Bytes
+}
+
+func (tx *AppendBitcoinHeader) Size() int {
+	return 36 + BitcoinHeaderLength
+}
+
+func (tx *AppendBitcoinHeader) isGoatTx() {}
+
+func (tx *AppendBitcoinHeader) Copy() Tx {
+	return &AppendBitcoinHeader{Header: common.CopyBytes(tx.Header)}
+}
+
+// Hash returns the block hash of the header in the internal byte order
+func (tx *AppendBitcoinHeader) Hash() common.Hash {
+	return DoubleSha256(tx.Header)
+}
+
+func (tx *AppendBitcoinHeader) Encode() []byte {
+	b := make([]byte, 0, tx.Size())
+
+	method := tx.MethodId()
+	b = append(b, method[:]...)
+	hash := tx.Hash()
+	b = append(b, hash[:]...)
+	return append(b, tx.Header...)
+}
+
+func (tx *AppendBitcoinHeader) Decode(input []byte) error {
+	if len(input) != tx.Size() {
+		return errors.New("Invalid input data for bitcoin header tx")
+	}
+	if [4]byte(input[:4]) != tx.MethodId() {
+		return errors.New("not a bitcoin header tx")
+	}
+	header := common.CopyBytes(input[36:])
+	if DoubleSha256(header) != common.BytesToHash(input[4:36]) {
+		return errors.New("bitcoin header hash mismatched")
+	}
+	tx.Header = header
+	return nil
+}
+
+func (tx *AppendBitcoinHeader) Sender() common.Address {
+	return RelayerExecutor
+}
+
+func (tx *AppendBitcoinHeader) Contract() common.Address {
+	return BitcoinContract
+}
+
+func (tx *AppendBitcoinHeader) Deposit() *Mint {
+	return nil
+}
+
+func (tx *AppendBitcoinHeader) Reward() *Mint {
+	return nil
+}
+
+func (tx *AppendBitcoinHeader) MethodId() [4]byte {
+	// newBlockHash(bytes32 _hash)
+	return [4]byte{0x94, 0xf4, 0x90, 0xbd}
+}
+
+// readVarInt reads the bitcoin compact size integer
+func readVarInt(b []byte) (uint64, []byte, error) {
+	if len(b) == 0 {
+		return 0, nil, errors.New("unexpected end of bitcoin tx")
+	}
+	switch prefix := b[0]; prefix {
+	case 0xfd:
+		if len(b) < 3 {
+			return 0, nil, errors.New("unexpected end of bitcoin tx")
+		}
+		return uint64(binary.LittleEndian.Uint16(b[1:3])), b[3:], nil
+	case 0xfe:
+		if len(b) < 5 {
+			return 0, nil, errors.New("unexpected end of bitcoin tx")
+		}
+		return uint64(binary.LittleEndian.Uint32(b[1:5])), b[5:], nil
+	case 0xff:
+		if len(b) < 9 {
+			return 0, nil, errors.New("unexpected end of bitcoin tx")
+		}
+		return binary.LittleEndian.Uint64(b[1:9]), b[9:], nil
+	default:
+		return uint64(prefix), b[1:], nil
+	}
+}
+
+// skipBytes skips the given number of bytes
+func skipBytes(b []byte, n uint64) ([]byte, error) {
+	if uint64(len(b)) < n {
+		return nil, errors.New("unexpected end of bitcoin tx")
+	}
+	return b[n:], nil
+}
+
+// BitcoinTxOutputValues parses the bitcoin tx without the witness data and returns the
+// values of the outputs in satoshi
+func BitcoinTxOutputValues(raw []byte) ([]uint64, error) {
+	b, err := skipBytes(raw, 4) // version
+	if err != nil {
+		return nil, err
+	}
+	inputs, b, err := readVarInt(b)
+	if err != nil {
+		return nil, err
+	}
+	if inputs == 0 {
+		return nil, errors.New("bitcoin tx with the witness data or without inputs")
+	}
+	for i := uint64(0); i < inputs; i++ {
+		if b, err = skipBytes(b, 36); err != nil { // outpoint
+			return nil, err
+		}
+		var size uint64
+		if size, b, err = readVarInt(b); err != nil {
+			return nil, err
+		}
+		if b, err = skipBytes(b, size); err != nil { // script
+			return nil, err
+		}
+		if b, err = skipBytes(b, 4); err != nil { // sequence
+			return nil, err
+		}
+	}
+
+	outputs, b, err := readVarInt(b)
+	if err != nil {
+		return nil, err
+	}
+	if outputs > uint64(len(b))/9 {
+		return nil, errors.New("too many bitcoin tx outputs")
+	}
+	values := make([]uint64, 0, outputs)
+	for i := uint64(0); i < outputs; i++ {
+		if len(b) < 8 {
+			return nil, errors.New("unexpected end of bitcoin tx")
+		}
+		values = append(values, binary.LittleEndian.Uint64(b[:8]))
+		var size uint64
+		if size, b, err = readVarInt(b[8:]); err != nil {
+			return nil, err
+		}
+		if b, err = skipBytes(b, size); err != nil {
+			return nil, err
+		}
+	}
+	if len(b) != 4 { // lock time
+		return nil, errors.New("invalid bitcoin tx length")
+	}
+	return values, nil
+}
@@ -0,0 +1,174 @@
+package goattypes
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestAppendBitcoinHeader(t *testing.T) {
+	// the bitcoin mainnet header at height 1
+	raw := hexutil.MustDecode("0x010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299")
+
+	header, err := DecodeBitcoinHeader(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 1 || header.Timestamp != 1231469665 || header.Bits != 0x1d00ffff || header.Nonce != 2573394689 {
+		t.Errorf("DecodeBitcoinHeader() = %+v", header)
+	}
+	if got := header.Encode(); !reflect.DeepEqual(got, raw) {
+		t.Errorf("BitcoinHeader.Encode() = %x, want %x", got, raw)
+	}
+	want := common.HexToHash("0x4860eb18bf1b1620e37e9490fc8a427514416fd75159ab86688e9a8300000000")
+	if got := header.Hash(); got != want {
+		t.Errorf("BitcoinHeader.Hash() = %x, want %x", got, want)
+	}
+
+	tx := &AppendBitcoinHeader{Header: raw}
+	if cop := tx.Copy(); !reflect.DeepEqual(tx, cop) {
+		t.Errorf("AppendBitcoinHeader.Copy(%v) != want %v", tx, cop)
+	}
+	enc, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"header":"` + hexutil.Encode(raw) + `"}`; string(enc) != want {
+		t.Errorf("AppendBitcoinHeader.MarshalJSON() = %s, want %s", enc, want)
+	}
+	var dec AppendBitcoinHeader
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tx, &dec) {
+		t.Errorf("AppendBitcoinHeader.UnmarshalJSON() = %v, want %v", &dec, tx)
+	}
+	input := tx.Encode()
+	if len(input) != tx.Size() {
+		t.Fatalf("AppendBitcoinHeader.Encode() length %d", len(input))
+	}
+	if hash := common.BytesToHash(input[4:36]); hash != want {
+		t.Errorf("AppendBitcoinHeader.Encode() hash = %x, want %x", hash, want)
+	}
+
+	rev, err := TxDecode(BirdgeModule, BitcoinNewHeaderAction, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tx, rev) {
+		t.Errorf("AppendBitcoinHeader.Decode(%v) != want %v", rev, tx)
+	}
+
+	input[4]++
+	if err := new(AppendBitcoinHeader).Decode(input); err == nil {
+		t.Error("AppendBitcoinHeader.Decode(): expect error for hash mismatched")
+	}
+
+	if tx.Deposit() != nil || tx.Reward() != nil {
+		t.Errorf("AppendBitcoinHeader should not mint")
+	}
+	if tx.Sender() != RelayerExecutor || tx.Contract() != BitcoinContract {
+		t.Errorf("AppendBitcoinHeader sender or contract mismatched")
+	}
+}
+
+func TestBitcoinCompact(t *testing.T) {
+	tests := []struct {
+		compact uint32
+		target  string
+	}{
+		{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000"},
+		{0x207fffff, "7fffff0000000000000000000000000000000000000000000000000000000000"},
+		{0x1b0404cb, "404cb000000000000000000000000000000000000000000000000"},
+		{0x03123456, "123456"},
+		{0x02008000, "80"},
+	}
+	for _, tt := range tests {
+		want, _ := new(big.Int).SetString(tt.target, 16)
+		if got := CompactToBig(tt.compact); got.Cmp(want) != 0 {
+			t.Errorf("CompactToBig(%08x) = %x, want %x", tt.compact, got, want)
+		}
+		if got := BigToCompact(want); got != tt.compact {
+			t.Errorf("BigToCompact(%x) = %08x, want %08x", want, got, tt.compact)
+		}
+	}
+}
+
+func TestDepositProofTx(t *testing.T) {
+	tx := &DepositProofTx{
+		Txid:    common.HexToHash("0x15bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e"),
+		TxOut:   1,
+		Target:  common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+		Amount:  big.NewInt(2e13),
+		Height:  100,
+		TxIndex: 2,
+		Branch:  []common.Hash{{0x1}, {0x2}},
+		RawTx:   []byte{0x1, 0x2, 0x3},
+	}
+	if cop := tx.Copy(); !reflect.DeepEqual(tx, cop) {
+		t.Errorf("DepositProofTx.Copy(%v) != want %v", tx, cop)
+	}
+	enc, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"txid":"0x15bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e","txout":"0x1","target":"0x5e4e4d79f08120352f04d638adec7d3892b28045","amount":"0x12309ce54000","height":"0x64","txIndex":"0x2",` +
+		`"branch":["0x0100000000000000000000000000000000000000000000000000000000000000","0x0200000000000000000000000000000000000000000000000000000000000000"],"rawTx":"0x010203"}`
+	if string(enc) != want {
+		t.Errorf("DepositProofTx.MarshalJSON() = %s, want %s", enc, want)
+	}
+	var dec DepositProofTx
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tx, &dec) {
+		t.Errorf("DepositProofTx.UnmarshalJSON() = %v, want %v", &dec, tx)
+	}
+
+	input := tx.Encode()
+	if len(input) != tx.Size() {
+		t.Fatalf("DepositProofTx.Encode() length %d", len(input))
+	}
+	// the deposit input is kept for the contract
+	deposit := &DepositTx{Txid: tx.Txid, TxOut: tx.TxOut, Target: tx.Target, Amount: tx.Amount}
+	if !reflect.DeepEqual(input[:deposit.Size()], deposit.Encode()) {
+		t.Errorf("DepositProofTx.Encode() = %x, not compatible with deposit tx", input)
+	}
+
+	rev, err := TxDecode(BirdgeModule, BridgeDepositProofAction, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tx, rev) {
+		t.Errorf("DepositProofTx.Decode(%v) != want %v", rev, tx)
+	}
+	if !reflect.DeepEqual(tx.Deposit(), deposit.Deposit()) {
+		t.Errorf("DepositProofTx.Deposit() = %v", tx.Deposit())
+	}
+
+	if err := new(DepositProofTx).Decode(input[:len(input)-len(tx.RawTx)-1]); err == nil {
+		t.Error("DepositProofTx.Decode(): expect error for the truncated branch")
+	}
+}
+
+func TestBitcoinTxOutputValues(t *testing.T) {
+	// the coinbase tx of the bitcoin mainnet block 1
+	raw := hexutil.MustDecode("0x01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff0704ffff001d0104ffffffff0100f2052a0100000043410496b538e853519c726a2c91e61ec11600ae1390813a627c66fb8be7947be63c52da7589379515d4e0a604f8141781e62294721166bf621e73a82cbf2342c858eeac00000000")
+	values, err := BitcoinTxOutputValues(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, []uint64{50 * 1e8}) {
+		t.Errorf("BitcoinTxOutputValues() = %v", values)
+	}
+	if txid, want := DoubleSha256(raw), common.HexToHash("0x982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e"); txid != want {
+		t.Errorf("txid mismatch: have %x want %x", txid, want)
+	}
+	if _, err := BitcoinTxOutputValues(raw[:len(raw)-1]); err == nil {
+		t.Error("BitcoinTxOutputValues(): expect error for the truncated tx")
+	}
+}
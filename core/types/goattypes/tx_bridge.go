@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const (
@@ -13,13 +14,37 @@ const (
 	BridgeCancel2Action
 	BridgePaidAction
 	BitcoinNewHashAction
+	BitcoinNewHeaderAction
+	BridgeDepositProofAction
 )
 
+func init() {
+	RegisterModule(&ModuleInfo{
+		Module: BirdgeModule,
+		Name:   "bridge",
+		Actions: map[Action]*ActionInfo{
+			BridgeDepoitAction:       {Name: "deposit", New: func() Tx { return new(DepositTx) }},
+			BridgeCancel2Action:      {Name: "cancel2", New: func() Tx { return new(Cancel2Tx) }},
+			BridgePaidAction:         {Name: "paid", New: func() Tx { return new(PaidTx) }},
+			BitcoinNewHashAction:     {Name: "newBitcoinHash", New: func() Tx { return new(AppendBitcoinHash) }},
+			BitcoinNewHeaderAction:   {Name: "newBitcoinHeader", New: func() Tx { return new(AppendBitcoinHeader) }},
+			BridgeDepositProofAction: {Name: "depositProof", New: func() Tx { return new(DepositProofTx) }},
+		},
+	})
+}
+
+//go:generate go run github.com/fjl/gencodec -type DepositTx -field-override depositTxMarshaling -out gen_deposit_tx_json.go
+
 type DepositTx struct {
-	Txid   common.Hash
-	TxOut  uint32
-	Target common.Address
-	Amount *big.Int
+	Txid   common.Hash    `json:"txid"`
+	TxOut  uint32         `json:"txout"`
+	Target common.Address `json:"target"`
+	Amount *big.Int       `json:"amount"`
+}
+
+type depositTxMarshaling struct {
+	TxOut  hexutil.Uint64
+	Amount *hexutil.Big
 }
 
 func (tx *DepositTx) isGoatTx() {}
@@ -97,8 +122,14 @@ func (tx *DepositTx) Reward() *Mint {
 	return nil
 }
 
+//go:generate go run github.com/fjl/gencodec -type Cancel2Tx -field-override cancel2TxMarshaling -out gen_cancel2_tx_json.go
+
 type Cancel2Tx struct {
-	Id *big.Int
+	Id *big.Int `json:"id"`
+}
+
+type cancel2TxMarshaling struct {
+	Id *hexutil.Big
 }
 
 func (tx *Cancel2Tx) isGoatTx() {}
@@ -154,11 +185,19 @@ func (tx *Cancel2Tx) MethodId() [4]byte {
 	return [4]byte{0xc1, 0x9d, 0xd3, 0x20}
 }
 
+//go:generate go run github.com/fjl/gencodec -type PaidTx -field-override paidTxMarshaling -out gen_paid_tx_json.go
+
 type PaidTx struct {
-	Id     *big.Int
-	Txid   common.Hash
-	TxOut  uint32
-	Amount *big.Int
+	Id     *big.Int    `json:"id"`
+	Txid   common.Hash `json:"txid"`
+	TxOut  uint32      `json:"txout"`
+	Amount *big.Int    `json:"amount"`
+}
+
+type paidTxMarshaling struct {
+	Id     *hexutil.Big
+	TxOut  hexutil.Uint64
+	Amount *hexutil.Big
 }
 
 func (tx *PaidTx) Size() int {
@@ -236,7 +275,7 @@ func (tx *PaidTx) MethodId() [4]byte {
 }
 
 type AppendBitcoinHash struct {
-	Hash common.Hash
+	Hash common.Hash `json:"hash"`
 }
 
 func (tx *AppendBitcoinHash) Size() int {
@@ -0,0 +1,153 @@
+package goattypes
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxMerkleBranchLength is the max depth of the bitcoin tx merkle tree
+const maxMerkleBranchLength = 32
+
+//go:generate go run github.com/fjl/gencodec -type DepositProofTx -field-override depositProofTxMarshaling -out gen_deposit_proof_tx_json.go
+
+// DepositProofTx is the bridge deposit with the inclusion proof of the bitcoin tx
+//
+// The input is compatible with the deposit tx, the proof is appended after the deposit
+// input, it's ignored by the contract and verified by the execution layer against the
+// tracked bitcoin headers before the tx is applied.
+//
+// proof: height(uint64) + tx index(uint32) + branch length(uint8) + branch + raw tx
+type DepositProofTx struct {
+	Txid   common.Hash    `json:"txid"` // in the internal byte order
+	TxOut  uint32         `json:"txout"`
+	Target common.Address `json:"target"`
+	Amount *big.Int       `json:"amount"`
+
+	Height  uint64        `json:"height"`  // the height of the bitcoin block including the tx
+	TxIndex uint32        `json:"txIndex"` // the index of the tx in the bitcoin block
+	Branch  []common.Hash `json:"branch"`  // the merkle branch from the txid to the merkle root
+	RawTx   []byte        `json:"rawTx"`   // the bitcoin tx without the witness data
+}
+
+type depositProofTxMarshaling struct {
+	TxOut   hexutil.Uint64
+	Amount  *hexutil.Big
+	Height  hexutil.Uint64
+	TxIndex hexutil.Uint64
+	RawTx   hexutil.Bytes
+}
+
+func (tx *DepositProofTx) isGoatTx() {}
+
+func (tx *DepositProofTx) deposit() *DepositTx {
+	return &DepositTx{Txid: tx.Txid, TxOut: tx.TxOut, Target: tx.Target, Amount: tx.Amount}
+}
+
+func (tx *DepositProofTx) Copy() Tx {
+	return &DepositProofTx{
+		Txid:    tx.Txid,
+		TxOut:   tx.TxOut,
+		Target:  tx.Target,
+		Amount:  new(big.Int).Set(tx.Amount),
+		Height:  tx.Height,
+		TxIndex: tx.TxIndex,
+		Branch:  append([]common.Hash(nil), tx.Branch...),
+		RawTx:   common.CopyBytes(tx.RawTx),
+	}
+}
+
+func (tx *DepositProofTx) MethodId() [4]byte {
+	// deposit(bytes32 _txid, uint32 _txout, address _target, uint256 _amount)
+	return [4]byte{0xb5, 0x5a, 0xda, 0x39}
+}
+
+func (tx *DepositProofTx) Size() int {
+	return 132 + 13 + len(tx.Branch)*32 + len(tx.RawTx)
+}
+
+func (tx *DepositProofTx) Encode() []byte {
+	b := make([]byte, 0, tx.Size())
+	b = append(b, tx.deposit().Encode()...)
+	b = binary.BigEndian.AppendUint64(b, tx.Height)
+	b = binary.BigEndian.AppendUint32(b, tx.TxIndex)
+	b = append(b, uint8(len(tx.Branch)))
+	for _, h := range tx.Branch {
+		b = append(b, h[:]...)
+	}
+	return append(b, tx.RawTx...)
+}
+
+func (tx *DepositProofTx) Decode(input []byte) error {
+	if len(input) < 132+13 {
+		return errors.New("Invalid input data for deposit proof tx")
+	}
+	deposit := new(DepositTx)
+	if err := deposit.Decode(input[:132]); err != nil {
+		return err
+	}
+	input = input[132:]
+
+	height := binary.BigEndian.Uint64(input[:8])
+	index := binary.BigEndian.Uint32(input[8:12])
+	count := int(input[12])
+	input = input[13:]
+	if count > maxMerkleBranchLength || len(input) < count*32 {
+		return errors.New("invalid merkle branch for deposit proof tx")
+	}
+	branch := make([]common.Hash, count)
+	for i := range branch {
+		branch[i] = common.BytesToHash(input[i*32 : (i+1)*32])
+	}
+	*tx = DepositProofTx{
+		Txid:    deposit.Txid,
+		TxOut:   deposit.TxOut,
+		Target:  deposit.Target,
+		Amount:  deposit.Amount,
+		Height:  height,
+		TxIndex: index,
+		Branch:  branch,
+		RawTx:   common.CopyBytes(input[count*32:]),
+	}
+	return nil
+}
+
+func (tx *DepositProofTx) Sender() common.Address {
+	return RelayerExecutor
+}
+
+func (tx *DepositProofTx) Contract() common.Address {
+	return BridgeContract
+}
+
+func (tx *DepositProofTx) Deposit() *Mint {
+	return &Mint{tx.Target, new(big.Int).Set(tx.Amount)}
+}
+
+func (tx *DepositProofTx) Reward() *Mint {
+	return nil
+}
+
+// MerkleRoot computes the merkle root of the bitcoin block from the txid and the branch
+func (tx *DepositProofTx) MerkleRoot() common.Hash {
+	var (
+		cur   = tx.Txid
+		index = tx.TxIndex
+		buf   = make([]byte, 64)
+	)
+	for _, sibling := range tx.Branch {
+		if index&1 == 1 {
+			copy(buf, sibling[:])
+			copy(buf[32:], cur[:])
+		} else {
+			copy(buf, cur[:])
+			copy(buf[32:], sibling[:])
+		}
+		cur = DoubleSha256(buf)
+		index >>= 1
+	}
+	return cur
+}
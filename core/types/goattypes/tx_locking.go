@@ -0,0 +1,210 @@
+package goattypes
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	LockingCompleteUnlockAction = iota + 1
+	LockingDistributeRewardAction
+)
+
+func init() {
+	RegisterModule(&ModuleInfo{
+		Module: LockingModule,
+		Name:   "locking",
+		Actions: map[Action]*ActionInfo{
+			LockingCompleteUnlockAction:   {Name: "completeUnlock", New: func() Tx { return new(CompleteUnlockTx) }},
+			LockingDistributeRewardAction: {Name: "distributeReward", New: func() Tx { return new(DistributeRewardTx) }},
+		},
+	})
+}
+
+//go:generate go run github.com/fjl/gencodec -type CompleteUnlockTx -field-override completeUnlockTxMarshaling -out gen_complete_unlock_tx_json.go
+
+// CompleteUnlockTx completes an un-delegation request from the consensus layer
+// The native token is minted to the recipient by the EL, the other tokens are
+// transferred by the locking contract
+type CompleteUnlockTx struct {
+	Id        uint64         `json:"id"`
+	Recipient common.Address `json:"recipient"`
+	Token     common.Address `json:"token"`
+	Amount    *big.Int       `json:"amount"`
+}
+
+type completeUnlockTxMarshaling struct {
+	Id     hexutil.Uint64
+	Amount *hexutil.Big
+}
+
+func (tx *CompleteUnlockTx) isGoatTx() {}
+
+func (tx *CompleteUnlockTx) Copy() Tx {
+	return &CompleteUnlockTx{
+		Id:        tx.Id,
+		Recipient: tx.Recipient,
+		Token:     tx.Token,
+		Amount:    new(big.Int).Set(tx.Amount),
+	}
+}
+
+func (tx *CompleteUnlockTx) MethodId() [4]byte {
+	// completeUnlock(uint64 id, address recipient, address token, uint256 amount)
+	return [4]byte{0x00, 0xab, 0xa5, 0x1a}
+}
+
+func (tx *CompleteUnlockTx) Size() int {
+	return 132
+}
+
+func (tx *CompleteUnlockTx) Encode() []byte {
+	b := make([]byte, 0, tx.Size())
+
+	method := tx.MethodId()
+	b = append(b, method[:]...)
+
+	id := make([]byte, 32)
+	binary.BigEndian.PutUint64(id[24:], tx.Id)
+	b = append(b, id...)
+
+	b = append(b, common.LeftPadBytes(tx.Recipient[:], 32)...)
+	b = append(b, common.LeftPadBytes(tx.Token[:], 32)...)
+	b = append(b, tx.Amount.FillBytes(make([]byte, 32))...)
+	return b
+}
+
+func (tx *CompleteUnlockTx) Decode(input []byte) error {
+	if len(input) != tx.Size() {
+		return errors.New("Invalid input data for completeUnlock tx")
+	}
+
+	if [4]byte(input[:4]) != tx.MethodId() {
+		return errors.New("not a completeUnlock tx")
+	}
+	input = input[4:]
+
+	tx.Id = binary.BigEndian.Uint64(input[24:32])
+	input = input[32:]
+
+	tx.Recipient = common.BytesToAddress(input[:32])
+	input = input[32:]
+
+	tx.Token = common.BytesToAddress(input[:32])
+	tx.Amount = new(big.Int).SetBytes(input[32:])
+	return nil
+}
+
+func (tx *CompleteUnlockTx) Sender() common.Address {
+	return LockingExecutor
+}
+
+func (tx *CompleteUnlockTx) Contract() common.Address {
+	return LockingContract
+}
+
+func (tx *CompleteUnlockTx) Deposit() *Mint {
+	return nil
+}
+
+func (tx *CompleteUnlockTx) Reward() *Mint {
+	// the native token
+	if tx.Token == (common.Address{}) {
+		return &Mint{tx.Recipient, new(big.Int).Set(tx.Amount)}
+	}
+	return nil
+}
+
+//go:generate go run github.com/fjl/gencodec -type DistributeRewardTx -field-override distributeRewardTxMarshaling -out gen_distribute_reward_tx_json.go
+
+// DistributeRewardTx distributes the goat token reward and the gas reward to a validator
+// The gas reward is minted to the recipient by the EL, the goat token is transferred by the locking contract
+type DistributeRewardTx struct {
+	Id        uint64         `json:"id"`
+	Recipient common.Address `json:"recipient"`
+	Goat      *big.Int       `json:"goat"`
+	GasReward *big.Int       `json:"gasReward"`
+}
+
+type distributeRewardTxMarshaling struct {
+	Id        hexutil.Uint64
+	Goat      *hexutil.Big
+	GasReward *hexutil.Big
+}
+
+func (tx *DistributeRewardTx) isGoatTx() {}
+
+func (tx *DistributeRewardTx) Copy() Tx {
+	return &DistributeRewardTx{
+		Id:        tx.Id,
+		Recipient: tx.Recipient,
+		Goat:      new(big.Int).Set(tx.Goat),
+		GasReward: new(big.Int).Set(tx.GasReward),
+	}
+}
+
+func (tx *DistributeRewardTx) MethodId() [4]byte {
+	// distributeReward(uint64 id, address recipient, uint256 goat, uint256 gasReward)
+	return [4]byte{0xbd, 0x9f, 0xad, 0xb5}
+}
+
+func (tx *DistributeRewardTx) Size() int {
+	return 132
+}
+
+func (tx *DistributeRewardTx) Encode() []byte {
+	b := make([]byte, 0, tx.Size())
+
+	method := tx.MethodId()
+	b = append(b, method[:]...)
+
+	id := make([]byte, 32)
+	binary.BigEndian.PutUint64(id[24:], tx.Id)
+	b = append(b, id...)
+
+	b = append(b, common.LeftPadBytes(tx.Recipient[:], 32)...)
+	b = append(b, tx.Goat.FillBytes(make([]byte, 32))...)
+	b = append(b, tx.GasReward.FillBytes(make([]byte, 32))...)
+	return b
+}
+
+func (tx *DistributeRewardTx) Decode(input []byte) error {
+	if len(input) != tx.Size() {
+		return errors.New("Invalid input data for distributeReward tx")
+	}
+
+	if [4]byte(input[:4]) != tx.MethodId() {
+		return errors.New("not a distributeReward tx")
+	}
+	input = input[4:]
+
+	tx.Id = binary.BigEndian.Uint64(input[24:32])
+	input = input[32:]
+
+	tx.Recipient = common.BytesToAddress(input[:32])
+	input = input[32:]
+
+	tx.Goat = new(big.Int).SetBytes(input[:32])
+	tx.GasReward = new(big.Int).SetBytes(input[32:])
+	return nil
+}
+
+func (tx *DistributeRewardTx) Sender() common.Address {
+	return LockingExecutor
+}
+
+func (tx *DistributeRewardTx) Contract() common.Address {
+	return LockingContract
+}
+
+func (tx *DistributeRewardTx) Deposit() *Mint {
+	return nil
+}
+
+func (tx *DistributeRewardTx) Reward() *Mint {
+	return &Mint{tx.Recipient, new(big.Int).Set(tx.GasReward)}
+}
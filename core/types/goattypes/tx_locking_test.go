@@ -0,0 +1,167 @@
+package goattypes
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestCompleteUnlockTx(t *testing.T) {
+	type fields struct {
+		Id        uint64
+		Recipient common.Address
+		Token     common.Address
+		Amount    *big.Int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []byte
+		reward bool
+	}{
+		{
+			name: "native",
+			fields: fields{
+				Id:        0x1f,
+				Recipient: common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+				Token:     common.Address{},
+				Amount:    big.NewInt(0x157f7f97),
+			},
+			want:   hexutil.MustDecode("0x00aba51a000000000000000000000000000000000000000000000000000000000000001f0000000000000000000000005e4e4d79f08120352f04d638adec7d3892b28045000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000157f7f97"),
+			reward: true,
+		},
+		{
+			name: "erc20",
+			fields: fields{
+				Id:        0x1f,
+				Recipient: common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+				Token:     common.HexToAddress("0x7594e474ae8ee2e70f67401c466a9415610e0212"),
+				Amount:    big.NewInt(0x157f7f97),
+			},
+			want:   hexutil.MustDecode("0x00aba51a000000000000000000000000000000000000000000000000000000000000001f0000000000000000000000005e4e4d79f08120352f04d638adec7d3892b280450000000000000000000000007594e474ae8ee2e70f67401c466a9415610e021200000000000000000000000000000000000000000000000000000000157f7f97"),
+			reward: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &CompleteUnlockTx{
+				Id:        tt.fields.Id,
+				Recipient: tt.fields.Recipient,
+				Token:     tt.fields.Token,
+				Amount:    tt.fields.Amount,
+			}
+
+			if cop := tx.Copy(); !reflect.DeepEqual(tx, cop) {
+				t.Errorf("CompleteUnlockTx.Copy(%v) != want %v", tx, cop)
+			}
+
+			got := tx.Encode()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompleteUnlockTx.Encode() = %x, want %x", got, tt.want)
+			}
+
+			rev := new(CompleteUnlockTx)
+			if err := rev.Decode(got); err != nil {
+				t.Errorf("CompleteUnlockTx.Decode(): %s", err)
+			}
+
+			if !reflect.DeepEqual(tx, rev) {
+				t.Errorf("CompleteUnlockTx.Decode(%v) != want %v", tx, rev)
+			}
+
+			if tx.Deposit() != nil {
+				t.Errorf("CompleteUnlockTx.Deposit() != nil")
+			}
+
+			if tt.reward {
+				want := &Mint{tx.Recipient, new(big.Int).Set(tx.Amount)}
+				if got := tx.Reward(); !reflect.DeepEqual(got, want) {
+					t.Errorf("CompleteUnlockTx.Reward(%v) != want %v", got, want)
+				}
+			} else if tx.Reward() != nil {
+				t.Errorf("CompleteUnlockTx.Reward() != nil")
+			}
+
+			if tx.Sender() != LockingExecutor {
+				t.Errorf("CompleteUnlockTx.Sender() != LockingExecutor")
+			}
+
+			if tx.Contract() != LockingContract {
+				t.Errorf("CompleteUnlockTx.Contract() != LockingContract")
+			}
+		})
+	}
+}
+
+func TestDistributeRewardTx(t *testing.T) {
+	type fields struct {
+		Id        uint64
+		Recipient common.Address
+		Goat      *big.Int
+		GasReward *big.Int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []byte
+	}{
+		{
+			name: "1",
+			fields: fields{
+				Id:        0x64,
+				Recipient: common.HexToAddress("0xd12a5a92d4621fbe3068914988d538c410245443"),
+				Goat:      big.NewInt(0xba606dcd),
+				GasReward: big.NewInt(0x32cc827f),
+			},
+			want: hexutil.MustDecode("0xbd9fadb50000000000000000000000000000000000000000000000000000000000000064000000000000000000000000d12a5a92d4621fbe3068914988d538c41024544300000000000000000000000000000000000000000000000000000000ba606dcd0000000000000000000000000000000000000000000000000000000032cc827f"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &DistributeRewardTx{
+				Id:        tt.fields.Id,
+				Recipient: tt.fields.Recipient,
+				Goat:      tt.fields.Goat,
+				GasReward: tt.fields.GasReward,
+			}
+
+			if cop := tx.Copy(); !reflect.DeepEqual(tx, cop) {
+				t.Errorf("DistributeRewardTx.Copy(%v) != want %v", tx, cop)
+			}
+
+			got := tx.Encode()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistributeRewardTx.Encode() = %x, want %x", got, tt.want)
+			}
+
+			rev := new(DistributeRewardTx)
+			if err := rev.Decode(got); err != nil {
+				t.Errorf("DistributeRewardTx.Decode(): %s", err)
+			}
+
+			if !reflect.DeepEqual(tx, rev) {
+				t.Errorf("DistributeRewardTx.Decode(%v) != want %v", tx, rev)
+			}
+
+			if tx.Deposit() != nil {
+				t.Errorf("DistributeRewardTx.Deposit() != nil")
+			}
+
+			want := &Mint{tx.Recipient, new(big.Int).Set(tx.GasReward)}
+			if got := tx.Reward(); !reflect.DeepEqual(got, want) {
+				t.Errorf("DistributeRewardTx.Reward(%v) != want %v", got, want)
+			}
+
+			if tx.Sender() != LockingExecutor {
+				t.Errorf("DistributeRewardTx.Sender() != LockingExecutor")
+			}
+
+			if tx.Contract() != LockingContract {
+				t.Errorf("DistributeRewardTx.Contract() != LockingContract")
+			}
+		})
+	}
+}
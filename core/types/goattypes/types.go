@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 type Module uint8
@@ -16,9 +17,15 @@ const (
 
 type Action uint8
 
+//go:generate go run github.com/fjl/gencodec -type Mint -field-override mintMarshaling -out gen_mint_json.go
+
 type Mint struct {
-	Address common.Address
-	Amount  *big.Int
+	Address common.Address `json:"address"`
+	Amount  *big.Int       `json:"amount"`
+}
+
+type mintMarshaling struct {
+	Amount *hexutil.Big
 }
 
 type Tx interface {
@@ -37,18 +44,8 @@ type Tx interface {
 
 func TxDecode(module Module, action Action, data []byte) (Tx, error) {
 	var inner Tx
-	switch module {
-	case BirdgeModule:
-		switch action {
-		case BridgeDepoitAction:
-			inner = new(DepositTx)
-		case BridgeCancel2Action:
-			inner = new(Cancel2Tx)
-		case BridgePaidAction:
-			inner = new(PaidTx)
-		case BitcoinNewHashAction:
-			inner = new(AppendBitcoinHash)
-		}
+	if info := LookupAction(module, action); info != nil {
+		inner = info.New()
 	}
 	if inner == nil {
 		return nil, fmt.Errorf("unrecognized goat tx(module %d action %d)", module, action)
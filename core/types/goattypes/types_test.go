@@ -103,6 +103,56 @@ func TestTxDecode(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "completeUnlock",
+			args: args{
+				module: LockingModule,
+				action: LockingCompleteUnlockAction,
+				data:   hexutil.MustDecode("0x00aba51a000000000000000000000000000000000000000000000000000000000000001f0000000000000000000000005e4e4d79f08120352f04d638adec7d3892b280450000000000000000000000007594e474ae8ee2e70f67401c466a9415610e021200000000000000000000000000000000000000000000000000000000157f7f97"),
+			},
+			want: &CompleteUnlockTx{
+				Id:        0x1f,
+				Recipient: common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+				Token:     common.HexToAddress("0x7594e474ae8ee2e70f67401c466a9415610e0212"),
+				Amount:    big.NewInt(0x157f7f97),
+			},
+			wantErr: false,
+		},
+		{
+			name: "completeUnlock-false",
+			args: args{
+				module: LockingModule,
+				action: LockingCompleteUnlockAction,
+				data:   hexutil.MustDecode("0xbd9fadb50000000000000000000000000000000000000000000000000000000000000064000000000000000000000000d12a5a92d4621fbe3068914988d538c41024544300000000000000000000000000000000000000000000000000000000ba606dcd0000000000000000000000000000000000000000000000000000000032cc827f"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "distributeReward",
+			args: args{
+				module: LockingModule,
+				action: LockingDistributeRewardAction,
+				data:   hexutil.MustDecode("0xbd9fadb50000000000000000000000000000000000000000000000000000000000000064000000000000000000000000d12a5a92d4621fbe3068914988d538c41024544300000000000000000000000000000000000000000000000000000000ba606dcd0000000000000000000000000000000000000000000000000000000032cc827f"),
+			},
+			want: &DistributeRewardTx{
+				Id:        0x64,
+				Recipient: common.HexToAddress("0xd12a5a92d4621fbe3068914988d538c410245443"),
+				Goat:      big.NewInt(0xba606dcd),
+				GasReward: big.NewInt(0x32cc827f),
+			},
+			wantErr: false,
+		},
+		{
+			name: "locking-unknown",
+			args: args{
+				module: LockingModule,
+				action: 0xff,
+				data:   hexutil.MustDecode("0x1234"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -117,3 +167,21 @@ func TestTxDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleRegistry(t *testing.T) {
+	if name := ModuleName(BirdgeModule); name != "bridge" {
+		t.Fatalf("bridge module name mismatch: %q", name)
+	}
+	if name := ActionName(LockingModule, LockingDistributeRewardAction); name != "distributeReward" {
+		t.Fatalf("locking action name mismatch: %q", name)
+	}
+	if ActionName(LockingModule, 0xff) != "" || LookupModule(0xff) != nil {
+		t.Fatal("unknown module or action found")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("duplicate module is registered")
+		}
+	}()
+	RegisterModule(&ModuleInfo{Module: LockingModule, Name: "dup"})
+}
@@ -153,24 +153,14 @@ func (r *Request) decode(b []byte) (RequestData, error) {
 	}
 	var inner RequestData
 	switch b[0] {
-	// goat types
-	case GoatGasRevenueRequestType:
-		inner = new(GasRevenue)
-	case GoatAddVoterRequestType:
-		inner = new(AddVoter)
-	case GoatRemoveVoterRequestType:
-		inner = new(RemoveVoter)
-	case GoatWithdrawalRequestType:
-		inner = new(BridgeWithdrawal)
-	case GoatReplaceByFeeRequestType:
-		inner = new(ReplaceByFee)
-	case GoatCancel1RequestType:
-		inner = new(ReplaceByFee)
-
 	case DepositRequestType:
 		inner = new(Deposit)
 	default:
-		return nil, ErrRequestTypeNotSupported
+		kind := LookupGoatRequestKind(b[0])
+		if kind == nil {
+			return nil, ErrRequestTypeNotSupported
+		}
+		inner = kind.New()
 	}
 	err := inner.decode(b[1:])
 	return inner, err
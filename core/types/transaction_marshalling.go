@@ -19,7 +19,10 @@ package types
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -54,13 +57,35 @@ type txJSON struct {
 	Commitments []kzg4844.Commitment `json:"commitments,omitempty"`
 	Proofs      []kzg4844.Proof      `json:"proofs,omitempty"`
 
-	Module *goattypes.Module `json:"module,omitempty"`
-	Action *goattypes.Action `json:"action,omitempty"`
+	Module *goatTxQuantity `json:"module,omitempty"`
+	Action *goatTxQuantity `json:"action,omitempty"`
 
 	// Only used for encoding:
 	Hash common.Hash `json:"hash"`
 }
 
+// goatTxQuantity is the module or action of the goat tx, it's encoded as a hex quantity
+// and the JSON number of the older encoding is accepted as well.
+type goatTxQuantity hexutil.Uint64
+
+// MarshalText implements encoding.TextMarshaler.
+func (q goatTxQuantity) MarshalText() ([]byte, error) {
+	return hexutil.Uint64(q).MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (q *goatTxQuantity) UnmarshalJSON(input []byte) error {
+	if len(input) > 0 && input[0] == '"' {
+		return (*hexutil.Uint64)(q).UnmarshalJSON(input)
+	}
+	v, err := strconv.ParseUint(string(input), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid goat tx module or action %s", input)
+	}
+	*q = goatTxQuantity(v)
+	return nil
+}
+
 // yParityValue returns the YParity value from JSON. For backwards-compatibility reasons,
 // this can be given in the 'v' field or the 'yParity' field. If both exist, they must match.
 func (tx *txJSON) yParityValue() (*big.Int, error) {
@@ -159,8 +184,8 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 		}
 
 	case *GoatTx:
-		enc.Module = &itx.Module
-		enc.Action = &itx.Action
+		module, action := goatTxQuantity(itx.Module), goatTxQuantity(itx.Action)
+		enc.Module, enc.Action = &module, &action
 		enc.Nonce = (*hexutil.Uint64)(&itx.Nonce)
 		enc.Input = (*hexutil.Bytes)(&itx.Data)
 		enc.To = tx.To()
@@ -428,12 +453,18 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		if dec.Module == nil {
 			return errors.New("missing required field 'module' in transaction")
 		}
-		itx.Module = *dec.Module
+		if *dec.Module > math.MaxUint8 {
+			return errors.New("'module' value overflows uint8")
+		}
+		itx.Module = goattypes.Module(*dec.Module)
 
 		if dec.Action == nil {
 			return errors.New("missing required field 'action' in transaction")
 		}
-		itx.Action = *dec.Action
+		if *dec.Action > math.MaxUint8 {
+			return errors.New("'action' value overflows uint8")
+		}
+		itx.Action = goattypes.Action(*dec.Action)
 
 		if dec.Nonce == nil {
 			return errors.New("missing required field 'nonce' in transaction")
@@ -53,7 +53,7 @@ func MakeSigner(config *params.ChainConfig, blockNumber *big.Int, blockTime uint
 	default:
 		signer = FrontierSigner{}
 	}
-	return signer
+	return withGoatSigner(config, signer)
 }
 
 // LatestSigner returns the 'most permissive' Signer available for the given chain
@@ -81,7 +81,7 @@ func LatestSigner(config *params.ChainConfig) Signer {
 	} else {
 		signer = HomesteadSigner{}
 	}
-	return signer
+	return withGoatSigner(config, signer)
 }
 
 // LatestSignerForChainID returns the 'most permissive' Signer available. Specifically,
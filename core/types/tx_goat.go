@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -37,6 +38,38 @@ func (tx *Transaction) Reward() *goattypes.Mint {
 	return tx.inner.(*GoatTx).inner.Reward()
 }
 
+// BitcoinHeader returns the raw bitcoin header appended by the goat tx, it returns nil
+// if it's not a bitcoin header goat tx
+func (tx *Transaction) BitcoinHeader() []byte {
+	if !tx.IsGoatTx() {
+		return nil
+	}
+	if v, ok := tx.inner.(*GoatTx).inner.(*goattypes.AppendBitcoinHeader); ok {
+		return common.CopyBytes(v.Header)
+	}
+	return nil
+}
+
+// DepositProof returns the bridge deposit with the bitcoin inclusion proof, it returns nil
+// if it's not a deposit proof goat tx
+func (tx *Transaction) DepositProof() *goattypes.DepositProofTx {
+	if !tx.IsGoatTx() {
+		return nil
+	}
+	if v, ok := tx.inner.(*GoatTx).inner.(*goattypes.DepositProofTx); ok {
+		return v.Copy().(*goattypes.DepositProofTx)
+	}
+	return nil
+}
+
+// GoatTx returns a copy of the goat tx data, it returns nil if it's not a goat tx
+func (tx *Transaction) GoatTx() *GoatTx {
+	if !tx.IsGoatTx() {
+		return nil
+	}
+	return tx.inner.copy().(*GoatTx)
+}
+
 const (
 	GoatTxType = 0x60
 )
@@ -109,3 +142,45 @@ func (tx *GoatTx) decode(input []byte) error {
 func (tx *GoatTx) Sender() common.Address {
 	return tx.inner.Sender()
 }
+
+// goatSigner resolves the default executors of the goat txs to the configured ones
+// of the chain, the other txs are handled by the wrapped signer.
+type goatSigner struct {
+	Signer
+	goat *params.GoatConfig
+}
+
+// withGoatSigner wraps the signer if the chain is configured with the system addresses
+// other than the defaults.
+func withGoatSigner(config *params.ChainConfig, signer Signer) Signer {
+	if config.Goat == nil || config.Goat.Addresses == nil {
+		return signer
+	}
+	return goatSigner{Signer: signer, goat: config.Goat}
+}
+
+func (s goatSigner) Sender(tx *Transaction) (common.Address, error) {
+	if tx.IsGoatTx() {
+		return GoatSystemAddress(s, tx.inner.(*GoatTx).Sender()), nil
+	}
+	return s.Signer.Sender(tx)
+}
+
+func (s goatSigner) Equal(s2 Signer) bool {
+	x, ok := s2.(goatSigner)
+	return ok && x.goat.SystemAddresses() == s.goat.SystemAddresses() && x.Signer.Equal(s.Signer)
+}
+
+// GoatSystemAddress maps the default system contract or executor address used by the
+// goat txs to the configured one of the chain which the signer is made for.
+func GoatSystemAddress(s Signer, addr common.Address) common.Address {
+	if gs, ok := s.(goatSigner); ok {
+		return gs.goat.SystemAddress(addr)
+	}
+	return addr
+}
+
+// Inner returns the decoded goat tx
+func (tx *GoatTx) Inner() goattypes.Tx {
+	return tx.inner
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+)
+
+func TestGoatTxJSON(t *testing.T) {
+	tx := NewTx(NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 1, &goattypes.DepositTx{
+		Txid:   common.Hash{0x1},
+		TxOut:  2,
+		Target: common.Address{0x3},
+		Amount: big.NewInt(1e10),
+	}))
+	enc, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(enc, []byte(`"module":"0x1"`)) || !bytes.Contains(enc, []byte(`"action":"0x1"`)) {
+		t.Fatalf("module and action are not hex quantities: %s", enc)
+	}
+	// the numeric module and action of the older encoding are accepted
+	legacy := bytes.Replace(enc, []byte(`"module":"0x1"`), []byte(`"module":1`), 1)
+	legacy = bytes.Replace(legacy, []byte(`"action":"0x1"`), []byte(`"action":1`), 1)
+	for _, input := range [][]byte{enc, legacy} {
+		var dec Transaction
+		if err := json.Unmarshal(input, &dec); err != nil {
+			t.Fatalf("failed to decode %s: %v", input, err)
+		}
+		if dec.Hash() != tx.Hash() {
+			t.Errorf("goat tx mismatch after decoding %s", input)
+		}
+	}
+	invalid := bytes.Replace(enc, []byte(`"module":"0x1"`), []byte(`"module":-1`), 1)
+	if err := json.Unmarshal(invalid, new(Transaction)); err == nil {
+		t.Error("expect error for the negative module")
+	}
+}
@@ -83,6 +83,8 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	goatWithdrawalIndexer *core.ChainIndexer // Bridge withdrawal indexer of the goat chain
+
 	APIBackend *EthAPIBackend
 
 	miner    *miner.Miner
@@ -221,6 +223,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
+	if chainConfig.Goat != nil {
+		eth.goatWithdrawalIndexer = core.NewGoatWithdrawalIndexer(chainDb, chainConfig.Goat, params.GoatWithdrawalBlocks, params.GoatWithdrawalConfirms)
+		eth.goatWithdrawalIndexer.Start(eth.blockchain)
+	}
 
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
@@ -408,6 +414,9 @@ func (s *Ethereum) Stop() error {
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	if s.goatWithdrawalIndexer != nil {
+		s.goatWithdrawalIndexer.Close()
+	}
 	s.txPool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
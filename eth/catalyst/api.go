@@ -88,15 +88,20 @@ var caps = []string{
 	"engine_getPayloadV2",
 	"engine_getPayloadV3",
 	"engine_getPayloadV4",
+	"engine_getPayloadV5",
 	"engine_newPayloadV1",
 	"engine_newPayloadV2",
 	"engine_newPayloadV3",
 	"engine_newPayloadV4",
+	"engine_newPayloadV5",
 	"engine_getPayloadBodiesByHashV1",
 	"engine_getPayloadBodiesByHashV2",
+	"engine_getPayloadBodiesByHashV3",
 	"engine_getPayloadBodiesByRangeV1",
 	"engine_getPayloadBodiesByRangeV2",
+	"engine_getPayloadBodiesByRangeV3",
 	"engine_getClientVersionV1",
+	"engine_simulateGoatPayloadV1",
 }
 
 type ConsensusAPI struct {
@@ -364,21 +369,9 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	// sealed by the beacon client. The payload will be requested later, and we
 	// will replace it arbitrarily many times in between.
 	if payloadAttributes != nil {
-		// goat
-		if d := len(payloadAttributes.GoatTxs); d > params.GoatTxLimitPerBlock {
-			return engine.STATUS_INVALID, fmt.Errorf("goat tx size too large(size %d)", d)
-		}
-
-		goatTxs := make([]*types.Transaction, 0, len(payloadAttributes.GoatTxs))
-		for i, otx := range payloadAttributes.GoatTxs {
-			var tx = new(types.Transaction)
-			if err := tx.UnmarshalBinary(otx); err != nil {
-				return engine.STATUS_INVALID, fmt.Errorf("not a valid transaction %d: %v", i, err)
-			}
-			if !tx.IsGoatTx() {
-				return engine.STATUS_INVALID, fmt.Errorf("not a goat tx %d", i)
-			}
-			goatTxs = append(goatTxs, tx)
+		goatTxs, err := api.decodeGoatTxs(block.Header(), payloadAttributes)
+		if err != nil {
+			return engine.STATUS_INVALID, err
 		}
 
 		args := &miner.BuildPayloadArgs{
@@ -392,6 +385,9 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 
 			GoatTxs: goatTxs,
 		}
+		if payloadAttributes.ConsensusBlockHash != nil {
+			args.ConsensusBlockHash = *payloadAttributes.ConsensusBlockHash
+		}
 		id := args.Id()
 		// If we already are busy generating this work, then we do not need
 		// to start a second process.
@@ -401,7 +397,7 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		payload, err := api.eth.Miner().BuildPayload(args)
 		if err != nil {
 			log.Error("Failed to build payload", "err", err)
-			return valid(nil), engine.InvalidPayloadAttributes.With(err)
+			return valid(nil), goatPayloadError(err, goatTxs)
 		}
 		api.localBlocks.put(id, payload)
 		return valid(&id), nil
@@ -448,7 +444,7 @@ func (api *ConsensusAPI) GetPayloadV1(payloadID engine.PayloadID) (*engine.Execu
 	if err != nil {
 		return nil, err
 	}
-	return data.ExecutionPayload, nil
+	return engine.LegacyRequestsEnvelope(data).ExecutionPayload, nil
 }
 
 // GetPayloadV2 returns a cached payload by id.
@@ -456,7 +452,11 @@ func (api *ConsensusAPI) GetPayloadV2(payloadID engine.PayloadID) (*engine.Execu
 	if !payloadID.Is(engine.PayloadV1, engine.PayloadV2) {
 		return nil, engine.UnsupportedFork
 	}
-	return api.getPayload(payloadID, false)
+	data, err := api.getPayload(payloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	return engine.LegacyRequestsEnvelope(data), nil
 }
 
 // GetPayloadV3 returns a cached payload by id.
@@ -464,7 +464,11 @@ func (api *ConsensusAPI) GetPayloadV3(payloadID engine.PayloadID) (*engine.Execu
 	if !payloadID.Is(engine.PayloadV3) {
 		return nil, engine.UnsupportedFork
 	}
-	return api.getPayload(payloadID, false)
+	data, err := api.getPayload(payloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	return engine.LegacyRequestsEnvelope(data), nil
 }
 
 // GetPayloadV4 returns a cached payload by id.
@@ -472,7 +476,24 @@ func (api *ConsensusAPI) GetPayloadV4(payloadID engine.PayloadID) (*engine.Execu
 	if !payloadID.Is(engine.PayloadV3) {
 		return nil, engine.UnsupportedFork
 	}
-	return api.getPayload(payloadID, false)
+	data, err := api.getPayload(payloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	return engine.LegacyRequestsEnvelope(data), nil
+}
+
+// GetPayloadV5 returns a cached payload by id. Unlike V4, the requests of the payload
+// are encoded as the ordered list of the typed opaque requests.
+func (api *ConsensusAPI) GetPayloadV5(payloadID engine.PayloadID) (*engine.RequestListPayloadEnvelope, error) {
+	if !payloadID.Is(engine.PayloadV3) {
+		return nil, engine.UnsupportedFork
+	}
+	data, err := api.getPayload(payloadID, false)
+	if err != nil {
+		return nil, err
+	}
+	return engine.RequestListEnvelope(data), nil
 }
 
 func (api *ConsensusAPI) getPayload(payloadID engine.PayloadID, full bool) (*engine.ExecutionPayloadEnvelope, error) {
@@ -568,6 +589,47 @@ func (api *ConsensusAPI) NewPayloadV4(params engine.ExecutableData, versionedHas
 	return api.newPayload(params, versionedHashes, beaconRoot)
 }
 
+// NewPayloadV5 creates an Eth1 block, inserts it in the chain, and returns the status of the chain.
+// Unlike V4, the requests are carried by the ordered list of the typed opaque requests instead
+// of the per type request lists, and it's used by both the cancun and prague payloads.
+func (api *ConsensusAPI) NewPayloadV5(params engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash) (engine.PayloadStatusV1, error) {
+	if params.Withdrawals == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil withdrawals post-shanghai"))
+	}
+	if params.ExcessBlobGas == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil excessBlobGas post-cancun"))
+	}
+	if params.BlobGasUsed == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil blobGasUsed post-cancun"))
+	}
+	if engine.HasLegacyRequests(&params) {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("unexpected per type request lists in V5"))
+	}
+
+	if versionedHashes == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil versionedHashes post-cancun"))
+	}
+	if beaconRoot == nil {
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil beaconRoot post-cancun"))
+	}
+
+	config := api.eth.BlockChain().Config()
+	switch config.LatestFork(params.Timestamp) {
+	case forks.Cancun:
+		// the goat requests are present since the genesis
+		if config.Goat != nil && params.Requests == nil {
+			return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil requests on goat chain"))
+		}
+	case forks.Prague:
+		if params.Requests == nil {
+			return engine.PayloadStatusV1{Status: engine.INVALID}, engine.InvalidParams.With(errors.New("nil requests post-prague"))
+		}
+	default:
+		return engine.PayloadStatusV1{Status: engine.INVALID}, engine.UnsupportedFork.With(errors.New("newPayloadV5 must only be called for cancun and prague payloads"))
+	}
+	return api.newPayload(params, versionedHashes, beaconRoot)
+}
+
 func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash) (engine.PayloadStatusV1, error) {
 	// The locking here is, strictly, not required. Without these locks, this can happen:
 	//
@@ -586,7 +648,7 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 	defer api.newPayloadLock.Unlock()
 
 	log.Trace("Engine API request received", "method", "NewPayload", "number", params.Number, "hash", params.BlockHash)
-	block, err := engine.ExecutableDataToBlock(params, versionedHashes, beaconRoot)
+	block, err := engine.ExecutableDataToBlock(params, versionedHashes, beaconRoot, api.eth.BlockChain().Config())
 	if err != nil {
 		bgu := "nil"
 		if params.BlobGasUsed != nil {
@@ -627,6 +689,13 @@ func (api *ConsensusAPI) newPayload(params engine.ExecutableData, versionedHashe
 			"len(params.BridgeWithdrawals)", len(params.BridgeWithdrawals),
 			"len(params.ReplaceByFees)", len(params.ReplaceByFees),
 			"len(params.Cancel1s)", len(params.Cancel1s),
+			"len(params.CreateValidators)", len(params.CreateValidators),
+			"len(params.Locks)", len(params.Locks),
+			"len(params.Unlocks)", len(params.Unlocks),
+			"len(params.Claims)", len(params.Claims),
+			"len(params.UpdateTokenWeights)", len(params.UpdateTokenWeights),
+			"len(params.UpdateTokenThresholds)", len(params.UpdateTokenThresholds),
+			"len(params.Grants)", len(params.Grants),
 			"beaconRoot", beaconRoot,
 			"error", err)
 		return api.invalid(err, nil), nil
@@ -904,7 +973,7 @@ func (api *ConsensusAPI) GetPayloadBodiesByHashV1(hashes []common.Hash) []*engin
 	bodies := make([]*engine.ExecutionPayloadBody, len(hashes))
 	for i, hash := range hashes {
 		block := api.eth.BlockChain().GetBlockByHash(hash)
-		body := getBody(block)
+		body := getBody(block, false)
 		if body != nil {
 			// Nil out the V2 values, clients should know to not request V1 objects
 			// after Prague.
@@ -921,7 +990,18 @@ func (api *ConsensusAPI) GetPayloadBodiesByHashV2(hashes []common.Hash) []*engin
 	bodies := make([]*engine.ExecutionPayloadBody, len(hashes))
 	for i, hash := range hashes {
 		block := api.eth.BlockChain().GetBlockByHash(hash)
-		bodies[i] = getBody(block)
+		bodies[i] = getBody(block, false)
+	}
+	return bodies
+}
+
+// GetPayloadBodiesByHashV3 implements engine_getPayloadBodiesByHashV3, the requests
+// of the bodies are encoded as the ordered list of the typed opaque requests.
+func (api *ConsensusAPI) GetPayloadBodiesByHashV3(hashes []common.Hash) []*engine.ExecutionPayloadBody {
+	bodies := make([]*engine.ExecutionPayloadBody, len(hashes))
+	for i, hash := range hashes {
+		block := api.eth.BlockChain().GetBlockByHash(hash)
+		bodies[i] = getBody(block, true)
 	}
 	return bodies
 }
@@ -929,7 +1009,7 @@ func (api *ConsensusAPI) GetPayloadBodiesByHashV2(hashes []common.Hash) []*engin
 // GetPayloadBodiesByRangeV1 implements engine_getPayloadBodiesByRangeV1 which allows for retrieval of a range
 // of block bodies by the engine api.
 func (api *ConsensusAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBody, error) {
-	bodies, err := api.getBodiesByRange(start, count)
+	bodies, err := api.getBodiesByRange(start, count, false)
 	if err != nil {
 		return nil, err
 	}
@@ -946,10 +1026,16 @@ func (api *ConsensusAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64)
 // GetPayloadBodiesByRangeV2 implements engine_getPayloadBodiesByRangeV1 which allows for retrieval of a range
 // of block bodies by the engine api.
 func (api *ConsensusAPI) GetPayloadBodiesByRangeV2(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBody, error) {
-	return api.getBodiesByRange(start, count)
+	return api.getBodiesByRange(start, count, false)
 }
 
-func (api *ConsensusAPI) getBodiesByRange(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBody, error) {
+// GetPayloadBodiesByRangeV3 implements engine_getPayloadBodiesByRangeV3, the requests
+// of the bodies are encoded as the ordered list of the typed opaque requests.
+func (api *ConsensusAPI) GetPayloadBodiesByRangeV3(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBody, error) {
+	return api.getBodiesByRange(start, count, true)
+}
+
+func (api *ConsensusAPI) getBodiesByRange(start, count hexutil.Uint64, requestList bool) ([]*engine.ExecutionPayloadBody, error) {
 	if start == 0 || count == 0 {
 		return nil, engine.InvalidParams.With(fmt.Errorf("invalid start or count, start: %v count: %v", start, count))
 	}
@@ -965,28 +1051,20 @@ func (api *ConsensusAPI) getBodiesByRange(start, count hexutil.Uint64) ([]*engin
 	bodies := make([]*engine.ExecutionPayloadBody, 0, uint64(count))
 	for i := uint64(start); i <= last; i++ {
 		block := api.eth.BlockChain().GetBlockByNumber(i)
-		bodies = append(bodies, getBody(block))
+		bodies = append(bodies, getBody(block, requestList))
 	}
 	return bodies, nil
 }
 
-func getBody(block *types.Block) *engine.ExecutionPayloadBody {
+func getBody(block *types.Block, requestList bool) *engine.ExecutionPayloadBody {
 	if block == nil {
 		return nil
 	}
 
 	var (
-		body            = block.Body()
-		txs             = make([]hexutil.Bytes, len(body.Transactions))
-		withdrawals     = body.Withdrawals
-		depositRequests types.Deposits
-
-		gasRevenueRequests       types.GasRevenues
-		addVoterRequests         types.AddVoters
-		removeVoterRequests      types.RemoveVoters
-		bridgeWithdrawalRequests types.BridgeWithdrawals
-		replaceByFeeRequests     types.ReplaceByFees
-		cancel1Requests          types.Cancel1s
+		body        = block.Body()
+		txs         = make([]hexutil.Bytes, len(body.Transactions))
+		withdrawals = body.Withdrawals
 	)
 
 	for j, tx := range body.Transactions {
@@ -998,41 +1076,15 @@ func getBody(block *types.Block) *engine.ExecutionPayloadBody {
 		withdrawals = make([]*types.Withdrawal, 0)
 	}
 
+	res := &engine.ExecutionPayloadBody{
+		TransactionData: txs,
+		Withdrawals:     withdrawals,
+	}
 	if block.Header().RequestsHash != nil {
 		// TODO: this isn't future proof because we can't determine if a request
 		// type has activated yet or if there are just no requests of that type from
 		// only the block.
-		for _, req := range block.Requests() {
-			switch v := req.Inner().(type) {
-			case *types.GasRevenue:
-				gasRevenueRequests = append(gasRevenueRequests, v)
-			case *types.AddVoter:
-				addVoterRequests = append(addVoterRequests, v)
-			case *types.RemoveVoter:
-				removeVoterRequests = append(removeVoterRequests, v)
-			case *types.BridgeWithdrawal:
-				bridgeWithdrawalRequests = append(bridgeWithdrawalRequests, v)
-			case *types.ReplaceByFee:
-				replaceByFeeRequests = append(replaceByFeeRequests, v)
-			case *types.Cancel1:
-				cancel1Requests = append(cancel1Requests, v)
-
-			case *types.Deposit:
-				depositRequests = append(depositRequests, v)
-			}
-		}
-	}
-
-	return &engine.ExecutionPayloadBody{
-		TransactionData: txs,
-		Withdrawals:     withdrawals,
-		Deposits:        depositRequests,
-
-		GasRevenues:       gasRevenueRequests,
-		AddVoters:         addVoterRequests,
-		RemoveVoters:      removeVoterRequests,
-		BridgeWithdrawals: bridgeWithdrawalRequests,
-		ReplaceByFees:     replaceByFeeRequests,
-		Cancel1s:          cancel1Requests,
+		engine.SetPayloadBodyRequests(block.Requests(), res, requestList)
 	}
+	return res
 }
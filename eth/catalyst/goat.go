@@ -2,10 +2,141 @@ package catalyst
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/params"
 )
 
 func (api *ConsensusAPI) GetChainConfig(_ context.Context) (*params.ChainConfig, error) {
 	return api.eth.BlockChain().Config(), nil
 }
+
+// decodeGoatTxs decodes the goat txs of the payload attributes and verifies them
+// against the parent state
+func (api *ConsensusAPI) decodeGoatTxs(parent *types.Header, attrs *engine.PayloadAttributes) (types.Transactions, error) {
+	goatConfig := api.eth.BlockChain().Config().Goat
+	if d := uint64(len(attrs.GoatTxs)); d > goatConfig.GoatTxLimitAt(attrs.Timestamp) {
+		return nil, engine.InvalidPayloadAttributes.With(fmt.Errorf("goat tx size too large(size %d)", d))
+	}
+
+	goatTxs := make([]*types.Transaction, 0, len(attrs.GoatTxs))
+	for i, otx := range attrs.GoatTxs {
+		var tx = new(types.Transaction)
+		if err := tx.UnmarshalBinary(otx); err != nil {
+			return nil, engine.InvalidPayloadAttributes.With(fmt.Errorf("not a valid transaction %d: %v", i, err))
+		}
+		if !tx.IsGoatTx() {
+			return nil, engine.InvalidPayloadAttributes.With(fmt.Errorf("not a goat tx %d", i))
+		}
+		goatTxs = append(goatTxs, tx)
+	}
+	if len(goatTxs) != 0 {
+		statedb, err := api.eth.BlockChain().StateAt(parent.Root)
+		if err != nil {
+			return nil, engine.InvalidPayloadAttributes.With(fmt.Errorf("failed to read the goat executor nonces: %v", err))
+		}
+		if err := core.VerifyGoatTxs(goatConfig, goatTxs, statedb); err != nil {
+			return nil, engine.InvalidPayloadAttributes.With(err)
+		}
+	}
+	return goatTxs, nil
+}
+
+// goatPayloadError converts the goat tx failure in the payload building to the
+// engine API error with the structured data, the other errors are returned as
+// the invalid payload attributes
+func goatPayloadError(err error, goatTxs types.Transactions) *engine.EngineAPIError {
+	var goatErr *core.GoatTxError
+	if !errors.As(err, &goatErr) || goatErr.Index >= len(goatTxs) {
+		return engine.InvalidPayloadAttributes.With(err)
+	}
+	var apiErr *engine.EngineAPIError
+	switch goatErr.Kind {
+	case core.ErrGoatTxReverted:
+		apiErr = engine.GoatTxReverted
+	case core.ErrGoatDepositReturn:
+		apiErr = engine.InvalidGoatDepositReturn
+	case core.ErrGoatDepositTax:
+		apiErr = engine.GoatDepositTaxTooLarge
+	case core.ErrGoatMintAmount:
+		apiErr = engine.InvalidGoatMintAmount
+	default:
+		return engine.InvalidPayloadAttributes.With(err)
+	}
+	return apiErr.WithData(err, &engine.GoatTxErrorData{
+		Error:        err.Error(),
+		Index:        hexutil.Uint64(goatErr.Index),
+		Hash:         goatTxs[goatErr.Index].Hash(),
+		RevertReason: goatErr.RevertReason(),
+		RevertData:   goatErr.Revert,
+	})
+}
+
+// SimulateGoatPayloadV1 builds the payload with the given goat txs and the pending
+// txs on top of the parent, and returns the goat requests and the goat tx results
+// it would produce. The payload is built on a throwaway state and never stored,
+// so it can't be retrieved by engine_getPayload. The failed goat txs are reported
+// in the results and excluded from the payload, including its goat tx count and
+// root in the extra data.
+func (api *ConsensusAPI) SimulateGoatPayloadV1(parentHash common.Hash, attrs *engine.PayloadAttributes) (*engine.GoatPayloadSimulation, error) {
+	log.Trace("Engine API request received", "method", "SimulateGoatPayload", "parent", parentHash)
+	if api.eth.BlockChain().Config().Goat == nil {
+		return nil, engine.UnsupportedFork.With(errors.New("not a goat chain"))
+	}
+	if attrs == nil {
+		return nil, engine.InvalidPayloadAttributes.With(errors.New("missing payload attributes"))
+	}
+	parent := api.eth.BlockChain().GetHeaderByHash(parentHash)
+	if parent == nil {
+		return nil, engine.InvalidForkChoiceState.With(fmt.Errorf("unknown parent %s", parentHash))
+	}
+	goatTxs, err := api.decodeGoatTxs(parent, attrs)
+	if err != nil {
+		return nil, err
+	}
+	args := &miner.BuildPayloadArgs{
+		Parent:       parentHash,
+		Timestamp:    attrs.Timestamp,
+		FeeRecipient: attrs.SuggestedFeeRecipient,
+		Random:       attrs.Random,
+		Withdrawals:  attrs.Withdrawals,
+		BeaconRoot:   attrs.BeaconRoot,
+
+		GoatTxs: goatTxs,
+	}
+	if attrs.ConsensusBlockHash != nil {
+		args.ConsensusBlockHash = *attrs.ConsensusBlockHash
+	}
+	sim, err := api.eth.Miner().SimulateGoatPayload(args)
+	if err != nil {
+		return nil, engine.InvalidPayloadAttributes.With(err)
+	}
+
+	res := &engine.GoatPayloadSimulation{
+		ExecutionPayload: engine.BlockToExecutableData(sim.Block, sim.Fees, nil).ExecutionPayload,
+		GasRevenue:       (*hexutil.Big)(sim.GasRevenue),
+		FoundationTax:    (*hexutil.Big)(sim.FoundationTax),
+		GoatTxs:          make([]*engine.GoatTxResult, 0, len(sim.GoatTxs)),
+	}
+	for _, r := range sim.GoatTxs {
+		txr := &engine.GoatTxResult{Index: hexutil.Uint64(r.Index), Hash: r.Hash, Status: hexutil.Uint64(types.ReceiptStatusSuccessful)}
+		if r.Err != nil {
+			txr.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			txr.Error = r.Err.Error()
+		}
+		var goatErr *core.GoatTxError
+		if errors.As(r.Err, &goatErr) {
+			txr.RevertReason, txr.RevertData = goatErr.RevertReason(), goatErr.Revert
+		}
+		res.GoatTxs = append(res.GoatTxs, txr)
+	}
+	return res, nil
+}
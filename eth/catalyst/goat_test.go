@@ -0,0 +1,211 @@
+package catalyst
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+var (
+	// the bridge stub returns zero tax for every deposit
+	goatTaxFreeBridge = common.FromHex("0x60206000f3")
+	// the bridge stub reverts with Error("no") for every deposit
+	goatRevertedBridge = common.FromHex("0x6064600c60003960646000fd" +
+		"08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"6e6f000000000000000000000000000000000000000000000000000000000000")
+)
+
+func goatDeposit(nonce uint64) hexutil.Bytes {
+	inner := &goattypes.DepositTx{Txid: common.Hash{byte(nonce + 1)}, Target: common.Address{0x1}, Amount: big.NewInt(1e18)}
+	enc, _ := types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, nonce, inner)).MarshalBinary()
+	return enc
+}
+
+func startGoatEthService(t *testing.T, bridge []byte) (*node.Node, *eth.Ethereum) {
+	t.Helper()
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{Bridge: &core.GoatPredeploy{Code: bridge}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return startEthService(t, genesis, nil)
+}
+
+func TestSimulateGoatPayload(t *testing.T) {
+
+	tests := []struct {
+		code   []byte
+		status []uint64
+		reason string
+	}{
+		{goatTaxFreeBridge, []uint64{types.ReceiptStatusSuccessful, types.ReceiptStatusSuccessful}, ""},
+		// the second deposit is failed with the nonce since the first one is reverted
+		{goatRevertedBridge, []uint64{types.ReceiptStatusFailed, types.ReceiptStatusFailed}, "no"},
+	}
+	for i, test := range tests {
+		n, ethservice := startGoatEthService(t, test.code)
+		api := NewConsensusAPI(ethservice)
+
+		parent := ethservice.BlockChain().CurrentBlock()
+		attrs := &engine.PayloadAttributes{
+			Timestamp:   parent.Time + 1,
+			Withdrawals: []*types.Withdrawal{},
+			BeaconRoot:  &common.Hash{},
+			GoatTxs:     []hexutil.Bytes{goatDeposit(0), goatDeposit(1)},
+		}
+		res, err := api.SimulateGoatPayloadV1(parent.Hash(), attrs)
+		if err != nil {
+			n.Close()
+			t.Fatalf("test %d: failed to simulate: %v", i, err)
+		}
+		if len(res.GoatTxs) != len(test.status) {
+			t.Fatalf("test %d: goat tx results mismatch: %d", i, len(res.GoatTxs))
+		}
+		for j, r := range res.GoatTxs {
+			if uint64(r.Status) != test.status[j] {
+				t.Errorf("test %d: goat tx %d status mismatch: have %d want %d (%s)", i, j, r.Status, test.status[j], r.Error)
+			}
+		}
+		if reason := res.GoatTxs[0].RevertReason; reason != test.reason {
+			t.Errorf("test %d: revert reason mismatch: have %q want %q", i, reason, test.reason)
+		}
+		if len(res.ExecutionPayload.GasRevenues) != 1 || res.GasRevenue.ToInt().Sign() != 0 {
+			t.Errorf("test %d: gas revenue mismatch", i)
+		}
+		// the failed goat txs are left out of the body and the header extra
+		block, err := engine.ExecutableDataToBlock(*res.ExecutionPayload, nil, attrs.BeaconRoot, ethservice.BlockChain().Config())
+		if err != nil {
+			t.Fatalf("test %d: invalid simulated payload: %v", i, err)
+		}
+		if err := core.ValidateGoatBody(ethservice.BlockChain().Config().Goat, block); err != nil {
+			t.Errorf("test %d: invalid simulated goat body: %v", i, err)
+		}
+		if api.localBlocks.payloads[0] != nil || ethservice.BlockChain().CurrentBlock().Hash() != parent.Hash() {
+			t.Errorf("test %d: the simulated payload is stored", i)
+		}
+		n.Close()
+	}
+}
+
+func TestGoatPayloadError(t *testing.T) {
+	n, ethservice := startGoatEthService(t, goatRevertedBridge)
+	defer n.Close()
+	api := NewConsensusAPI(ethservice)
+
+	parent := ethservice.BlockChain().CurrentBlock()
+	attrs := &engine.PayloadAttributes{
+		Timestamp:   parent.Time + 1,
+		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &common.Hash{},
+		GoatTxs:     []hexutil.Bytes{goatDeposit(0)},
+	}
+	_, err := api.ForkchoiceUpdatedV3(engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash()}, attrs)
+	var apiErr *engine.EngineAPIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != engine.GoatTxReverted.ErrorCode() {
+		t.Fatalf("expect goat tx reverted error, got %v", err)
+	}
+	data, ok := apiErr.ErrorData().(*engine.GoatTxErrorData)
+	if !ok {
+		t.Fatalf("unexpected error data %T", apiErr.ErrorData())
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(attrs.GoatTxs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if data.Index != 0 || data.Hash != tx.Hash() || data.RevertReason != "no" || len(data.RevertData) != 100 {
+		t.Errorf("error data mismatch: %+v", data)
+	}
+}
+
+func TestGoatPayloadRequestList(t *testing.T) {
+	n, ethservice := startGoatEthService(t, goatTaxFreeBridge)
+	defer n.Close()
+	api := NewConsensusAPI(ethservice)
+
+	parent := ethservice.BlockChain().CurrentBlock()
+	attrs := &engine.PayloadAttributes{
+		Timestamp:   parent.Time + 1,
+		Withdrawals: []*types.Withdrawal{},
+		BeaconRoot:  &common.Hash{},
+		GoatTxs:     []hexutil.Bytes{goatDeposit(0)},
+	}
+	resp, err := api.ForkchoiceUpdatedV3(engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash()}, attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := api.GetPayloadV3(*resp.PayloadID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legacy.ExecutionPayload.Requests != nil || len(legacy.ExecutionPayload.GasRevenues) != 1 {
+		t.Fatalf("unexpected V3 payload requests")
+	}
+	list, err := api.GetPayloadV5(*resp.PayloadID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := list.ExecutionPayload
+	if len(payload.Requests) != 1 || engine.HasLegacyRequests(payload) {
+		t.Fatalf("unexpected V5 payload requests: %d", len(payload.Requests))
+	}
+	if payload.Requests[0][0] != types.GoatGasRevenueRequestType {
+		t.Fatalf("unexpected request type %#x", payload.Requests[0][0])
+	}
+	enc, err := json.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(enc, []byte("gasRevenueRequests")) || bytes.Contains(enc, []byte("depositRequests")) {
+		t.Fatalf("V5 payload with the per type request lists: %s", enc)
+	}
+	var dec engine.RequestListPayloadEnvelope
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dec.ExecutionPayload.Requests, payload.Requests) || engine.HasLegacyRequests(dec.ExecutionPayload) {
+		t.Fatalf("V5 payload requests mismatch after the JSON round trip")
+	}
+	// the payload without requests keeps the empty request list
+	empty := *list.ExecutionPayloadEnvelope
+	empty.ExecutionPayload = &engine.ExecutableData{}
+	if enc, _ = json.Marshal(engine.RequestListEnvelope(&empty)); !bytes.Contains(enc, []byte(`"requests":[]`)) {
+		t.Fatalf("V5 payload without the empty request list: %s", enc)
+	}
+
+	if _, err := api.NewPayloadV5(*legacy.ExecutionPayload, []common.Hash{}, &common.Hash{}); err == nil {
+		t.Fatal("V5 payload with the per type request lists is accepted")
+	}
+	status, err := api.NewPayloadV5(*payload, []common.Hash{}, &common.Hash{})
+	if err != nil || status.Status != engine.VALID {
+		t.Fatalf("failed to import V5 payload: %v %v", status.Status, err)
+	}
+
+	bodies := api.GetPayloadBodiesByHashV3([]common.Hash{payload.BlockHash})
+	if bodies[0] == nil || len(bodies[0].Requests) != 1 || bodies[0].GasRevenues != nil {
+		t.Fatalf("unexpected V3 body requests")
+	}
+	if enc, _ = json.Marshal(bodies[0]); bytes.Contains(enc, []byte("gasRevenueRequests")) || !bytes.Contains(enc, []byte(`"requests":[`)) {
+		t.Fatalf("unexpected V3 body encoding: %s", enc)
+	}
+	bodies = api.GetPayloadBodiesByHashV2([]common.Hash{payload.BlockHash})
+	if bodies[0].Requests != nil || len(bodies[0].GasRevenues) != 1 || len(bodies[0].DeprecatedGasRevenues) != 1 {
+		t.Fatalf("unexpected V2 body requests")
+	}
+	if enc, _ = json.Marshal(bodies[0]); !bytes.Contains(enc, []byte(`"gasRevenueRequests":[`)) || bytes.Contains(enc, []byte(`"requests"`)) {
+		t.Fatalf("unexpected V2 body encoding: %s", enc)
+	}
+}
@@ -27,8 +27,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/event"
@@ -40,6 +42,8 @@ import (
 
 const devEpochLength = 32
 
+var errNotGoatChain = errors.New("not a goat chain")
+
 // withdrawalQueue implements a FIFO queue which holds withdrawals that are
 // pending inclusion.
 type withdrawalQueue struct {
@@ -80,6 +84,63 @@ func (w *withdrawalQueue) subscribe(ch chan<- newWithdrawalsEvent) event.Subscri
 	return w.subs.Track(sub)
 }
 
+// goatTxQueue implements a FIFO queue which holds goat txs that are pending
+// inclusion.
+type goatTxQueue struct {
+	pending types.Transactions
+	mu      sync.Mutex
+	feed    event.Feed
+	subs    event.SubscriptionScope
+}
+
+type newGoatTxsEvent struct{ Txs types.Transactions }
+
+// add queues a goat tx for future inclusion.
+func (q *goatTxQueue) add(tx *types.Transaction) {
+	q.mu.Lock()
+	q.pending = append(q.pending, tx)
+	q.mu.Unlock()
+
+	q.feed.Send(newGoatTxsEvent{types.Transactions{tx}})
+}
+
+// addWithNonce queues the goat tx created with the next nonce of the sender,
+// which is counted from the given state nonce and the pending txs.
+func (q *goatTxQueue) addWithNonce(sender common.Address, stateNonce uint64, create func(nonce uint64) *types.Transaction) *types.Transaction {
+	q.mu.Lock()
+	nonce := stateNonce
+	for _, tx := range q.pending {
+		if tx.GoatTx().Sender() == sender && tx.Nonce() >= nonce {
+			nonce = tx.Nonce() + 1
+		}
+	}
+	tx := create(nonce)
+	q.pending = append(q.pending, tx)
+	q.mu.Unlock()
+
+	q.feed.Send(newGoatTxsEvent{types.Transactions{tx}})
+	return tx
+}
+
+// pop dequeues the specified number of goat txs from the queue.
+func (q *goatTxQueue) pop(count int) types.Transactions {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	count = min(count, len(q.pending))
+	popped := q.pending[0:count]
+	q.pending = q.pending[count:]
+
+	return popped
+}
+
+// subscribe allows a listener to be updated when new goat txs are added to
+// the queue.
+func (q *goatTxQueue) subscribe(ch chan<- newGoatTxsEvent) event.Subscription {
+	sub := q.feed.Subscribe(ch)
+	return q.subs.Track(sub)
+}
+
 // SimulatedBeacon drives an Ethereum instance as if it were a real beacon
 // client. It can run in period mode where it mines a new block every period
 // (seconds) or on every transaction via Commit, Fork and AdjustTime.
@@ -88,6 +149,7 @@ type SimulatedBeacon struct {
 	eth         *eth.Ethereum
 	period      uint64
 	withdrawals withdrawalQueue
+	goatTxs     goatTxQueue
 
 	feeRecipient     common.Address
 	feeRecipientLock sync.Mutex // lock gates concurrent access to the feeRecipient
@@ -173,6 +235,18 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 		return fmt.Errorf("failed to sync txpool: %w", err)
 	}
 
+	var goatTxs []hexutil.Bytes
+	if goat := c.eth.BlockChain().Config().Goat; goat != nil {
+		goatTxs = make([]hexutil.Bytes, 0)
+		for _, tx := range c.goatTxs.pop(int(goat.GoatTxLimitAt(timestamp))) {
+			enc, err := tx.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			goatTxs = append(goatTxs, enc)
+		}
+	}
+
 	var random [32]byte
 	rand.Read(random[:])
 	fcResponse, err := c.engineAPI.forkchoiceUpdated(c.curForkchoiceState, &engine.PayloadAttributes{
@@ -181,6 +255,7 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 		Withdrawals:           withdrawals,
 		Random:                random,
 		BeaconRoot:            &common.Hash{},
+		GoatTxs:               goatTxs,
 	}, engine.PayloadV3)
 	if err != nil {
 		return err
@@ -284,6 +359,43 @@ func (c *SimulatedBeacon) Commit() common.Hash {
 	return c.eth.BlockChain().CurrentBlock().Hash()
 }
 
+// AddGoatTx queues a goat tx for the inclusion in the next sealed block.
+func (c *SimulatedBeacon) AddGoatTx(tx *types.Transaction) error {
+	if c.eth.BlockChain().Config().Goat == nil {
+		return errNotGoatChain
+	}
+	if !tx.IsGoatTx() {
+		return errors.New("not a goat tx")
+	}
+	c.goatTxs.add(tx)
+	return nil
+}
+
+// Deposit queues a bridge deposit goat tx minting the amount to the target, the
+// bitcoin txid is random and the nonce follows the relayer executor nonce.
+func (c *SimulatedBeacon) Deposit(target common.Address, amount *big.Int) (*types.Transaction, error) {
+	config := c.eth.BlockChain().Config().Goat
+	if config == nil {
+		return nil, errNotGoatChain
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, errors.New("invalid deposit amount")
+	}
+	statedb, err := c.eth.BlockChain().State()
+	if err != nil {
+		return nil, err
+	}
+	var txid common.Hash
+	rand.Read(txid[:])
+
+	deposit := &goattypes.DepositTx{Txid: txid, Target: target, Amount: new(big.Int).Set(amount)}
+	sender := deposit.Sender()
+	tx := c.goatTxs.addWithNonce(sender, statedb.GetNonce(config.SystemAddress(sender)), func(nonce uint64) *types.Transaction {
+		return types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, nonce, deposit))
+	})
+	return tx, nil
+}
+
 // Rollback un-sends previously added transactions.
 func (c *SimulatedBeacon) Rollback() {
 	// Flush all transactions from the transaction pools
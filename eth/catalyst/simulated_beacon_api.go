@@ -18,8 +18,10 @@ package catalyst
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -50,10 +52,13 @@ func (a *simulatedBeaconAPI) loop() {
 		newWxs    = make(chan newWithdrawalsEvent)
 		newTxsSub = a.sim.eth.TxPool().SubscribeTransactions(newTxs, true)
 		newWxsSub = a.sim.withdrawals.subscribe(newWxs)
+		newGtxs   = make(chan newGoatTxsEvent)
+		newGtxSub = a.sim.goatTxs.subscribe(newGtxs)
 		doCommit  = make(chan struct{}, 1)
 	)
 	defer newTxsSub.Unsubscribe()
 	defer newWxsSub.Unsubscribe()
+	defer newGtxSub.Unsubscribe()
 
 	// A background thread which signals to the simulator when to commit
 	// based on messages over doCommit.
@@ -85,6 +90,11 @@ func (a *simulatedBeaconAPI) loop() {
 			case doCommit <- struct{}{}:
 			default:
 			}
+		case <-newGtxs:
+			select {
+			case doCommit <- struct{}{}:
+			default:
+			}
 		case <-newTxs:
 			select {
 			case doCommit <- struct{}{}:
@@ -99,6 +109,27 @@ func (a *simulatedBeaconAPI) AddWithdrawal(ctx context.Context, withdrawal *type
 	return a.sim.withdrawals.add(withdrawal)
 }
 
+// AddGoatTx adds a binary encoded goat tx to the pending queue.
+func (a *simulatedBeaconAPI) AddGoatTx(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := a.sim.AddGoatTx(tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// Deposit adds a bridge deposit of the amount to the target to the pending queue.
+func (a *simulatedBeaconAPI) Deposit(ctx context.Context, target common.Address, amount *hexutil.Big) (common.Hash, error) {
+	tx, err := a.sim.Deposit(target, (*big.Int)(amount))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
 // SetFeeRecipient sets the fee recipient for block building purposes.
 func (a *simulatedBeaconAPI) SetFeeRecipient(ctx context.Context, feeRecipient common.Address) {
 	a.sim.setFeeRecipient(feeRecipient)
@@ -0,0 +1,401 @@
+package filters
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// chainHeadEventChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadEventChanSize = 10
+	// chainSideEventChanSize is the size of channel listening to ChainSideEvent.
+	chainSideEventChanSize = 10
+
+	// goatBridgeCursorLength is the length of the encoded bridge event cursor
+	goatBridgeCursorLength = 8 + common.HashLength + 8
+)
+
+var errNotGoatChain = errors.New("not a goat chain")
+
+// GoatBackend is the backend of the goat filter API, it needs the chain head and
+// side events to follow the canonical chain.
+type GoatBackend interface {
+	Backend
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription
+}
+
+// GoatFilterAPI offers the goat subscriptions of the bridge events for the relayers.
+type GoatFilterAPI struct {
+	backend GoatBackend
+}
+
+// NewGoatFilterAPI returns a new GoatFilterAPI instance.
+func NewGoatFilterAPI(backend GoatBackend) *GoatFilterAPI {
+	return &GoatFilterAPI{backend: backend}
+}
+
+// GoatBridgeCursor is the position in the bridge event feed, it's the block and the
+// number of the bridge events of the block which have been delivered. It's encoded
+// as an opaque hex string.
+type GoatBridgeCursor struct {
+	Number uint64
+	Hash   common.Hash
+	Index  uint64
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c GoatBridgeCursor) MarshalText() ([]byte, error) {
+	enc := make([]byte, goatBridgeCursorLength)
+	binary.BigEndian.PutUint64(enc, c.Number)
+	copy(enc[8:], c.Hash[:])
+	binary.BigEndian.PutUint64(enc[8+common.HashLength:], c.Index)
+	return hexutil.Bytes(enc).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *GoatBridgeCursor) UnmarshalText(input []byte) error {
+	var enc hexutil.Bytes
+	if err := enc.UnmarshalText(input); err != nil {
+		return err
+	}
+	if len(enc) != goatBridgeCursorLength {
+		return fmt.Errorf("invalid bridge event cursor length %d", len(enc))
+	}
+	c.Number = binary.BigEndian.Uint64(enc)
+	c.Hash = common.BytesToHash(enc[8 : 8+common.HashLength])
+	c.Index = binary.BigEndian.Uint64(enc[8+common.HashLength:])
+	return nil
+}
+
+// GoatBridgeCriteria is the start of the bridge event subscription, at most one of
+// the fields can be set. It streams the new events only if none is set.
+type GoatBridgeCriteria struct {
+	// FromBlock replays the events from the block
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	// Cursor resumes the subscription after the event of the cursor, the events
+	// which are no longer canonical since then are retracted first.
+	Cursor *GoatBridgeCursor `json:"cursor"`
+}
+
+// GoatBridgeEvent is a notification of the bridge event subscription. If the event
+// is removed by a chain reorg, it's sent again with the removed flag set, and the
+// retractions are sent in the reverse order of the events.
+type GoatBridgeEvent struct {
+	Id      hexutil.Uint64             `json:"id"`
+	Kind    string                     `json:"kind"`
+	Event   *types.GoatWithdrawalEvent `json:"event"`
+	Removed bool                       `json:"removed"`
+	// Cursor resumes the subscription after this notification
+	Cursor GoatBridgeCursor `json:"cursor"`
+	// Error is set on the last notification if the delivery fails, it carries no event
+	// and the cursor of the last delivered event to resume by a new subscription.
+	Error string `json:"error,omitempty"`
+}
+
+// BridgeEvents creates a subscription of the bridge withdrawal events, which are the
+// Withdraw, RBF and Cancel1 events of the bridge contract and the cancel2 and paid goat
+// txs. It replays the canonical events from the given block or cursor, and then follows
+// the canonical chain, the events of the reorged blocks are retracted explicitly. If the
+// delivery fails, the last notification carries the error.
+func (api *GoatFilterAPI) BridgeEvents(ctx context.Context, crit *GoatBridgeCriteria) (*rpc.Subscription, error) {
+	config := api.backend.ChainConfig()
+	if config.Goat == nil {
+		return &rpc.Subscription{}, errNotGoatChain
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	feed := &goatBridgeFeed{backend: api.backend, config: config}
+	start, err := feed.start(ctx, crit)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+
+	var (
+		rpcSub     = notifier.CreateSubscription()
+		heads      = make(chan core.ChainHeadEvent, chainHeadEventChanSize)
+		sides      = make(chan core.ChainSideEvent, chainSideEventChanSize)
+		headSub    = api.backend.SubscribeChainHeadEvent(heads)
+		sideSub    = api.backend.SubscribeChainSideEvent(sides)
+		wake       = make(chan struct{}, 1)
+		quit       = make(chan struct{})
+		done       = make(chan struct{})
+		notifyWake = func() {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		}
+	)
+	feed.quit = quit
+	feed.notify = func(ev *GoatBridgeEvent) error {
+		return notifier.Notify(rpcSub.ID, ev)
+	}
+
+	// The chain events only wake up the feed, which catches up with the current
+	// canonical chain by itself. So the replay doesn't block the chain insertion.
+	go func() {
+		defer close(quit)
+		defer headSub.Unsubscribe()
+		defer sideSub.Unsubscribe()
+		for {
+			select {
+			case <-heads:
+				notifyWake()
+			case <-sides:
+				notifyWake()
+			case <-rpcSub.Err():
+				return
+			case <-headSub.Err():
+				return
+			case <-sideSub.Err():
+				return
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(done)
+		feed.run(context.Background(), start, wake)
+	}()
+
+	return rpcSub, nil
+}
+
+// goatBridgeFeed delivers the bridge events of the canonical chain to a subscriber
+type goatBridgeFeed struct {
+	backend GoatBackend
+	config  *params.ChainConfig
+	notify  func(ev *GoatBridgeEvent) error
+	quit    <-chan struct{}
+
+	// tip is the position of the last delivered event, the index is math.MaxUint64
+	// if all the events of the block have been delivered.
+	tip GoatBridgeCursor
+	// last is the cursor of the last delivered notification
+	last GoatBridgeCursor
+}
+
+// errGoatBridgeFeedClosed is returned if the subscription is closed during the delivery
+var errGoatBridgeFeedClosed = errors.New("subscription closed")
+
+// start returns the position to resume the feed from
+func (f *goatBridgeFeed) start(ctx context.Context, crit *GoatBridgeCriteria) (GoatBridgeCursor, error) {
+	if crit != nil && crit.Cursor != nil {
+		if crit.FromBlock != nil {
+			return GoatBridgeCursor{}, errors.New("fromBlock and cursor can't be both set")
+		}
+		return *crit.Cursor, nil
+	}
+
+	var number uint64
+	if crit == nil || crit.FromBlock == nil {
+		// only stream the new events
+		number = f.backend.CurrentHeader().Number.Uint64() + 1
+	} else {
+		switch *crit.FromBlock {
+		case rpc.PendingBlockNumber:
+			return GoatBridgeCursor{}, errors.New("pending block is not supported")
+		case rpc.EarliestBlockNumber:
+			number = 0
+		case rpc.LatestBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+			header, err := f.backend.HeaderByNumber(ctx, *crit.FromBlock)
+			if err != nil {
+				return GoatBridgeCursor{}, err
+			}
+			if header == nil {
+				return GoatBridgeCursor{}, fmt.Errorf("%s block not found", crit.FromBlock)
+			}
+			number = header.Number.Uint64()
+		default:
+			number = uint64(*crit.FromBlock)
+		}
+	}
+	if number == 0 {
+		genesis, err := f.backend.HeaderByNumber(ctx, 0)
+		if err != nil || genesis == nil {
+			return GoatBridgeCursor{}, errors.New("genesis block not found")
+		}
+		return GoatBridgeCursor{Number: 0, Hash: genesis.Hash(), Index: 0}, nil
+	}
+	// starts after all the events of the parent block
+	parent, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number-1))
+	if err != nil {
+		return GoatBridgeCursor{}, err
+	}
+	if parent == nil {
+		return GoatBridgeCursor{}, fmt.Errorf("block %d not found", number-1)
+	}
+	return GoatBridgeCursor{Number: number - 1, Hash: parent.Hash(), Index: math.MaxUint64}, nil
+}
+
+// run delivers the events from the start position and then on every wake up, until
+// the subscription is closed. If the delivery fails, the subscriber is notified of the
+// error and no more events are delivered.
+func (f *goatBridgeFeed) run(ctx context.Context, start GoatBridgeCursor, wake <-chan struct{}) {
+	err := f.resume(ctx, start)
+	for err == nil {
+		if err = f.update(ctx); err != nil {
+			break
+		}
+		select {
+		case <-wake:
+		case <-f.quit:
+			return
+		}
+	}
+	if errors.Is(err, errGoatBridgeFeedClosed) {
+		return
+	}
+	log.Warn("Failed to deliver goat bridge events", "err", err)
+	if err := f.notify(&GoatBridgeEvent{Cursor: f.last, Error: err.Error()}); err != nil {
+		log.Debug("Failed to notify goat bridge event error", "err", err)
+	}
+}
+
+// resume retracts the delivered events which are no longer canonical since the cursor,
+// and delivers the rest events of the cursor block.
+func (f *goatBridgeFeed) resume(ctx context.Context, cursor GoatBridgeCursor) error {
+	f.tip, f.last = cursor, cursor
+	if err := f.retract(ctx); err != nil {
+		return err
+	}
+	events, _, err := f.blockEvents(ctx, f.tip.Hash, f.tip.Number)
+	if err != nil {
+		return err
+	}
+	for i := f.tip.Index; i < uint64(len(events)); i++ {
+		if err := f.send(events[i], false, GoatBridgeCursor{f.tip.Number, f.tip.Hash, i + 1}); err != nil {
+			return err
+		}
+	}
+	f.tip.Index = math.MaxUint64
+	return nil
+}
+
+// update retracts the delivered events which are no longer canonical, and delivers
+// the events of the new canonical blocks.
+func (f *goatBridgeFeed) update(ctx context.Context) error {
+	if err := f.retract(ctx); err != nil {
+		return err
+	}
+	head := f.backend.CurrentHeader().Number.Uint64()
+	for number := f.tip.Number + 1; number <= head; number++ {
+		if f.closed() {
+			return errGoatBridgeFeedClosed
+		}
+		header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return err
+		}
+		// the canonical chain is being reorged, wait for the next chain event
+		if header == nil || header.ParentHash != f.tip.Hash {
+			return nil
+		}
+		hash := header.Hash()
+		events, _, err := f.blockEvents(ctx, hash, number)
+		if err != nil {
+			return err
+		}
+		for i, ev := range events {
+			if err := f.send(ev, false, GoatBridgeCursor{number, hash, uint64(i) + 1}); err != nil {
+				return err
+			}
+		}
+		f.tip = GoatBridgeCursor{Number: number, Hash: hash, Index: math.MaxUint64}
+	}
+	return nil
+}
+
+// retract sends the retractions of the delivered events in the reverse order, until
+// the tip block is canonical.
+func (f *goatBridgeFeed) retract(ctx context.Context) error {
+	for {
+		canonical, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(f.tip.Number))
+		if err != nil {
+			return err
+		}
+		if canonical != nil && canonical.Hash() == f.tip.Hash {
+			return nil
+		}
+		if f.tip.Number == 0 {
+			return fmt.Errorf("unknown genesis block %x", f.tip.Hash)
+		}
+		events, header, err := f.blockEvents(ctx, f.tip.Hash, f.tip.Number)
+		if err != nil {
+			return err
+		}
+		for i := min(f.tip.Index, uint64(len(events))); i > 0; i-- {
+			if err := f.send(events[i-1], true, GoatBridgeCursor{f.tip.Number, f.tip.Hash, i - 1}); err != nil {
+				return err
+			}
+		}
+		f.tip = GoatBridgeCursor{Number: f.tip.Number - 1, Hash: header.ParentHash, Index: math.MaxUint64}
+	}
+}
+
+// blockEvents returns the bridge events of the block and its header
+func (f *goatBridgeFeed) blockEvents(ctx context.Context, hash common.Hash, number uint64) ([]*types.GoatWithdrawalEvent, *types.Header, error) {
+	header, err := f.backend.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header == nil || header.Number.Uint64() != number {
+		return nil, nil, fmt.Errorf("block %d %x not found", number, hash)
+	}
+	body, err := f.backend.GetBody(ctx, hash, rpc.BlockNumber(number))
+	if err != nil {
+		return nil, nil, err
+	}
+	receipts, err := f.backend.GetReceipts(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(receipts) != len(body.Transactions) {
+		return nil, nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(body.Transactions), len(receipts))
+	}
+	return core.GoatBridgeEvents(f.config.Goat, hash, number, body.Transactions, receipts), header, nil
+}
+
+func (f *goatBridgeFeed) closed() bool {
+	select {
+	case <-f.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *goatBridgeFeed) send(ev *types.GoatWithdrawalEvent, removed bool, cursor GoatBridgeCursor) error {
+	if f.closed() {
+		return errGoatBridgeFeedClosed
+	}
+	err := f.notify(&GoatBridgeEvent{
+		Id:      hexutil.Uint64(ev.Id()),
+		Kind:    ev.Kind(),
+		Event:   ev,
+		Removed: removed,
+		Cursor:  cursor,
+	})
+	if err == nil {
+		f.last = cursor
+	}
+	return err
+}
@@ -0,0 +1,238 @@
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+type goatTestBackend struct {
+	*testBackend
+	config        *params.ChainConfig
+	chainHeadFeed event.Feed
+	chainSideFeed event.Feed
+}
+
+func (b *goatTestBackend) ChainConfig() *params.ChainConfig {
+	return b.config
+}
+
+func (b *goatTestBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return b.chainHeadFeed.Subscribe(ch)
+}
+
+func (b *goatTestBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription {
+	return b.chainSideFeed.Subscribe(ch)
+}
+
+// writeGoatBridgeBlock writes a child block of the parent whose txs emit the bridge logs
+func writeGoatBridgeBlock(db ethdb.Database, parent *types.Header, seed byte, logs ...*types.Log) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		BaseFee:    big.NewInt(1),
+		Extra:      []byte{seed},
+	}
+	var (
+		txs      types.Transactions
+		receipts types.Receipts
+	)
+	for i, l := range logs {
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &goattypes.BridgeContract, Gas: 21000, GasPrice: big.NewInt(1)})
+		txs = append(txs, tx)
+		receipts = append(receipts, &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), Logs: []*types.Log{l}})
+	}
+	block := types.NewBlock(header, &types.Body{Transactions: txs}, receipts, trie.NewStackTrie(nil))
+	rawdb.WriteBlock(db, block)
+	rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts)
+	return block.Header()
+}
+
+// setGoatCanonical marks the headers canonical, the last one is the new head
+func setGoatCanonical(db ethdb.Database, chain ...*types.Header) {
+	for _, header := range chain {
+		rawdb.WriteCanonicalHash(db, header.Hash(), header.Number.Uint64())
+	}
+	head := chain[len(chain)-1]
+	for n := head.Number.Uint64() + 1; rawdb.ReadCanonicalHash(db, n) != (common.Hash{}); n++ {
+		rawdb.DeleteCanonicalHash(db, n)
+	}
+	rawdb.WriteHeadBlockHash(db, head.Hash())
+	rawdb.WriteHeadHeaderHash(db, head.Hash())
+}
+
+func goatRBFLog(id, maxTxPrice uint64) *types.Log {
+	return &types.Log{
+		Address: goattypes.BridgeContract,
+		Topics:  []common.Hash{types.GoatReplaceByFeeTopic, common.BigToHash(new(big.Int).SetUint64(id))},
+		Data:    common.BigToHash(new(big.Int).SetUint64(maxTxPrice)).Bytes(),
+	}
+}
+
+func goatCancel1Log(id uint64) *types.Log {
+	return &types.Log{
+		Address: goattypes.BridgeContract,
+		Topics:  []common.Hash{types.GoatCancel1Topic, common.BigToHash(new(big.Int).SetUint64(id))},
+	}
+}
+
+type goatBridgeResult struct {
+	id      uint64
+	kind    string
+	removed bool
+	cursor  GoatBridgeCursor
+}
+
+func TestGoatBridgeFeed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		config  = *params.TestChainConfig
+		backend = &goatTestBackend{testBackend: &testBackend{db: db}, config: &config}
+		genesis = &types.Header{Number: common.Big0, BaseFee: big.NewInt(1)}
+	)
+	config.Goat = &params.GoatConfig{}
+	rawdb.WriteBlock(db, types.NewBlockWithHeader(genesis))
+	rawdb.WriteReceipts(db, genesis.Hash(), 0, nil)
+	setGoatCanonical(db, genesis)
+
+	var (
+		b1 = writeGoatBridgeBlock(db, genesis, 1, goatRBFLog(1, 10))
+		b2 = writeGoatBridgeBlock(db, b1, 2, goatCancel1Log(2), goatRBFLog(3, 20))
+	)
+	setGoatCanonical(db, b1, b2)
+
+	var results []goatBridgeResult
+	newFeed := func() *goatBridgeFeed {
+		return &goatBridgeFeed{backend: backend, config: backend.config, notify: func(ev *GoatBridgeEvent) error {
+			results = append(results, goatBridgeResult{uint64(ev.Id), ev.Kind, ev.Removed, ev.Cursor})
+			return nil
+		}}
+	}
+	check := func(name string, want ...goatBridgeResult) {
+		t.Helper()
+		if !reflect.DeepEqual(results, want) {
+			t.Fatalf("%s: events mismatch\ngot  %v\nwant %v", name, results, want)
+		}
+		results = nil
+	}
+
+	// replay from the block 1
+	feed := newFeed()
+	one := rpc.BlockNumber(1)
+	start, err := feed.start(context.Background(), &GoatBridgeCriteria{FromBlock: &one})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := feed.resume(context.Background(), start); err != nil {
+		t.Fatal(err)
+	}
+	if err := feed.update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check("replay",
+		goatBridgeResult{1, "rbf", false, GoatBridgeCursor{1, b1.Hash(), 1}},
+		goatBridgeResult{2, "cancel1", false, GoatBridgeCursor{2, b2.Hash(), 1}},
+		goatBridgeResult{3, "rbf", false, GoatBridgeCursor{2, b2.Hash(), 2}},
+	)
+
+	// reorg the block 2, the events are retracted in the reverse order
+	var (
+		f2 = writeGoatBridgeBlock(db, b1, 3, goatCancel1Log(4))
+		f3 = writeGoatBridgeBlock(db, f2, 4)
+	)
+	setGoatCanonical(db, b1, f2, f3)
+	if err := feed.update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check("reorg",
+		goatBridgeResult{3, "rbf", true, GoatBridgeCursor{2, b2.Hash(), 1}},
+		goatBridgeResult{2, "cancel1", true, GoatBridgeCursor{2, b2.Hash(), 0}},
+		goatBridgeResult{4, "cancel1", false, GoatBridgeCursor{2, f2.Hash(), 1}},
+	)
+
+	// resume from a cursor of the reorged block
+	feed = newFeed()
+	if err := feed.resume(context.Background(), GoatBridgeCursor{2, b2.Hash(), 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := feed.update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check("resume reorged",
+		goatBridgeResult{2, "cancel1", true, GoatBridgeCursor{2, b2.Hash(), 0}},
+		goatBridgeResult{4, "cancel1", false, GoatBridgeCursor{2, f2.Hash(), 1}},
+	)
+
+	// resume from a canonical cursor
+	feed = newFeed()
+	if err := feed.resume(context.Background(), GoatBridgeCursor{1, b1.Hash(), 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := feed.update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check("resume canonical",
+		goatBridgeResult{1, "rbf", false, GoatBridgeCursor{1, b1.Hash(), 1}},
+		goatBridgeResult{4, "cancel1", false, GoatBridgeCursor{2, f2.Hash(), 1}},
+	)
+
+	// stream the new events only
+	feed = newFeed()
+	if start, err = feed.start(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := feed.resume(context.Background(), start); err != nil {
+		t.Fatal(err)
+	}
+	f4 := writeGoatBridgeBlock(db, f3, 5, goatRBFLog(4, 30))
+	setGoatCanonical(db, b1, f2, f3, f4)
+	if err := feed.update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	check("new", goatBridgeResult{4, "rbf", false, GoatBridgeCursor{4, f4.Hash(), 1}})
+
+	// the delivery failure is notified with the cursor of the last delivered event
+	var last *GoatBridgeEvent
+	feed = &goatBridgeFeed{backend: backend, config: backend.config, notify: func(ev *GoatBridgeEvent) error {
+		last = ev
+		return nil
+	}}
+	unknown := GoatBridgeCursor{1, common.Hash{0x1}, 0}
+	feed.run(context.Background(), unknown, nil)
+	if last == nil || last.Error == "" || last.Event != nil || last.Cursor != unknown {
+		t.Fatalf("unexpected failure notification: %+v", last)
+	}
+}
+
+func TestGoatBridgeCursorJSON(t *testing.T) {
+	cursor := GoatBridgeCursor{Number: 10, Hash: common.Hash{0x1}, Index: 2}
+	enc, err := json.Marshal(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec GoatBridgeCursor
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec != cursor {
+		t.Fatalf("cursor mismatch: got %v want %v", dec, cursor)
+	}
+	if err := json.Unmarshal([]byte(`"0x01"`), &dec); err == nil {
+		t.Fatal("expected error for the invalid cursor")
+	}
+}
@@ -19,6 +19,7 @@ package tracetest
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -34,9 +35,13 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
 
 	// Force-load live packages, to trigger registration
@@ -44,20 +49,28 @@ import (
 )
 
 type supplyInfoIssuance struct {
-	GenesisAlloc *hexutil.Big `json:"genesisAlloc,omitempty"`
-	Reward       *hexutil.Big `json:"reward,omitempty"`
-	Withdrawals  *hexutil.Big `json:"withdrawals,omitempty"`
+	GenesisAlloc    *hexutil.Big `json:"genesisAlloc,omitempty"`
+	Reward          *hexutil.Big `json:"reward,omitempty"`
+	Withdrawals     *hexutil.Big `json:"withdrawals,omitempty"`
+	BridgeDeposit   *hexutil.Big `json:"bridgeDeposit,omitempty"`
+	ValidatorReward *hexutil.Big `json:"validatorReward,omitempty"`
 }
 
 type supplyInfoBurn struct {
-	EIP1559 *hexutil.Big `json:"1559,omitempty"`
-	Blob    *hexutil.Big `json:"blob,omitempty"`
-	Misc    *hexutil.Big `json:"misc,omitempty"`
+	EIP1559          *hexutil.Big `json:"1559,omitempty"`
+	Blob             *hexutil.Big `json:"blob,omitempty"`
+	Misc             *hexutil.Big `json:"misc,omitempty"`
+	BridgeWithdrawal *hexutil.Big `json:"bridgeWithdrawal,omitempty"`
+}
+
+type supplyInfoRedistribution struct {
+	FoundationTax *hexutil.Big `json:"foundationTax,omitempty"`
 }
 
 type supplyInfo struct {
-	Issuance *supplyInfoIssuance `json:"issuance,omitempty"`
-	Burn     *supplyInfoBurn     `json:"burn,omitempty"`
+	Issuance       *supplyInfoIssuance       `json:"issuance,omitempty"`
+	Burn           *supplyInfoBurn           `json:"burn,omitempty"`
+	Redistribution *supplyInfoRedistribution `json:"redistribution,omitempty"`
 
 	// Block info
 	Number     uint64      `json:"blockNumber"`
@@ -543,6 +556,164 @@ func TestSupplySelfdestructItselfAndRevert(t *testing.T) {
 	compareAsJSON(t, expected, actual)
 }
 
+// TestSupplyGoat runs a goat block with a deposit, a reward, a bridge withdrawal
+// and the gas fees through the supply tracer.
+func TestSupplyGoat(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		tax    = big.NewInt(1e16)
+	)
+	// the bridge stub returns the tax for the deposits from the relayer executor,
+	// and emits the Withdraw event from the calldata for the other callers, the id
+	// is the first calldata word and the rest of the calldata is the event data
+	bridge := append([]byte{0x33, 0x73}, params.GoatRelayerExecutor[:]...)
+	bridge = append(bridge, 0x14, 0x60, 0x51, 0x57)
+	bridge = append(bridge, 0x60, 0x20, 0x36, 0x03, 0x60, 0x20, 0x60, 0x00, 0x37, 0x60, 0x00, 0x60, 0x00, 0x35, 0x7f)
+	bridge = append(bridge, types.GoatWithdrawalTopic[:]...)
+	bridge = append(bridge, 0x60, 0x20, 0x36, 0x03, 0x60, 0x00, 0xa3, 0x00)
+	bridge = append(bridge, 0x5b, 0x66)
+	bridge = append(bridge, common.LeftPadBytes(tax.Bytes(), 7)...)
+	bridge = append(bridge, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3)
+
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{
+		Faucet: &addr,
+		Bridge: &core.GoatPredeploy{Code: bridge},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	traceOutputPath := filepath.ToSlash(t.TempDir())
+	sim := simulated.NewBackend(nil, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = genesis
+		ethConf.VMTrace = "supply"
+		ethConf.VMTraceJsonConfig = fmt.Sprintf(`{"path":"%s"}`, traceOutputPath)
+	})
+	defer sim.Close()
+
+	var (
+		ctx     = context.Background()
+		client  = sim.Client()
+		deposit = big.NewInt(1e18)
+		reward  = big.NewInt(1e15)
+		// withdraw 1000 satoshis
+		data = make([]byte, 32+192)
+	)
+	data[31] = 1
+	new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e10)).FillBytes(data[32:64])
+	data[32+95] = 1
+	data[32+127] = 128
+	data[32+159] = 4
+	copy(data[32+160:], "bc1q")
+
+	if _, err := sim.Deposit(common.Address{0x1}, deposit); err != nil {
+		t.Fatal(err)
+	}
+	distribute := &goattypes.DistributeRewardTx{Id: 1, Recipient: common.Address{0x2}, Goat: new(big.Int), GasReward: reward}
+	if err := sim.AddGoatTx(types.NewTx(types.NewGoatTx(goattypes.LockingModule, goattypes.LockingDistributeRewardAction, 0, distribute))); err != nil {
+		t.Fatal(err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainid, err := client.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bridgeAddr := genesis.Config.Goat.SystemAddresses().Bridge
+	withdraw, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainid,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+		Gas:       100000,
+		To:        &bridgeAddr,
+		Data:      data,
+	}), types.LatestSignerForChainID(chainid), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(ctx, withdraw); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	block, err := client.BlockByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(block.Transactions()); n != 3 {
+		t.Fatalf("block txs mismatch: %d", n)
+	}
+	receipts := make(types.Receipts, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("tx %x failed", tx.Hash())
+		}
+		receipts = append(receipts, receipt)
+	}
+	// close the backend to flush the tracer output
+	sim.Close()
+
+	data, err = os.ReadFile(path.Join(traceOutputPath, "supply.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	var actual *supplyInfo
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// the genesis allocation of the dev faucet overflows hexutil.Big
+		var number struct {
+			Number uint64 `json:"blockNumber"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &number); err != nil {
+			t.Fatalf("failed to unmarshal result: %v", err)
+		}
+		if number.Number != block.NumberU64() {
+			continue
+		}
+		actual = new(supplyInfo)
+		if err := json.Unmarshal(scanner.Bytes(), actual); err != nil {
+			t.Fatalf("failed to unmarshal result: %v", err)
+		}
+	}
+	if actual == nil {
+		t.Fatalf("block %d is not traced", block.NumberU64())
+	}
+
+	var (
+		fees        = core.CalcGoatBlockFees(block.Header(), block.Transactions(), receipts).Total()
+		payments, _ = core.GoatFoundationSplit(genesis.Config.Goat, block.Time(), fees)
+		foundation  = new(big.Int)
+	)
+	for _, p := range payments {
+		if p.Recipient != nil {
+			foundation.Add(foundation, p.Amount)
+		}
+	}
+	expected := supplyInfo{
+		Issuance: &supplyInfoIssuance{
+			BridgeDeposit:   (*hexutil.Big)(deposit),
+			ValidatorReward: (*hexutil.Big)(reward),
+		},
+		Burn: &supplyInfoBurn{
+			EIP1559:          (*hexutil.Big)(new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))),
+			BridgeWithdrawal: (*hexutil.Big)(big.NewInt(1000 * 1e10)),
+		},
+		Redistribution: &supplyInfoRedistribution{
+			FoundationTax: (*hexutil.Big)(foundation),
+		},
+		Number:     block.NumberU64(),
+		Hash:       block.Hash(),
+		ParentHash: block.ParentHash(),
+	}
+	compareAsJSON(t, expected, actual)
+}
+
 func testSupplyTracer(t *testing.T, genesis *core.Genesis, gen func(*core.BlockGen)) ([]supplyInfo, *core.BlockChain, error) {
 	var (
 		engine = beacon.New(ethash.NewFaker())
@@ -14,23 +14,29 @@ var _ = (*supplyInfoIssuanceMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s supplyInfoIssuance) MarshalJSON() ([]byte, error) {
 	type supplyInfoIssuance struct {
-		GenesisAlloc *hexutil.Big `json:"genesisAlloc,omitempty"`
-		Reward       *hexutil.Big `json:"reward,omitempty"`
-		Withdrawals  *hexutil.Big `json:"withdrawals,omitempty"`
+		GenesisAlloc    *hexutil.Big `json:"genesisAlloc,omitempty"`
+		Reward          *hexutil.Big `json:"reward,omitempty"`
+		Withdrawals     *hexutil.Big `json:"withdrawals,omitempty"`
+		BridgeDeposit   *hexutil.Big `json:"bridgeDeposit,omitempty"`
+		ValidatorReward *hexutil.Big `json:"validatorReward,omitempty"`
 	}
 	var enc supplyInfoIssuance
 	enc.GenesisAlloc = (*hexutil.Big)(s.GenesisAlloc)
 	enc.Reward = (*hexutil.Big)(s.Reward)
 	enc.Withdrawals = (*hexutil.Big)(s.Withdrawals)
+	enc.BridgeDeposit = (*hexutil.Big)(s.BridgeDeposit)
+	enc.ValidatorReward = (*hexutil.Big)(s.ValidatorReward)
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (s *supplyInfoIssuance) UnmarshalJSON(input []byte) error {
 	type supplyInfoIssuance struct {
-		GenesisAlloc *hexutil.Big `json:"genesisAlloc,omitempty"`
-		Reward       *hexutil.Big `json:"reward,omitempty"`
-		Withdrawals  *hexutil.Big `json:"withdrawals,omitempty"`
+		GenesisAlloc    *hexutil.Big `json:"genesisAlloc,omitempty"`
+		Reward          *hexutil.Big `json:"reward,omitempty"`
+		Withdrawals     *hexutil.Big `json:"withdrawals,omitempty"`
+		BridgeDeposit   *hexutil.Big `json:"bridgeDeposit,omitempty"`
+		ValidatorReward *hexutil.Big `json:"validatorReward,omitempty"`
 	}
 	var dec supplyInfoIssuance
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -45,5 +51,11 @@ func (s *supplyInfoIssuance) UnmarshalJSON(input []byte) error {
 	if dec.Withdrawals != nil {
 		s.Withdrawals = (*big.Int)(dec.Withdrawals)
 	}
+	if dec.BridgeDeposit != nil {
+		s.BridgeDeposit = (*big.Int)(dec.BridgeDeposit)
+	}
+	if dec.ValidatorReward != nil {
+		s.ValidatorReward = (*big.Int)(dec.ValidatorReward)
+	}
 	return nil
 }
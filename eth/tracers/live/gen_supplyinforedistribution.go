@@ -0,0 +1,37 @@
+// Code generated by github.com/fjl/gencodec. DO NOT EDIT.
+
+package live
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var _ = (*supplyInfoRedistributionMarshaling)(nil)
+
+// MarshalJSON marshals as JSON.
+func (s supplyInfoRedistribution) MarshalJSON() ([]byte, error) {
+	type supplyInfoRedistribution struct {
+		FoundationTax *hexutil.Big `json:"foundationTax,omitempty"`
+	}
+	var enc supplyInfoRedistribution
+	enc.FoundationTax = (*hexutil.Big)(s.FoundationTax)
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON unmarshals from JSON.
+func (s *supplyInfoRedistribution) UnmarshalJSON(input []byte) error {
+	type supplyInfoRedistribution struct {
+		FoundationTax *hexutil.Big `json:"foundationTax,omitempty"`
+	}
+	var dec supplyInfoRedistribution
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	if dec.FoundationTax != nil {
+		s.FoundationTax = (*big.Int)(dec.FoundationTax)
+	}
+	return nil
+}
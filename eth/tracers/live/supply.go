@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -26,31 +27,52 @@ type supplyInfoIssuance struct {
 	GenesisAlloc *big.Int `json:"genesisAlloc,omitempty"`
 	Reward       *big.Int `json:"reward,omitempty"`
 	Withdrawals  *big.Int `json:"withdrawals,omitempty"`
+
+	// goat
+	BridgeDeposit   *big.Int `json:"bridgeDeposit,omitempty"`   // the deposits from the bridge including the tax
+	ValidatorReward *big.Int `json:"validatorReward,omitempty"` // the rewards distributed by the goat txs
 }
 
 //go:generate go run github.com/fjl/gencodec -type supplyInfoIssuance -field-override supplyInfoIssuanceMarshaling -out gen_supplyinfoissuance.go
 type supplyInfoIssuanceMarshaling struct {
-	GenesisAlloc *hexutil.Big
-	Reward       *hexutil.Big
-	Withdrawals  *hexutil.Big
+	GenesisAlloc    *hexutil.Big
+	Reward          *hexutil.Big
+	Withdrawals     *hexutil.Big
+	BridgeDeposit   *hexutil.Big
+	ValidatorReward *hexutil.Big
 }
 
 type supplyInfoBurn struct {
 	EIP1559 *big.Int `json:"1559,omitempty"`
 	Blob    *big.Int `json:"blob,omitempty"`
 	Misc    *big.Int `json:"misc,omitempty"`
+
+	// goat
+	BridgeWithdrawal *big.Int `json:"bridgeWithdrawal,omitempty"` // the withdrawals to the bitcoin network
 }
 
 //go:generate go run github.com/fjl/gencodec -type supplyInfoBurn -field-override supplyInfoBurnMarshaling -out gen_supplyinfoburn.go
 type supplyInfoBurnMarshaling struct {
-	EIP1559 *hexutil.Big
-	Blob    *hexutil.Big
-	Misc    *hexutil.Big
+	EIP1559          *hexutil.Big
+	Blob             *hexutil.Big
+	Misc             *hexutil.Big
+	BridgeWithdrawal *hexutil.Big
+}
+
+// supplyInfoRedistribution is the burnt fees minted back to the accounts
+type supplyInfoRedistribution struct {
+	FoundationTax *big.Int `json:"foundationTax,omitempty"` // the goat foundation shares of the gas fees paid to the recipients
+}
+
+//go:generate go run github.com/fjl/gencodec -type supplyInfoRedistribution -field-override supplyInfoRedistributionMarshaling -out gen_supplyinforedistribution.go
+type supplyInfoRedistributionMarshaling struct {
+	FoundationTax *hexutil.Big
 }
 
 type supplyInfo struct {
-	Issuance *supplyInfoIssuance `json:"issuance,omitempty"`
-	Burn     *supplyInfoBurn     `json:"burn,omitempty"`
+	Issuance       *supplyInfoIssuance       `json:"issuance,omitempty"`
+	Burn           *supplyInfoBurn           `json:"burn,omitempty"`
+	Redistribution *supplyInfoRedistribution `json:"redistribution,omitempty"`
 
 	// Block info
 	Number     uint64      `json:"blockNumber"`
@@ -67,6 +89,9 @@ type supply struct {
 	delta       supplyInfo
 	txCallstack []supplyTxCallstack // Callstack for current transaction
 	logger      *lumberjack.Logger
+
+	goat   bool // Flag whether the chain is a goat chain
+	goatTx bool // Flag whether the current transaction is a goat tx
 }
 
 type supplyTracerConfig struct {
@@ -98,28 +123,36 @@ func newSupply(cfg json.RawMessage) (*tracing.Hooks, error) {
 		logger: logger,
 	}
 	return &tracing.Hooks{
-		OnBlockStart:    t.OnBlockStart,
-		OnBlockEnd:      t.OnBlockEnd,
-		OnGenesisBlock:  t.OnGenesisBlock,
-		OnTxStart:       t.OnTxStart,
-		OnBalanceChange: t.OnBalanceChange,
-		OnEnter:         t.OnEnter,
-		OnExit:          t.OnExit,
-		OnClose:         t.OnClose,
+		OnBlockchainInit: t.OnBlockchainInit,
+		OnBlockStart:     t.OnBlockStart,
+		OnBlockEnd:       t.OnBlockEnd,
+		OnGenesisBlock:   t.OnGenesisBlock,
+		OnTxStart:        t.OnTxStart,
+		OnTxEnd:          t.OnTxEnd,
+		OnBalanceChange:  t.OnBalanceChange,
+		OnEnter:          t.OnEnter,
+		OnExit:           t.OnExit,
+		OnClose:          t.OnClose,
 	}, nil
 }
 
 func newSupplyInfo() supplyInfo {
 	return supplyInfo{
 		Issuance: &supplyInfoIssuance{
-			GenesisAlloc: big.NewInt(0),
-			Reward:       big.NewInt(0),
-			Withdrawals:  big.NewInt(0),
+			GenesisAlloc:    big.NewInt(0),
+			Reward:          big.NewInt(0),
+			Withdrawals:     big.NewInt(0),
+			BridgeDeposit:   big.NewInt(0),
+			ValidatorReward: big.NewInt(0),
 		},
 		Burn: &supplyInfoBurn{
-			EIP1559: big.NewInt(0),
-			Blob:    big.NewInt(0),
-			Misc:    big.NewInt(0),
+			EIP1559:          big.NewInt(0),
+			Blob:             big.NewInt(0),
+			Misc:             big.NewInt(0),
+			BridgeWithdrawal: big.NewInt(0),
+		},
+		Redistribution: &supplyInfoRedistribution{
+			FoundationTax: big.NewInt(0),
 		},
 
 		Number:     0,
@@ -132,6 +165,10 @@ func (s *supply) resetDelta() {
 	s.delta = newSupplyInfo()
 }
 
+func (s *supply) OnBlockchainInit(chainConfig *params.ChainConfig) {
+	s.goat = chainConfig.Goat != nil
+}
+
 func (s *supply) OnBlockStart(ev tracing.BlockEvent) {
 	s.resetDelta()
 
@@ -153,6 +190,13 @@ func (s *supply) OnBlockStart(ev tracing.BlockEvent) {
 		)
 		s.delta.Burn.Blob = burn
 	}
+	// The withdrawals are locked in the bridge and released on the bitcoin network
+	for _, r := range ev.Block.Requests() {
+		if w, ok := r.Inner().(*types.BridgeWithdrawal); ok {
+			amount := new(big.Int).Mul(new(big.Int).SetUint64(w.Amount), big.NewInt(params.Satoshi))
+			s.delta.Burn.BridgeWithdrawal.Add(s.delta.Burn.BridgeWithdrawal, amount)
+		}
+	}
 }
 
 func (s *supply) OnBlockEnd(err error) {
@@ -183,7 +227,12 @@ func (s *supply) OnBalanceChange(a common.Address, prevBalance, newBalance *big.
 	case tracing.BalanceIncreaseRewardMineBlock:
 		s.delta.Issuance.Reward.Add(s.delta.Issuance.Reward, diff)
 	case tracing.BalanceIncreaseWithdrawal:
-		s.delta.Issuance.Withdrawals.Add(s.delta.Issuance.Withdrawals, diff)
+		// the goat rewards are distributed by the goat txs
+		if s.goatTx {
+			s.delta.Issuance.ValidatorReward.Add(s.delta.Issuance.ValidatorReward, diff)
+		} else {
+			s.delta.Issuance.Withdrawals.Add(s.delta.Issuance.Withdrawals, diff)
+		}
 	case tracing.BalanceDecreaseSelfdestructBurn:
 		// BalanceDecreaseSelfdestructBurn is non-reversible as it happens
 		// at the end of the transaction.
@@ -191,7 +240,14 @@ func (s *supply) OnBalanceChange(a common.Address, prevBalance, newBalance *big.
 
 	case tracing.BalanceGoatDepoist, tracing.BalanceGoatTax:
 		// goat
-		s.delta.Issuance.Withdrawals.Add(s.delta.Issuance.Withdrawals, diff)
+		s.delta.Issuance.BridgeDeposit.Add(s.delta.Issuance.BridgeDeposit, diff)
+	case tracing.BalanceIncreaseRewardTransactionFee:
+		// the gas fees are burnt on the goat chain, and the foundation shares are
+		// minted back to the recipients at the end of the block except the burnt ones
+		if !s.goat {
+			return
+		}
+		s.delta.Redistribution.FoundationTax.Add(s.delta.Redistribution.FoundationTax, diff)
 	default:
 		return
 	}
@@ -199,6 +255,11 @@ func (s *supply) OnBalanceChange(a common.Address, prevBalance, newBalance *big.
 
 func (s *supply) OnTxStart(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
 	s.txCallstack = make([]supplyTxCallstack, 0, 1)
+	s.goatTx = tx.IsGoatTx()
+}
+
+func (s *supply) OnTxEnd(receipt *types.Receipt, err error) {
+	s.goatTx = false
 }
 
 // internalTxsHandler handles internal transactions burned amount
@@ -282,7 +343,16 @@ func (s *supply) write(data any) {
 		supply.Issuance.Withdrawals = nil
 	}
 
-	if supply.Issuance.GenesisAlloc == nil && supply.Issuance.Reward == nil && supply.Issuance.Withdrawals == nil {
+	if supply.Issuance.BridgeDeposit.Sign() == 0 {
+		supply.Issuance.BridgeDeposit = nil
+	}
+
+	if supply.Issuance.ValidatorReward.Sign() == 0 {
+		supply.Issuance.ValidatorReward = nil
+	}
+
+	if supply.Issuance.GenesisAlloc == nil && supply.Issuance.Reward == nil && supply.Issuance.Withdrawals == nil &&
+		supply.Issuance.BridgeDeposit == nil && supply.Issuance.ValidatorReward == nil {
 		supply.Issuance = nil
 	}
 
@@ -298,10 +368,18 @@ func (s *supply) write(data any) {
 		supply.Burn.Misc = nil
 	}
 
-	if supply.Burn.EIP1559 == nil && supply.Burn.Blob == nil && supply.Burn.Misc == nil {
+	if supply.Burn.BridgeWithdrawal.Sign() == 0 {
+		supply.Burn.BridgeWithdrawal = nil
+	}
+
+	if supply.Burn.EIP1559 == nil && supply.Burn.Blob == nil && supply.Burn.Misc == nil && supply.Burn.BridgeWithdrawal == nil {
 		supply.Burn = nil
 	}
 
+	if supply.Redistribution.FoundationTax.Sign() == 0 {
+		supply.Redistribution = nil
+	}
+
 	out, _ := json.Marshal(supply)
 	if _, err := s.logger.Write(out); err != nil {
 		log.Warn("failed to write to supply tracer log file", "error", err)
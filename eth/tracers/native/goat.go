@@ -0,0 +1,198 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("goatTracer", newGoatTracer, false)
+}
+
+// goatTxInfo is the decoded goat tx
+type goatTxInfo struct {
+	Module   string         `json:"module"`
+	Action   string         `json:"action"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	Sender   common.Address `json:"sender"`
+	Contract common.Address `json:"contract"`
+	Inner    goattypes.Tx   `json:"inner"`
+}
+
+// goatMint is a balance change minted by the goat tx
+type goatMint struct {
+	Address common.Address `json:"address"`
+	Reason  string         `json:"reason"` // deposit, tax or reward
+	Amount  *hexutil.Big   `json:"amount"`
+}
+
+// goatEvent is a goat request emitted by a system contract log
+type goatEvent struct {
+	Kind     string         `json:"kind"`
+	Address  common.Address `json:"address"`
+	LogIndex hexutil.Uint   `json:"logIndex"`
+	Request  *types.Request `json:"request,omitempty"`
+	Error    string         `json:"error,omitempty"` // the event can't be decoded as a request
+}
+
+type goatTracerResult struct {
+	GoatTx   *goatTxInfo  `json:"goatTx,omitempty"`
+	Mints    []*goatMint  `json:"mints"`
+	Requests []*goatEvent `json:"requests"`
+}
+
+// goatTracer decodes the goat txs, and collects the balances minted by them and the
+// goat requests emitted by the relayer, bridge and locking contracts.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "goatTracer"})
+//	{
+//	  goatTx: {module: "bridge", action: "deposit", nonce: "0x0", sender: "0x...", contract: "0x...", inner: {...}},
+//	  mints: [{address: "0x...", reason: "deposit", amount: "0x..."}, {address: "0x...", reason: "tax", amount: "0x..."}],
+//	  requests: []
+//	}
+type goatTracer struct {
+	result    goatTracerResult
+	goat      *params.GoatConfig
+	time      uint64
+	goatTx    bool
+	frames    []int       // the number of requests when the call frames are entered
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newGoatTracer returns a native go tracer which decodes the goat activity of a tx.
+func newGoatTracer(ctx *tracers.Context, _ json.RawMessage) (*tracers.Tracer, error) {
+	t := &goatTracer{
+		result: goatTracerResult{Mints: make([]*goatMint, 0), Requests: make([]*goatEvent, 0)},
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart:       t.OnTxStart,
+			OnTxEnd:         t.OnTxEnd,
+			OnEnter:         t.OnEnter,
+			OnExit:          t.OnExit,
+			OnLog:           t.OnLog,
+			OnBalanceChange: t.OnBalanceChange,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *goatTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.goat, t.time = env.ChainConfig.Goat, env.Time
+	goatTx := tx.GoatTx()
+	if goatTx == nil {
+		return
+	}
+	t.goatTx = true
+	inner := goatTx.Inner()
+	t.result.GoatTx = &goatTxInfo{
+		Module:   goattypes.ModuleName(goatTx.Module),
+		Action:   goattypes.ActionName(goatTx.Module, goatTx.Action),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Sender:   t.goat.SystemAddress(inner.Sender()),
+		Contract: t.goat.SystemAddress(inner.Contract()),
+		Inner:    inner,
+	}
+}
+
+func (t *goatTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if err != nil {
+		// the tx is not applied at all
+		t.result.Mints = t.result.Mints[:0]
+		t.result.Requests = t.result.Requests[:0]
+	}
+}
+
+func (t *goatTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.frames = append(t.frames, len(t.result.Requests))
+}
+
+func (t *goatTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(t.frames) == 0 {
+		return
+	}
+	start := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	// the logs of the reverted frame are discarded
+	if reverted {
+		t.result.Requests = t.result.Requests[:start]
+	}
+}
+
+func (t *goatTracer) OnLog(log *types.Log) {
+	if t.interrupt.Load() || t.goat == nil {
+		return
+	}
+	module := types.GoatEventModule(t.goat.SystemAddresses(), log.Address)
+	if module == nil || !module.IsActive(t.goat, t.time) {
+		return
+	}
+	reqs, err := module.DecodeEvent(false, log.Topics, log.Data)
+	if err != nil {
+		t.result.Requests = append(t.result.Requests, &goatEvent{Address: log.Address, LogIndex: hexutil.Uint(log.Index), Error: err.Error()})
+		return
+	}
+	for _, req := range reqs {
+		var kind string
+		if k := types.LookupGoatRequestKind(req.Type()); k != nil {
+			kind = k.Name
+		}
+		t.result.Requests = append(t.result.Requests, &goatEvent{
+			Kind:     kind,
+			Address:  log.Address,
+			LogIndex: hexutil.Uint(log.Index),
+			Request:  req,
+		})
+	}
+}
+
+func (t *goatTracer) OnBalanceChange(addr common.Address, prev, cur *big.Int, reason tracing.BalanceChangeReason) {
+	if t.interrupt.Load() || !t.goatTx {
+		return
+	}
+	var name string
+	switch reason {
+	case tracing.BalanceGoatDepoist:
+		name = "deposit"
+	case tracing.BalanceGoatTax:
+		name = "tax"
+	case tracing.BalanceIncreaseWithdrawal:
+		name = "reward"
+	default:
+		return
+	}
+	t.result.Mints = append(t.result.Mints, &goatMint{
+		Address: addr,
+		Reason:  name,
+		Amount:  (*hexutil.Big)(new(big.Int).Sub(cur, prev)),
+	})
+}
+
+// GetResult returns the json-encoded goat activity of the tx, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *goatTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *goatTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -0,0 +1,89 @@
+package native_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoatTracer(t *testing.T) {
+	tracer, err := tracers.DefaultDirectory.New("goatTracer", &tracers.Context{}, nil)
+	require.NoError(t, err)
+
+	var (
+		config = *params.AllDevChainProtocolChanges
+		target = common.Address{0x1}
+		inner  = &goattypes.DepositTx{Txid: common.Hash{0x2}, TxOut: 1, Target: target, Amount: big.NewInt(100)}
+		tx     = types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 3, inner))
+		cancel = func(id byte) *types.Log {
+			return &types.Log{Address: goattypes.BridgeContract, Topics: []common.Hash{types.GoatCancel1Topic, {31: id}}, Index: uint(id)}
+		}
+	)
+	config.Goat = &params.GoatConfig{}
+
+	tracer.OnTxStart(&tracing.VMContext{ChainConfig: &config}, tx, goattypes.RelayerExecutor)
+	tracer.OnBalanceChange(target, big.NewInt(0), big.NewInt(90), tracing.BalanceGoatDepoist)
+	tracer.OnBalanceChange(goattypes.GoatFoundationContract, big.NewInt(5), big.NewInt(15), tracing.BalanceGoatTax)
+	tracer.OnBalanceChange(target, big.NewInt(90), big.NewInt(80), tracing.BalanceDecreaseSelfdestruct)
+	tracer.OnEnter(0, byte(vm.CALL), goattypes.RelayerExecutor, goattypes.BridgeContract, nil, 0, big.NewInt(0))
+	tracer.OnLog(cancel(1))
+	tracer.OnLog(&types.Log{Address: goattypes.BridgeContract, Topics: []common.Hash{types.GoatCancel1Topic, {}}, Data: []byte{0x1}, Index: 2})
+	tracer.OnLog(&types.Log{Address: common.Address{0x3}, Topics: []common.Hash{types.GoatCancel1Topic, {}}})
+	// the requests of the reverted frame are discarded
+	tracer.OnEnter(1, byte(vm.CALL), goattypes.BridgeContract, goattypes.BridgeContract, nil, 0, big.NewInt(0))
+	tracer.OnLog(cancel(3))
+	tracer.OnExit(1, nil, 0, vm.ErrExecutionReverted, true)
+	tracer.OnExit(0, nil, 0, nil, false)
+	tracer.OnTxEnd(&types.Receipt{}, nil)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var have struct {
+		GoatTx struct {
+			Module   string
+			Action   string
+			Nonce    string
+			Contract common.Address
+			Inner    *goattypes.DepositTx
+		}
+		Mints []struct {
+			Address common.Address
+			Reason  string
+			Amount  string
+		}
+		Requests []struct {
+			Kind     string
+			LogIndex string
+			Request  json.RawMessage
+			Error    string
+		}
+	}
+	require.NoError(t, json.Unmarshal(res, &have))
+	require.Equal(t, "bridge", have.GoatTx.Module)
+	require.Equal(t, "deposit", have.GoatTx.Action)
+	require.Equal(t, "0x3", have.GoatTx.Nonce)
+	require.Equal(t, goattypes.BridgeContract, have.GoatTx.Contract)
+	require.Equal(t, inner.Amount, have.GoatTx.Inner.Amount)
+
+	require.Len(t, have.Mints, 2)
+	require.Equal(t, "deposit", have.Mints[0].Reason)
+	require.Equal(t, "0x5a", have.Mints[0].Amount)
+	require.Equal(t, "tax", have.Mints[1].Reason)
+	require.Equal(t, "0xa", have.Mints[1].Amount)
+
+	require.Len(t, have.Requests, 2)
+	require.Equal(t, "cancel1", have.Requests[0].Kind)
+	require.Equal(t, "0x1", have.Requests[0].LogIndex)
+	require.JSONEq(t, `{"id":"0x1"}`, string(have.Requests[0].Request))
+	require.NotEmpty(t, have.Requests[1].Error)
+}
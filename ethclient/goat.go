@@ -0,0 +1,144 @@
+package ethclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GoatWithdrawal is a bridge withdrawal request with the block it's included in.
+type GoatWithdrawal struct {
+	BlockHash   common.Hash             `json:"blockHash"`
+	BlockNumber hexutil.Uint64          `json:"blockNumber"`
+	Withdrawal  *types.BridgeWithdrawal `json:"withdrawal"`
+}
+
+// GoatBitcoinHeader is a bitcoin header tracked by the execution layer.
+type GoatBitcoinHeader struct {
+	Height     hexutil.Uint64 `json:"height"`
+	Hash       common.Hash    `json:"hash"`
+	Version    int32          `json:"version"`
+	PrevBlock  common.Hash    `json:"prevBlock"`
+	MerkleRoot common.Hash    `json:"merkleRoot"`
+	Timestamp  hexutil.Uint64 `json:"timestamp"`
+	Bits       hexutil.Uint64 `json:"bits"`
+	Nonce      hexutil.Uint64 `json:"nonce"`
+	Raw        hexutil.Bytes  `json:"raw"`
+}
+
+// GoatBlockFees is the gas fees of a block and how they're split.
+type GoatBlockFees struct {
+	BlockHash     common.Hash    `json:"blockHash"`
+	BlockNumber   hexutil.Uint64 `json:"blockNumber"`
+	BurntFees     *hexutil.Big   `json:"burntFees"`
+	Tips          *hexutil.Big   `json:"tips"`
+	BlobFees      *hexutil.Big   `json:"blobFees"`
+	TotalFees     *hexutil.Big   `json:"totalFees"`
+	FoundationTax *hexutil.Big   `json:"foundationTax"`
+	GasRevenue    *hexutil.Big   `json:"gasRevenue"`
+}
+
+// GoatFoundationShare is a share of the gas fees taken by the foundation tax in a block.
+type GoatFoundationShare struct {
+	BlockHash   common.Hash     `json:"blockHash"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	Recipient   *common.Address `json:"recipient"` // nil if the share is burnt
+	BasePoint   hexutil.Uint64  `json:"basePoint"`
+	Amount      *hexutil.Big    `json:"amount"`
+}
+
+// GoatRequestsByHash returns the goat requests of the given block.
+func (ec *Client) GoatRequestsByHash(ctx context.Context, hash common.Hash) (*types.GoatRequests, error) {
+	var result *types.GoatRequests
+	err := ec.c.CallContext(ctx, &result, "goat_getRequestsByBlock", hash)
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// GoatRequestsByNumber returns the goat requests of the given block.
+//
+// If number is nil, the latest known block is returned.
+func (ec *Client) GoatRequestsByNumber(ctx context.Context, number *big.Int) (*types.GoatRequests, error) {
+	var result *types.GoatRequests
+	err := ec.c.CallContext(ctx, &result, "goat_getRequestsByBlock", toBlockNumArg(number))
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// GoatRequestsByRange returns the goat requests of the blocks in the range [from, from+count).
+func (ec *Client) GoatRequestsByRange(ctx context.Context, from, count uint64) ([]*types.GoatRequests, error) {
+	var result []*types.GoatRequests
+	err := ec.c.CallContext(ctx, &result, "goat_getRequestsByRange", hexutil.Uint64(from), hexutil.Uint64(count))
+	return result, err
+}
+
+// GoatBlockFees returns the gas fees of the given block.
+//
+// If number is nil, the latest known block is used.
+func (ec *Client) GoatBlockFees(ctx context.Context, number *big.Int) (*GoatBlockFees, error) {
+	var result *GoatBlockFees
+	err := ec.c.CallContext(ctx, &result, "goat_getBlockFees", toBlockNumArg(number))
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// GoatBlockFoundationShares returns the foundation tax shares of the gas fees of the given block.
+//
+// If number is nil, the latest known block is used.
+func (ec *Client) GoatBlockFoundationShares(ctx context.Context, number *big.Int) ([]*GoatFoundationShare, error) {
+	var result []*GoatFoundationShare
+	err := ec.c.CallContext(ctx, &result, "goat_getBlockFoundationShares", toBlockNumArg(number))
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// GoatWithdrawal returns the bridge withdrawal request with the given id.
+func (ec *Client) GoatWithdrawal(ctx context.Context, id uint64) (*GoatWithdrawal, error) {
+	var result *GoatWithdrawal
+	err := ec.c.CallContext(ctx, &result, "goat_getWithdrawal", hexutil.Uint64(id))
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// GoatWithdrawalStatus returns the current status of the bridge withdrawal with the given id.
+func (ec *Client) GoatWithdrawalStatus(ctx context.Context, id uint64) (*types.GoatWithdrawalLifecycle, error) {
+	var result *types.GoatWithdrawalLifecycle
+	err := ec.c.CallContext(ctx, &result, "goat_getWithdrawalStatus", hexutil.Uint64(id))
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// GoatBitcoinHeader returns the tracked bitcoin header at the given height.
+func (ec *Client) GoatBitcoinHeader(ctx context.Context, height uint64) (*GoatBitcoinHeader, error) {
+	var result *GoatBitcoinHeader
+	err := ec.c.CallContext(ctx, &result, "goat_btcHeader", hexutil.Uint64(height))
+	if err == nil && result == nil {
+		err = ethereum.NotFound
+	}
+	return result, err
+}
+
+// SubscribeGoatRequests subscribes to the goat requests of the new blocks.
+func (ec *Client) SubscribeGoatRequests(ctx context.Context, ch chan<- *types.GoatRequests) (ethereum.Subscription, error) {
+	sub, err := ec.c.Subscribe(ctx, "goat", ch, "requests")
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
@@ -18,6 +18,7 @@ package simulated
 
 import (
 	"errors"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -187,6 +188,17 @@ func (n *Backend) AdjustTime(adjustment time.Duration) error {
 	return n.beacon.AdjustTime(adjustment)
 }
 
+// AddGoatTx queues a goat tx, it's included in the next committed block.
+func (n *Backend) AddGoatTx(tx *types.Transaction) error {
+	return n.beacon.AddGoatTx(tx)
+}
+
+// Deposit queues a bridge deposit of the amount to the target, it's included in
+// the next committed block. The backend must be created with a goat chain config.
+func (n *Backend) Deposit(target common.Address, amount *big.Int) (*types.Transaction, error) {
+	return n.beacon.Deposit(target, amount)
+}
+
 // Client returns a client that accesses the simulated chain.
 func (n *Backend) Client() Client {
 	return n.client
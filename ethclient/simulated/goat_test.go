@@ -0,0 +1,368 @@
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGoatDeposit(t *testing.T) {
+	// the bridge stub returns zero tax for every deposit
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{
+		Bridge: &core.GoatPredeploy{Code: common.FromHex("0x60206000f3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := NewBackend(nil, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = genesis
+	})
+	defer sim.Close()
+
+	var (
+		client = sim.Client()
+		target = common.Address{0x1}
+		amount = big.NewInt(1e18)
+	)
+	for i := 0; i < 2; i++ {
+		tx, err := sim.Deposit(target, amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Nonce() != uint64(i) {
+			t.Fatalf("deposit %d: nonce mismatch %d", i, tx.Nonce())
+		}
+	}
+	sim.Commit()
+
+	block, err := client.BlockByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(block.Transactions()); n != 2 {
+		t.Fatalf("goat txs are not included: %d", n)
+	}
+	balance, err := client.BalanceAt(context.Background(), target, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := new(big.Int).Mul(amount, big.NewInt(2)); balance.Cmp(want) != 0 {
+		t.Fatalf("balance mismatch: have %v want %v", balance, want)
+	}
+
+	// the nonce follows the state after the inclusion
+	tx, err := sim.Deposit(target, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 2 {
+		t.Fatalf("nonce mismatch after inclusion: %d", tx.Nonce())
+	}
+}
+
+func TestGoatTransactionRoundTrip(t *testing.T) {
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{
+		Bridge: &core.GoatPredeploy{Code: common.FromHex("0x60206000f3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := NewBackend(nil, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = genesis
+	})
+	defer sim.Close()
+
+	tx, err := sim.Deposit(common.Address{0x1}, big.NewInt(1e18))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	var (
+		ctx    = context.Background()
+		client = sim.client
+	)
+	have, pending, err := client.TransactionByHash(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending || have.Hash() != tx.Hash() {
+		t.Fatalf("tx mismatch: pending %v hash %x", pending, have.Hash())
+	}
+	if want, got := tx.GoatTx(), have.GoatTx(); got.Module != want.Module || got.Action != want.Action || !reflect.DeepEqual(got.Inner(), want.Inner()) {
+		t.Fatalf("goat tx mismatch: have %+v want %+v", got, want)
+	}
+	if !reflect.DeepEqual(have.Deposit(), tx.Deposit()) {
+		t.Fatalf("deposit mismatch: have %+v want %+v", have.Deposit(), tx.Deposit())
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := client.TransactionSender(ctx, have, receipt.BlockHash, receipt.TransactionIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := genesis.Config.Goat.SystemAddresses().RelayerExecutor; sender != want {
+		t.Fatalf("sender mismatch: have %x want %x", sender, want)
+	}
+
+	// the goat fields are hex quantities like the other fields
+	var raw map[string]any
+	if err := client.Client.Client().CallContext(ctx, &raw, "eth_getTransactionByHash", tx.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	if raw["module"] != "0x1" || raw["action"] != "0x1" {
+		t.Fatalf("goat tx module and action mismatch: %v %v", raw["module"], raw["action"])
+	}
+}
+
+func TestGoatDepositNotGoatChain(t *testing.T) {
+	sim := NewBackend(types.GenesisAlloc{})
+	defer sim.Close()
+
+	if _, err := sim.Deposit(common.Address{0x1}, big.NewInt(1)); err == nil {
+		t.Fatal("expect error on the non-goat chain")
+	}
+}
+
+func TestGoatFoundationShares(t *testing.T) {
+	var (
+		treasury = common.Address{0xaa}
+		config   = &params.GoatConfig{GoatParams: params.GoatParams{
+			GfShares: []*params.GoatFoundationShare{{Recipient: &treasury, BasePoint: 300}, {BasePoint: 100}},
+		}}
+	)
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{Faucet: &testAddr, Goat: config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := NewBackend(nil, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = genesis
+	})
+	defer sim.Close()
+
+	client := sim.Client()
+	tx, err := newTx(sim, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	block, err := client.BlockByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fees := new(big.Int).Mul(new(big.Int).Add(block.BaseFee(), big.NewInt(params.GWei)), big.NewInt(21000))
+	shares, err := sim.client.GoatBlockFoundationShares(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shares) != 2 {
+		t.Fatalf("shares length mismatch: %d", len(shares))
+	}
+	for i, want := range []int64{300, 100} {
+		amount := new(big.Int).Div(new(big.Int).Mul(fees, big.NewInt(want)), big.NewInt(params.GoatGfMaxBasePoint))
+		if shares[i].Amount.ToInt().Cmp(amount) != 0 || uint64(shares[i].BasePoint) != uint64(want) || shares[i].BlockHash != block.Hash() {
+			t.Fatalf("share %d mismatch: %+v", i, shares[i])
+		}
+	}
+	if shares[0].Recipient == nil || *shares[0].Recipient != treasury || shares[1].Recipient != nil {
+		t.Fatal("share recipients mismatch")
+	}
+	balance, err := client.BalanceAt(context.Background(), treasury, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(shares[0].Amount.ToInt()) != 0 {
+		t.Fatalf("treasury balance mismatch: have %v want %v", balance, shares[0].Amount)
+	}
+
+	// the gas revenue takes the rest
+	requests, err := sim.client.GoatRequestsByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revenue := new(big.Int).Sub(fees, shares[0].Amount.ToInt())
+	revenue.Sub(revenue, shares[1].Amount.ToInt())
+	if len(requests.GasRevenues) != 1 || requests.GasRevenues[0].Amount.Cmp(revenue) != 0 {
+		t.Fatalf("gas revenue mismatch: %+v", requests.GasRevenues)
+	}
+}
+
+func TestGoatBlockFees(t *testing.T) {
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{Faucet: &testAddr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := NewBackend(nil, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = genesis
+	})
+	defer sim.Close()
+
+	tx, err := newTx(sim, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.Client().SendTransaction(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	block, err := sim.Client().BlockByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fees, err := sim.client.GoatBlockFees(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		burnt = new(big.Int).Mul(block.BaseFee(), big.NewInt(21000))
+		tips  = big.NewInt(21000 * params.GWei)
+		total = new(big.Int).Add(burnt, tips)
+		tax   = new(big.Int).Div(new(big.Int).Mul(total, big.NewInt(params.GoatGfBasePoint)), big.NewInt(params.GoatGfMaxBasePoint))
+	)
+	if fees.BlockHash != block.Hash() || fees.BurntFees.ToInt().Cmp(burnt) != 0 || fees.Tips.ToInt().Cmp(tips) != 0 || fees.BlobFees.ToInt().Sign() != 0 {
+		t.Fatalf("block fees mismatch: %+v", fees)
+	}
+	if fees.TotalFees.ToInt().Cmp(total) != 0 || fees.FoundationTax.ToInt().Cmp(tax) != 0 {
+		t.Fatalf("block fees split mismatch: %+v", fees)
+	}
+	requests, err := sim.client.GoatRequestsByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests.GasRevenues) != 1 || requests.GasRevenues[0].Amount.Cmp(fees.GasRevenue.ToInt()) != 0 {
+		t.Fatalf("gas revenue mismatch: have %+v want %v", requests.GasRevenues, fees.GasRevenue)
+	}
+}
+
+func TestGoatRequests(t *testing.T) {
+	// the bridge stub emits the Withdraw event, the id is the first calldata
+	// word and the rest of the calldata is the event data
+	code := []byte{0x60, 0x20, 0x36, 0x03, 0x60, 0x20, 0x60, 0x00, 0x37, 0x60, 0x00, 0x60, 0x00, 0x35, 0x7f}
+	code = append(code, types.GoatWithdrawalTopic[:]...)
+	code = append(code, 0x60, 0x20, 0x36, 0x03, 0x60, 0x00, 0xa3, 0x00)
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{
+		Faucet: &testAddr,
+		Bridge: &core.GoatPredeploy{Code: code},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := NewBackend(nil, func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		ethConf.Genesis = genesis
+	})
+	defer sim.Close()
+
+	var (
+		ctx     = context.Background()
+		client  = sim.client
+		address = "bc1qgoat"
+		want    = &types.BridgeWithdrawal{Id: 7, Amount: 1000, MaxTxPrice: 5, Address: address}
+		data    = make([]byte, 32+192)
+	)
+	data[31] = byte(want.Id)
+	new(big.Int).Mul(big.NewInt(int64(want.Amount)), big.NewInt(1e10)).FillBytes(data[32:64])
+	data[32+95] = byte(want.MaxTxPrice)
+	data[32+127] = 128
+	data[32+159] = byte(len(address))
+	copy(data[32+160:], address)
+
+	requests := make(chan *types.GoatRequests, 1)
+	sub, err := client.SubscribeGoatRequests(ctx, requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainid, err := client.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bridge := genesis.Config.Goat.SystemAddresses().Bridge
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainid,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+		Gas:       100000,
+		To:        &bridge,
+		Data:      data,
+	}), types.LatestSignerForChainID(chainid), testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	hash := sim.Commit()
+
+	check := func(name string, reqs *types.GoatRequests) {
+		t.Helper()
+		if reqs.BlockHash != hash || reqs.BlockNumber != 1 {
+			t.Fatalf("%s: block mismatch: %x %d", name, reqs.BlockHash, reqs.BlockNumber)
+		}
+		if len(reqs.BridgeWithdrawals) != 1 || !reflect.DeepEqual(reqs.BridgeWithdrawals[0], want) {
+			t.Fatalf("%s: withdrawals mismatch: %+v", name, reqs.BridgeWithdrawals)
+		}
+	}
+	select {
+	case reqs := <-requests:
+		check("subscription", reqs)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no requests notified")
+	}
+	reqs, err := client.GoatRequestsByHash(ctx, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("by hash", reqs)
+	if reqs, err = client.GoatRequestsByNumber(ctx, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	check("by number", reqs)
+	// the range is truncated at the head
+	list, err := client.GoatRequestsByRange(ctx, 1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("range length mismatch: %d", len(list))
+	}
+	check("by range", list[0])
+	if _, err := client.GoatRequestsByHash(ctx, common.Hash{0x1}); err == nil {
+		t.Fatal("expected error for the unknown block")
+	}
+
+	withdrawal, err := client.GoatWithdrawal(ctx, want.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withdrawal.BlockHash != hash || withdrawal.BlockNumber != 1 || !reflect.DeepEqual(withdrawal.Withdrawal, want) {
+		t.Fatalf("withdrawal mismatch: %+v", withdrawal)
+	}
+	if _, err := client.GoatWithdrawal(ctx, want.Id+1); err != ethereum.NotFound {
+		t.Fatalf("unknown withdrawal: have %v, want %v", err, ethereum.NotFound)
+	}
+}
@@ -19,6 +19,7 @@ package graphql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -34,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -209,6 +211,56 @@ func (w *Withdrawal) Amount(ctx context.Context) hexutil.Uint64 {
 	return hexutil.Uint64(w.amount)
 }
 
+// GoatMint represents the native token minted by a goat transaction.
+type GoatMint struct {
+	mint *goattypes.Mint
+}
+
+func (m *GoatMint) Address(ctx context.Context) common.Address {
+	return m.mint.Address
+}
+
+func (m *GoatMint) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*m.mint.Amount)
+}
+
+// GoatTransaction represents the decoded payload of a goat transaction.
+type GoatTransaction struct {
+	tx      *types.GoatTx
+	deposit *goattypes.Mint
+	reward  *goattypes.Mint
+}
+
+func (g *GoatTransaction) Module(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(g.tx.Module)
+}
+
+func (g *GoatTransaction) Action(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(g.tx.Action)
+}
+
+func (g *GoatTransaction) Data(ctx context.Context) (string, error) {
+	data, err := json.Marshal(g.tx.Inner())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (g *GoatTransaction) Deposit(ctx context.Context) *GoatMint {
+	if g.deposit == nil {
+		return nil
+	}
+	return &GoatMint{g.deposit}
+}
+
+func (g *GoatTransaction) Reward(ctx context.Context) *GoatMint {
+	if g.reward == nil {
+		return nil
+	}
+	return &GoatMint{g.reward}
+}
+
 // Transaction represents an Ethereum transaction.
 // backend and hash are mandatory; all others will be fetched when required.
 type Transaction struct {
@@ -351,6 +403,14 @@ func (t *Transaction) BlobVersionedHashes(ctx context.Context) *[]common.Hash {
 	return &blobHashes
 }
 
+func (t *Transaction) Goat(ctx context.Context) *GoatTransaction {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || !tx.IsGoatTx() {
+		return nil
+	}
+	return &GoatTransaction{tx: tx.GoatTx(), deposit: tx.Deposit(), reward: tx.Reward()}
+}
+
 func (t *Transaction) EffectiveTip(ctx context.Context) (*hexutil.Big, error) {
 	tx, block := t.resolve(ctx)
 	if tx == nil {
@@ -89,6 +89,28 @@ const schema string = `
         amount: Long!
     }
 
+    # GoatMint is the native token minted by a goat transaction.
+    type GoatMint {
+        # Address is the receiver of the minted amount.
+        address: Address!
+        # Amount is the minted value in wei.
+        amount: BigInt!
+    }
+
+    # GoatTransaction is the decoded payload of a goat transaction.
+    type GoatTransaction {
+        # Module is the goat module which handles the transaction.
+        module: Long!
+        # Action is the action of the module.
+        action: Long!
+        # Data is the decoded transaction in JSON format.
+        data: String!
+        # Deposit is the bridge deposit minted by the transaction.
+        deposit: GoatMint
+        # Reward is the reward or un-delegation minted by the transaction.
+        reward: GoatMint
+    }
+
     # Transaction is an Ethereum transaction.
     type Transaction {
         # Hash is the hash of this transaction.
@@ -170,6 +192,8 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+        # Goat is the decoded goat transaction, it's null for the other transaction types.
+        goat: GoatTransaction
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
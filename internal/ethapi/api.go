@@ -39,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
@@ -1186,7 +1187,7 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 
 func applyMessage(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, timeout time.Duration, gp *core.GasPool, blockContext *vm.BlockContext, vmConfig *vm.Config, precompiles vm.PrecompiledContracts, skipChecks bool) (*core.ExecutionResult, error) {
 	// Get a new instance of the EVM.
-	if err := args.CallDefaults(gp.Gas(), blockContext.BaseFee, b.ChainConfig().ChainID); err != nil {
+	if err := args.CallDefaults(gp.Gas(), blockContext.BaseFee, b.ChainConfig()); err != nil {
 		return nil, err
 	}
 	msg := args.ToMessage(header.BaseFee, skipChecks, skipChecks)
@@ -1323,7 +1324,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	if args.Gas == nil {
 		args.Gas = new(hexutil.Uint64)
 	}
-	if err := args.CallDefaults(gasCap, header.BaseFee, b.ChainConfig().ChainID); err != nil {
+	if err := args.CallDefaults(gasCap, header.BaseFee, b.ChainConfig()); err != nil {
 		return 0, err
 	}
 	call := args.ToMessage(header.BaseFee, true, true)
@@ -1474,6 +1475,13 @@ type RPCTransaction struct {
 	R                   *hexutil.Big      `json:"r"`
 	S                   *hexutil.Big      `json:"s"`
 	YParity             *hexutil.Uint64   `json:"yParity,omitempty"`
+
+	// goat
+	Module  *hexutil.Uint64 `json:"module,omitempty"`
+	Action  *hexutil.Uint64 `json:"action,omitempty"`
+	GoatTx  goattypes.Tx    `json:"goatTx,omitempty"`
+	Deposit *goattypes.Mint `json:"deposit,omitempty"`
+	Reward  *goattypes.Mint `json:"reward,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1548,6 +1556,14 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		}
 		result.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
 		result.BlobVersionedHashes = tx.BlobHashes()
+
+	case types.GoatTxType:
+		goatTx := tx.GoatTx()
+		module, action := hexutil.Uint64(goatTx.Module), hexutil.Uint64(goatTx.Action)
+		result.Module, result.Action = &module, &action
+		result.GoatTx = goatTx.Inner()
+		result.Deposit = tx.Deposit()
+		result.Reward = tx.Reward()
 	}
 	return result
 }
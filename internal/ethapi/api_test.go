@@ -47,6 +47,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -3400,3 +3401,149 @@ func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc s
 func addressToHash(a common.Address) common.Hash {
 	return common.BytesToHash(a.Bytes())
 }
+
+func TestTransactionGoatTx(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.ShanghaiTime = new(uint64)
+	config.CancunTime = new(uint64)
+
+	deposit := &goattypes.DepositTx{
+		Txid:   common.HexToHash("0x15bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e"),
+		TxOut:  1,
+		Target: common.HexToAddress("0x5e4e4d79f08120352f04d638adec7d3892b28045"),
+		Amount: big.NewInt(1e10),
+	}
+	tx := types.NewTx(types.NewGoatTx(goattypes.BirdgeModule, goattypes.BridgeDepoitAction, 7, deposit))
+
+	rpcTx := newRPCTransaction(tx, common.Hash{}, 0, 0, 0, nil, &config)
+	data, err := json.Marshal(rpcTx)
+	if err != nil {
+		t.Fatalf("marshalling failed; %v", err)
+	}
+	want := `{
+		"blockHash": null,
+		"blockNumber": null,
+		"from": "0xbc10000000000000000000000000000000001000",
+		"gas": "0x0",
+		"gasPrice": "0x0",
+		"hash": "` + tx.Hash().Hex() + `",
+		"input": "0xb55ada3915bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e00000000000000000000000000000000000000000000000000000000000000010000000000000000000000005e4e4d79f08120352f04d638adec7d3892b2804500000000000000000000000000000000000000000000000000000002540be400",
+		"nonce": "0x7",
+		"to": "0xbc10000000000000000000000000000000000003",
+		"transactionIndex": null,
+		"value": "0x0",
+		"type": "0x60",
+		"v": "0x0",
+		"r": "0x0",
+		"s": "0x0",
+		"module": "0x1",
+		"action": "0x1",
+		"goatTx": {
+			"txid": "0x15bb90fa63b9a92e31d31f8d8d30bf8da9d9a21314c65dd517f27740ae676d6e",
+			"txout": "0x1",
+			"target": "0x5e4e4d79f08120352f04d638adec7d3892b28045",
+			"amount": "0x2540be400"
+		},
+		"deposit": {
+			"address": "0x5e4e4d79f08120352f04d638adec7d3892b28045",
+			"amount": "0x2540be400"
+		}
+	}`
+	require.JSONEq(t, want, string(data))
+
+	var tx2 types.Transaction
+	if err := tx2.UnmarshalJSON(data); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if want, have := tx.Hash(), tx2.Hash(); want != have {
+		t.Fatalf("tx changed, want %x have %x", want, have)
+	}
+	if !reflect.DeepEqual(tx2.GoatTx().Inner(), deposit) {
+		t.Fatalf("goat tx changed, want %v have %v", deposit, tx2.GoatTx().Inner())
+	}
+}
+
+func TestCallGoatTx(t *testing.T) {
+	t.Parallel()
+
+	// the bridge stub returns 5 as the tax for every deposit
+	genesis, err := core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{
+		Bridge: &core.GoatPredeploy{Code: common.FromHex("0x600560005260206000f3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 0, genesis, beacon.New(ethash.NewFaker()), nil))
+
+	var (
+		target  = common.Address{0x1}
+		deposit = &goattypes.DepositTx{Txid: common.Hash{0x2}, TxOut: 1, Target: target, Amount: big.NewInt(100)}
+		module  = hexutil.Uint64(goattypes.BirdgeModule)
+		action  = hexutil.Uint64(goattypes.BridgeDepoitAction)
+		data    = hexutil.Bytes(deposit.Encode())
+		args    = TransactionArgs{Module: &module, Action: &action, GoatData: &data}
+		latest  = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	ret, err := api.Call(context.Background(), args, &latest, nil, nil)
+	if err != nil {
+		t.Fatalf("eth_call failed: %v", err)
+	}
+	if tax := new(big.Int).SetBytes(ret); tax.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("tax mismatch: have %v want 5", tax)
+	}
+
+	results, err := api.SimulateV1(context.Background(), simOpts{BlockStateCalls: []simBlock{{Calls: []TransactionArgs{args, args}}}}, &latest)
+	if err != nil {
+		t.Fatalf("eth_simulateV1 failed: %v", err)
+	}
+	enc, err := json.Marshal(results[0]["calls"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[
+		{"returnData": "0x0000000000000000000000000000000000000000000000000000000000000005", "logs": [], "gasUsed": "0x0", "status": "0x1",
+		 "goatMint": {"deposit": {"address": "0x0100000000000000000000000000000000000000", "amount": "0x5f"}, "tax": "0x5"}},
+		{"returnData": "0x0000000000000000000000000000000000000000000000000000000000000005", "logs": [], "gasUsed": "0x0", "status": "0x1",
+		 "goatMint": {"deposit": {"address": "0x0100000000000000000000000000000000000000", "amount": "0x5f"}, "tax": "0x5"}}
+	]`
+	require.JSONEq(t, want, string(enc))
+	if txs := results[0]["transactions"].([]interface{}); len(txs) != 2 {
+		t.Fatalf("simulated txs mismatch: %d", len(txs))
+	}
+
+	// the fields which the goat tx can't carry
+	value := hexutil.Big(*big.NewInt(1))
+	for i, invalid := range []TransactionArgs{
+		{Module: &module, Action: &action},
+		{Module: &module, Action: &action, GoatData: &data, Value: &value},
+		{Module: &module, Action: &action, GoatData: &data, From: &target},
+		{Module: &module, Action: &action, GoatData: &data, Input: &data},
+	} {
+		if _, err := api.Call(context.Background(), invalid, &latest, nil, nil); err == nil {
+			t.Errorf("invalid %d: expect error", i)
+		}
+	}
+	// the configured executor is accepted as the sender
+	addrs := &params.GoatAddresses{RelayerExecutor: common.Address{0xe1}, Bridge: common.Address{0xb1}}
+	genesis, err = core.DeveloperGoatGenesisBlock(&core.GoatGenesisSpec{
+		Goat:   &params.GoatConfig{Addresses: addrs},
+		Bridge: &core.GoatPredeploy{Code: common.FromHex("0x600560005260206000f3")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api = NewBlockChainAPI(newTestBackend(t, 0, genesis, beacon.New(ethash.NewFaker()), nil))
+	args.From = &addrs.RelayerExecutor
+	if ret, err = api.Call(context.Background(), args, &latest, nil, nil); err != nil {
+		t.Fatalf("eth_call with the configured executor failed: %v", err)
+	}
+	if tax := new(big.Int).SetBytes(ret); tax.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("tax mismatch: have %v want 5", tax)
+	}
+	args.From = &goattypes.RelayerExecutor
+	if _, err := api.Call(context.Background(), args, &latest, nil, nil); err == nil {
+		t.Error("expect error for the default executor")
+	}
+}
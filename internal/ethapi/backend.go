@@ -101,7 +101,7 @@ type Backend interface {
 
 func GetAPIs(apiBackend Backend) []rpc.API {
 	nonceLock := new(AddrLocker)
-	return []rpc.API{
+	apis := []rpc.API{
 		{
 			Namespace: "eth",
 			Service:   NewEthereumAPI(apiBackend),
@@ -125,4 +125,8 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Service:   NewPersonalAccountAPI(apiBackend, nonceLock),
 		},
 	}
+	if apiBackend.ChainConfig().Goat != nil {
+		apis = append(apis, rpc.API{Namespace: "goat", Service: NewGoatAPI(apiBackend)})
+	}
+	return apis
 }
@@ -0,0 +1,299 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxGoatRequestsRange is the maximum number of blocks can be queried by goat_getRequestsByRange
+	maxGoatRequestsRange = 1024
+
+	// chainEventChanSize is the size of channel listening to ChainEvent.
+	chainEventChanSize = 10
+)
+
+var errNotGoatChain = errors.New("not a goat chain")
+
+// GoatAPI provides an API to access the goat requests for the consensus layer.
+type GoatAPI struct {
+	b Backend
+}
+
+// NewGoatAPI creates a new goat API instance.
+func NewGoatAPI(b Backend) *GoatAPI {
+	return &GoatAPI{b}
+}
+
+// RPCGoatWithdrawal is a bridge withdrawal request with the block it's included in
+type RPCGoatWithdrawal struct {
+	BlockHash   common.Hash             `json:"blockHash"`
+	BlockNumber hexutil.Uint64          `json:"blockNumber"`
+	Withdrawal  *types.BridgeWithdrawal `json:"withdrawal"`
+}
+
+// RPCBitcoinHeader is a bitcoin header tracked by the execution layer, the hashes are in the internal byte order
+type RPCBitcoinHeader struct {
+	Height     hexutil.Uint64 `json:"height"`
+	Hash       common.Hash    `json:"hash"`
+	Version    int32          `json:"version"`
+	PrevBlock  common.Hash    `json:"prevBlock"`
+	MerkleRoot common.Hash    `json:"merkleRoot"`
+	Timestamp  hexutil.Uint64 `json:"timestamp"`
+	Bits       hexutil.Uint64 `json:"bits"`
+	Nonce      hexutil.Uint64 `json:"nonce"`
+	Raw        hexutil.Bytes  `json:"raw"`
+}
+
+// RPCGoatFoundationShare is a share of the gas fees taken by the foundation tax in a block
+type RPCGoatFoundationShare struct {
+	BlockHash   common.Hash     `json:"blockHash"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	Recipient   *common.Address `json:"recipient"` // null if the share is burnt
+	BasePoint   hexutil.Uint64  `json:"basePoint"`
+	Amount      *hexutil.Big    `json:"amount"`
+}
+
+// RPCGoatBlockFees is the gas fees of a block, the gas revenue is handed to the consensus layer
+// by the gas revenue request, and the foundation tax includes the burnt shares
+type RPCGoatBlockFees struct {
+	BlockHash     common.Hash    `json:"blockHash"`
+	BlockNumber   hexutil.Uint64 `json:"blockNumber"`
+	BurntFees     *hexutil.Big   `json:"burntFees"`
+	Tips          *hexutil.Big   `json:"tips"`
+	BlobFees      *hexutil.Big   `json:"blobFees"`
+	TotalFees     *hexutil.Big   `json:"totalFees"`
+	FoundationTax *hexutil.Big   `json:"foundationTax"`
+	GasRevenue    *hexutil.Big   `json:"gasRevenue"`
+}
+
+// GetRequestsByBlock returns the goat requests of the given block, it returns null if the block is not found
+func (api *GoatAPI) GetRequestsByBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.GoatRequests, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	return types.NewGoatRequests(block), nil
+}
+
+// GetRequestsByRange returns the goat requests of the blocks in the range [from, from+count)
+// The result is truncated at the current head
+func (api *GoatAPI) GetRequestsByRange(ctx context.Context, from, count hexutil.Uint64) ([]*types.GoatRequests, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	if from == 0 || count == 0 {
+		return nil, errors.New("invalid block range")
+	}
+	if count > maxGoatRequestsRange {
+		return nil, errors.New("block range is too large")
+	}
+
+	head := api.b.CurrentHeader().Number.Uint64()
+	if uint64(from) > head {
+		return []*types.GoatRequests{}, nil
+	}
+	last := uint64(from) + uint64(count) - 1
+	if last > head {
+		last = head
+	}
+
+	res := make([]*types.GoatRequests, 0, last-uint64(from)+1)
+	for number := uint64(from); number <= last; number++ {
+		block, err := api.b.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			break
+		}
+		res = append(res, types.NewGoatRequests(block))
+	}
+	return res, nil
+}
+
+// GetBlockFoundationShares returns the foundation tax shares of the gas fees of the given block,
+// it returns null if the block is not found
+func (api *GoatAPI) GetBlockFoundationShares(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*RPCGoatFoundationShare, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	fees, err := api.blockFees(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	payments, _ := core.GoatFoundationSplit(api.b.ChainConfig().Goat, block.Time(), fees.Total())
+	res := make([]*RPCGoatFoundationShare, len(payments))
+	for i, p := range payments {
+		res[i] = &RPCGoatFoundationShare{
+			BlockHash:   block.Hash(),
+			BlockNumber: hexutil.Uint64(block.NumberU64()),
+			Recipient:   p.Recipient,
+			BasePoint:   hexutil.Uint64(p.BasePoint),
+			Amount:      (*hexutil.Big)(p.Amount),
+		}
+	}
+	return res, nil
+}
+
+// GetBlockFees returns the gas fees of the given block and how they're split into the
+// foundation tax and the gas revenue, it returns null if the block is not found
+func (api *GoatAPI) GetBlockFees(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*RPCGoatBlockFees, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	fees, err := api.blockFees(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	total := fees.Total()
+	_, revenue := core.GoatFoundationSplit(api.b.ChainConfig().Goat, block.Time(), total)
+	return &RPCGoatBlockFees{
+		BlockHash:     block.Hash(),
+		BlockNumber:   hexutil.Uint64(block.NumberU64()),
+		BurntFees:     (*hexutil.Big)(fees.BurntFees),
+		Tips:          (*hexutil.Big)(fees.Tips),
+		BlobFees:      (*hexutil.Big)(fees.BlobFees),
+		TotalFees:     (*hexutil.Big)(total),
+		FoundationTax: (*hexutil.Big)(new(big.Int).Sub(total, revenue)),
+		GasRevenue:    (*hexutil.Big)(revenue),
+	}, nil
+}
+
+// blockFees computes the gas fees of the block with the stored receipts
+func (api *GoatAPI) blockFees(ctx context.Context, block *types.Block) (*core.GoatBlockFees, error) {
+	receipts, err := api.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	return core.CalcGoatBlockFees(block.Header(), txs, receipts), nil
+}
+
+// GetWithdrawal returns the bridge withdrawal request with the given id, it returns null if the id is not found
+// and an error if the withdrawal index is incomplete
+func (api *GoatAPI) GetWithdrawal(ctx context.Context, id hexutil.Uint64) (*RPCGoatWithdrawal, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	events, complete := core.ReadGoatWithdrawalEvents(api.b.ChainDb(), api.b.ChainConfig().Goat, uint64(id))
+	for _, ev := range events {
+		if ev.Withdrawal != nil {
+			return &RPCGoatWithdrawal{
+				BlockHash:   ev.BlockHash,
+				BlockNumber: hexutil.Uint64(ev.BlockNumber),
+				Withdrawal:  ev.Withdrawal,
+			}, nil
+		}
+	}
+	if !complete {
+		return nil, fmt.Errorf("withdrawal %d not found, the withdrawal index is incomplete", id)
+	}
+	return nil, nil
+}
+
+// GetWithdrawalStatus returns the current status of the bridge withdrawal with the given id
+// and all the blocks and transactions that touched it, it returns null if the id is not found.
+// The history is flagged incomplete if the withdrawal index skipped the blocks without history,
+// and an error is returned instead of null for the same reason.
+func (api *GoatAPI) GetWithdrawalStatus(ctx context.Context, id hexutil.Uint64) (*types.GoatWithdrawalLifecycle, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return nil, errNotGoatChain
+	}
+	lc, complete := core.ReadGoatWithdrawalLifecycle(api.b.ChainDb(), api.b.ChainConfig().Goat, uint64(id))
+	if lc == nil && !complete {
+		return nil, fmt.Errorf("withdrawal %d not found, the withdrawal index is incomplete", id)
+	}
+	return lc, nil
+}
+
+// BtcHeader returns the bitcoin header at the given height tracked by the latest state,
+// it returns null if the header is not tracked
+func (api *GoatAPI) BtcHeader(ctx context.Context, height hexutil.Uint64) (*RPCBitcoinHeader, error) {
+	config := api.b.ChainConfig().Goat
+	if config == nil {
+		return nil, errNotGoatChain
+	}
+	if config.Bitcoin == nil {
+		return nil, errors.New("bitcoin header tracking is disabled")
+	}
+	state, _, err := api.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	raw := core.ReadBitcoinHeader(config, state, uint64(height))
+	if raw == nil {
+		return nil, nil
+	}
+	header, err := goattypes.DecodeBitcoinHeader(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &RPCBitcoinHeader{
+		Height:     height,
+		Hash:       header.Hash(),
+		Version:    header.Version,
+		PrevBlock:  header.PrevBlock,
+		MerkleRoot: header.MerkleRoot,
+		Timestamp:  hexutil.Uint64(header.Timestamp),
+		Bits:       hexutil.Uint64(header.Bits),
+		Nonce:      hexutil.Uint64(header.Nonce),
+		Raw:        raw,
+	}, nil
+}
+
+// Requests creates a subscription that is triggered each time a block is appended to the chain
+// It sends the goat requests of the block
+func (api *GoatAPI) Requests(ctx context.Context) (*rpc.Subscription, error) {
+	if api.b.ChainConfig().Goat == nil {
+		return &rpc.Subscription{}, errNotGoatChain
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		chainEvents := make(chan core.ChainEvent, chainEventChanSize)
+		chainSub := api.b.SubscribeChainEvent(chainEvents)
+		defer chainSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-chainEvents:
+				notifier.Notify(rpcSub.ID, types.NewGoatRequests(ev.Block))
+			case <-rpcSub.Err():
+				return
+			case <-chainSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
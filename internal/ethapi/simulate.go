@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -62,6 +63,14 @@ type simCallResult struct {
 	GasUsed     hexutil.Uint64 `json:"gasUsed"`
 	Status      hexutil.Uint64 `json:"status"`
 	Error       *callError     `json:"error,omitempty"`
+	GoatMint    *simGoatMint   `json:"goatMint,omitempty"`
+}
+
+// simGoatMint is the balances minted by a simulated goat tx
+type simGoatMint struct {
+	Deposit *goattypes.Mint `json:"deposit,omitempty"`
+	Tax     *hexutil.Big    `json:"tax,omitempty"`
+	Reward  *goattypes.Mint `json:"reward,omitempty"`
 }
 
 func (r *simCallResult) MarshalJSON() ([]byte, error) {
@@ -226,6 +235,9 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		blobGasUsed += receipts[i].BlobGasUsed
 		logs := tracer.Logs()
 		callRes := simCallResult{ReturnValue: result.Return(), Logs: logs, GasUsed: hexutil.Uint64(result.UsedGas)}
+		if mint := result.GoatMint; mint != nil {
+			callRes.GoatMint = &simGoatMint{Deposit: mint.Deposit, Tax: (*hexutil.Big)(mint.Tax), Reward: mint.Reward}
+		}
 		if result.Failed() {
 			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
 			if errors.Is(result.Err, vm.ErrExecutionReverted) {
@@ -266,6 +278,19 @@ func repairLogs(calls []simCallResult, hash common.Hash) {
 }
 
 func (sim *simulator) sanitizeCall(call *TransactionArgs, state *state.StateDB, header *types.Header, blockContext vm.BlockContext, gasUsed *uint64) error {
+	if call.IsGoatTx() {
+		if err := call.setGoatDefaults(sim.chainConfig.Goat); err != nil {
+			return err
+		}
+		// the goat tx doesn't take the block gas
+		gas := sim.chainConfig.Goat.GoatTxGasLimitAt(header.Time)
+		call.Gas = (*hexutil.Uint64)(&gas)
+		if call.Nonce == nil {
+			nonce := state.GetNonce(call.from())
+			call.Nonce = (*hexutil.Uint64)(&nonce)
+		}
+		return call.CallDefaults(0, header.BaseFee, sim.chainConfig)
+	}
 	if call.Nonce == nil {
 		nonce := state.GetNonce(call.from())
 		call.Nonce = (*hexutil.Uint64)(&nonce)
@@ -278,7 +303,7 @@ func (sim *simulator) sanitizeCall(call *TransactionArgs, state *state.StateDB,
 	if *gasUsed+uint64(*call.Gas) > blockContext.GasLimit {
 		return &blockGasLimitReachedError{fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, blockContext.GasLimit)}
 	}
-	if err := call.CallDefaults(sim.gp.Gas(), header.BaseFee, sim.chainConfig.ChainID); err != nil {
+	if err := call.CallDefaults(sim.gp.Gas(), header.BaseFee, sim.chainConfig); err != nil {
 		return err
 	}
 	return nil
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/types/goattypes"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -72,10 +73,59 @@ type TransactionArgs struct {
 	Commitments []kzg4844.Commitment `json:"commitments"`
 	Proofs      []kzg4844.Proof      `json:"proofs"`
 
+	// For GoatTxType, the sender and the recipient are implied by the goat tx,
+	// and the gas semantics of the goat txs are applied
+	Module   *hexutil.Uint64 `json:"module,omitempty"`
+	Action   *hexutil.Uint64 `json:"action,omitempty"`
+	GoatData *hexutil.Bytes  `json:"goatData,omitempty"`
+
 	// This configures whether blobs are allowed to be passed.
 	blobSidecarAllowed bool
 }
 
+// IsGoatTx returns an indicator if the args contains the goat tx fields.
+func (args *TransactionArgs) IsGoatTx() bool {
+	return args.Module != nil || args.Action != nil || args.GoatData != nil
+}
+
+// goatTx decodes the goat tx from the module, action and goatData fields.
+func (args *TransactionArgs) goatTx() (goattypes.Tx, error) {
+	if args.Module == nil || args.Action == nil || args.GoatData == nil {
+		return nil, errors.New("module, action and goatData are required for the goat tx")
+	}
+	if *args.Module > math.MaxUint8 || *args.Action > math.MaxUint8 {
+		return nil, fmt.Errorf("invalid goat tx module %d or action %d", *args.Module, *args.Action)
+	}
+	return goattypes.TxDecode(goattypes.Module(*args.Module), goattypes.Action(*args.Action), *args.GoatData)
+}
+
+// setGoatDefaults checks the goat tx fields, and fills the sender and the recipient
+// with the configured executor and system contract of the goat tx.
+func (args *TransactionArgs) setGoatDefaults(goat *params.GoatConfig) error {
+	if goat == nil {
+		return errors.New("goat tx on the non-goat chain")
+	}
+	inner, err := args.goatTx()
+	if err != nil {
+		return err
+	}
+	if args.Data != nil || args.Input != nil || args.AccessList != nil || args.BlobHashes != nil || args.Blobs != nil {
+		return errors.New("goat tx can't carry data, access list or blobs")
+	}
+	if args.Value != nil && args.Value.ToInt().Sign() != 0 {
+		return errors.New("goat tx can't carry value")
+	}
+	sender, contract := goat.SystemAddress(inner.Sender()), goat.SystemAddress(inner.Contract())
+	if args.From != nil && *args.From != sender {
+		return fmt.Errorf("goat tx sender mismatch: have %s want %s", args.From, sender)
+	}
+	if args.To != nil && *args.To != contract {
+		return fmt.Errorf("goat tx recipient mismatch: have %s want %s", args.To, contract)
+	}
+	args.From, args.To = &sender, &contract
+	return nil
+}
+
 // from retrieves the transaction sender address.
 func (args *TransactionArgs) from() common.Address {
 	if args.From == nil {
@@ -97,6 +147,9 @@ func (args *TransactionArgs) data() []byte {
 
 // setDefaults fills in default values for unspecified tx fields.
 func (args *TransactionArgs) setDefaults(ctx context.Context, b Backend, skipGasEstimation bool) error {
+	if args.IsGoatTx() {
+		return errors.New("goat tx can only be simulated")
+	}
 	if err := args.setBlobTxSidecar(ctx); err != nil {
 		return err
 	}
@@ -366,16 +419,21 @@ func (args *TransactionArgs) setBlobTxSidecar(ctx context.Context) error {
 
 // CallDefaults sanitizes the transaction arguments, often filling in zero values,
 // for the purpose of eth_call class of RPC methods.
-func (args *TransactionArgs) CallDefaults(globalGasCap uint64, baseFee *big.Int, chainID *big.Int) error {
+func (args *TransactionArgs) CallDefaults(globalGasCap uint64, baseFee *big.Int, config *params.ChainConfig) error {
+	if args.IsGoatTx() {
+		if err := args.setGoatDefaults(config.Goat); err != nil {
+			return err
+		}
+	}
 	// Reject invalid combinations of pre- and post-1559 fee styles
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
 		return errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	}
 	if args.ChainID == nil {
-		args.ChainID = (*hexutil.Big)(chainID)
+		args.ChainID = (*hexutil.Big)(config.ChainID)
 	} else {
-		if have := (*big.Int)(args.ChainID); have.Cmp(chainID) != 0 {
-			return fmt.Errorf("chainId does not match node's (have=%v, want=%v)", have, chainID)
+		if have := (*big.Int)(args.ChainID); have.Cmp(config.ChainID) != 0 {
+			return fmt.Errorf("chainId does not match node's (have=%v, want=%v)", have, config.ChainID)
 		}
 	}
 	if args.Gas == nil {
@@ -447,6 +505,28 @@ func (args *TransactionArgs) ToMessage(baseFee *big.Int, skipNonceCheck, skipEoA
 			}
 		}
 	}
+	if args.IsGoatTx() {
+		// the goat tx pays no gas, and the gas limit is replaced by the goat tx gas limit
+		tx := args.ToTransaction(types.GoatTxType)
+		return &core.Message{
+			From:             args.from(),
+			To:               args.To,
+			Value:            new(big.Int),
+			Nonce:            tx.Nonce(),
+			GasLimit:         uint64(*args.Gas),
+			GasPrice:         new(big.Int),
+			GasFeeCap:        new(big.Int),
+			GasTipCap:        new(big.Int),
+			Data:             tx.Data(),
+			SkipNonceChecks:  skipNonceCheck,
+			SkipFromEOACheck: skipEoACheck,
+			IsGoatTx:         true,
+			Deposit:          tx.Deposit(),
+			Reward:           tx.Reward(),
+			BitcoinHeader:    tx.BitcoinHeader(),
+			DepositProof:     tx.DepositProof(),
+		}
+	}
 	var accessList types.AccessList
 	if args.AccessList != nil {
 		accessList = *args.AccessList
@@ -472,6 +552,11 @@ func (args *TransactionArgs) ToMessage(baseFee *big.Int, skipNonceCheck, skipEoA
 // ToTransaction converts the arguments to a transaction.
 // This assumes that setDefaults has been called.
 func (args *TransactionArgs) ToTransaction(defaultType int) *types.Transaction {
+	if args.IsGoatTx() {
+		// the goat tx fields are checked by CallDefaults
+		inner, _ := args.goatTx()
+		return types.NewTx(types.NewGoatTx(goattypes.Module(*args.Module), goattypes.Action(*args.Action), uint64(*args.Nonce), inner))
+	}
 	usedType := types.LegacyTxType
 	switch {
 	case args.BlobHashes != nil || defaultType == types.BlobTxType:
@@ -31,6 +31,7 @@ var Modules = map[string]string{
 	"les":      LESJs,
 	"vflux":    VfluxJs,
 	"dev":      DevJs,
+	"goat":     GoatJs,
 }
 
 const CliqueJs = `
@@ -890,6 +891,68 @@ web3._extend({
 			call: 'dev_setFeeRecipient',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'addGoatTx',
+			call: 'dev_addGoatTx',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'deposit',
+			call: 'dev_deposit',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+	],
+});
+`
+
+const GoatJs = `
+web3._extend({
+	property: 'goat',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'getRequestsByBlock',
+			call: 'goat_getRequestsByBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getRequestsByRange',
+			call: 'goat_getRequestsByRange',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockFees',
+			call: 'goat_getBlockFees',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockFoundationShares',
+			call: 'goat_getBlockFoundationShares',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getWithdrawal',
+			call: 'goat_getWithdrawal',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getWithdrawalStatus',
+			call: 'goat_getWithdrawalStatus',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'btcHeader',
+			call: 'goat_btcHeader',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
 	],
 });
 `
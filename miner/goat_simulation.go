@@ -0,0 +1,59 @@
+package miner
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GoatTxResult is the execution result of a goat tx in the payload simulation
+type GoatTxResult struct {
+	Index int
+	Hash  common.Hash
+	Err   error // nil if the goat tx is applied, the failures are core.GoatTxError
+}
+
+// GoatPayloadSimulation is the result of the goat payload simulation
+type GoatPayloadSimulation struct {
+	Block         *types.Block
+	Fees          *big.Int // the total gas fees of the block
+	GasRevenue    *big.Int // the gas revenue request amount
+	FoundationTax *big.Int // the share of the gas fees paid to the goat foundation
+	GoatTxs       []*GoatTxResult
+}
+
+// SimulateGoatPayload builds the payload with the given goat txs and the pending
+// txs on top of a throwaway state. Unlike BuildPayload, the failed goat txs don't
+// abort the building but are reported in the results, and nothing is stored.
+// The failed goat txs are left out of the block, and the header extra commits to
+// the applied ones only, so the block is consistent with its own body.
+func (miner *Miner) SimulateGoatPayload(args *BuildPayloadArgs) (*GoatPayloadSimulation, error) {
+	if miner.chainConfig.Goat == nil {
+		return nil, errors.New("not a goat chain")
+	}
+	res := miner.generateWork(&generateParams{
+		timestamp:   args.Timestamp,
+		forceTime:   true,
+		parentHash:  args.Parent,
+		coinbase:    args.FeeRecipient,
+		random:      args.Random,
+		withdrawals: args.Withdrawals,
+		beaconRoot:  args.BeaconRoot,
+		txs:         args.GoatTxs,
+		simulate:    true,
+
+		consensusBlockHash: args.ConsensusBlockHash,
+	})
+	if res.err != nil {
+		return nil, res.err
+	}
+	return &GoatPayloadSimulation{
+		Block:         res.block,
+		Fees:          res.fees,
+		GasRevenue:    res.gasRevenue,
+		FoundationTax: new(big.Int).Sub(res.fees, res.gasRevenue),
+		GoatTxs:       res.goatResults,
+	}, nil
+}
@@ -43,7 +43,8 @@ type BuildPayloadArgs struct {
 	BeaconRoot   *common.Hash          // The provided beaconRoot (Cancun)
 	Version      engine.PayloadVersion // Versioning byte for payload id calculation.
 
-	GoatTxs types.Transactions
+	GoatTxs            types.Transactions
+	ConsensusBlockHash common.Hash // The consensus layer block hash committed in the goat header extra
 }
 
 // Id computes an 8-byte identifier by hashing the components of the payload arguments.
@@ -57,6 +58,9 @@ func (args *BuildPayloadArgs) Id() engine.PayloadID {
 	if args.BeaconRoot != nil {
 		hasher.Write(args.BeaconRoot[:])
 	}
+	if args.ConsensusBlockHash != (common.Hash{}) {
+		hasher.Write(args.ConsensusBlockHash[:])
+	}
 	var out engine.PayloadID
 	copy(out[:], hasher.Sum(nil)[:8])
 	out[0] = byte(args.Version)
@@ -192,6 +196,8 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 		beaconRoot:  args.BeaconRoot,
 		noTxs:       true,
 		txs:         args.GoatTxs,
+
+		consensusBlockHash: args.ConsensusBlockHash,
 	}
 	empty := miner.generateWork(emptyParams)
 	if empty.err != nil {
@@ -224,6 +230,8 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 			beaconRoot:  args.BeaconRoot,
 			noTxs:       false,
 			txs:         args.GoatTxs,
+
+			consensusBlockHash: args.ConsensusBlockHash,
 		}
 
 		for {
@@ -57,6 +57,8 @@ type environment struct {
 	receipts []*types.Receipt
 	sidecars []*types.BlobTxSidecar
 	blobs    int
+
+	goatResults []*GoatTxResult // the goat tx results, only collected in the simulation
 }
 
 const (
@@ -74,6 +76,9 @@ type newPayloadResult struct {
 	sidecars []*types.BlobTxSidecar // collected blobs of blob transactions
 	stateDB  *state.StateDB         // StateDB after executing the transactions
 	receipts []*types.Receipt       // Receipts collected during construction
+
+	gasRevenue  *big.Int        // the goat gas revenue after the foundation tax
+	goatResults []*GoatTxResult // the goat tx results of the simulation
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -88,7 +93,9 @@ type generateParams struct {
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
 
 	// goat txs from cosmos
-	txs types.Transactions
+	txs                types.Transactions
+	consensusBlockHash common.Hash // the consensus layer block hash committed in the header extra
+	simulate           bool        // Flag whether the failed goat txs are recorded instead of aborting
 }
 
 // generateWork generates a sealing block based on the given parameters.
@@ -116,32 +123,14 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 		allLogs = append(allLogs, r.Logs...)
 	}
 
-	var gasFees = new(big.Int)
+	var (
+		gasFees    = new(big.Int)
+		gasRevenue *big.Int
+	)
 	if miner.chainConfig.Goat != nil {
-		// calculate the reward
-		burntFees := new(big.Int)
-		if work.header.BaseFee != nil && work.header.GasUsed > 0 {
-			gasUsed := new(big.Int).SetUint64(work.header.GasUsed)
-			burntFees.Mul(work.header.BaseFee, gasUsed)
-		}
-
-		if work.header.ExcessBlobGas != nil && work.header.BlobGasUsed != nil && *work.header.BlobGasUsed > 0 {
-			blobBaseFee := eip4844.CalcBlobFee(*work.header.ExcessBlobGas)
-			blobUsed := new(big.Int).SetUint64(*work.header.BlobGasUsed)
-			burntFees.Add(burntFees, blobUsed.Mul(blobUsed, blobBaseFee))
-		}
-
-		gasFees.Add(gasFees, burntFees)
-		for i, tx := range work.txs {
-			gasUsed := work.receipts[i].GasUsed
-			if gasUsed == 0 { // It's the goat tx
-				continue
-			}
-			minerFee, _ := tx.EffectiveGasTip(work.header.BaseFee)
-			gasFees.Add(gasFees, new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), minerFee))
-		}
-		gasRevenue := core.ProcessGoatFoundationReward(work.state, gasFees)
-		requests, err := core.ProcessGoatRequests(gasRevenue, allLogs, miner.chainConfig)
+		gasFees = core.CalcGoatBlockFees(work.header, work.txs, work.receipts).Total()
+		gasRevenue = core.ProcessGoatFoundationReward(miner.chainConfig.Goat, work.header.Time, work.state, gasFees)
+		requests, err := core.ProcessGoatRequests(gasRevenue, allLogs, miner.chainConfig, work.header.Time)
 		if err != nil {
 			return &newPayloadResult{err: err}
 		}
@@ -171,6 +160,9 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 		sidecars: work.sidecars,
 		stateDB:  work.state,
 		receipts: work.receipts,
+
+		gasRevenue:  gasRevenue,
+		goatResults: work.goatResults,
 	}
 }
 
@@ -210,10 +202,11 @@ func (miner *Miner) prepareWork(genParams *generateParams) (*environment, error)
 
 	if miner.chainConfig.Goat != nil {
 		// Set the extra field.
-		header.Extra = make([]byte, 0, params.GoatHeaderExtraLengthV0)
-		header.Extra = append(header.Extra, uint8(len(genParams.txs)))
-		header.Extra = append(header.Extra,
-			types.DeriveSha(genParams.txs, trie.NewStackTrie(nil)).Bytes()...)
+		extra, err := miner.goatHeaderExtra(timestamp, genParams.txs, genParams.consensusBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		header.Extra = extra
 	} else {
 		// Set the extra field.
 		if len(miner.config.ExtraData) != 0 {
@@ -275,17 +268,38 @@ func (miner *Miner) prepareWork(genParams *generateParams) (*environment, error)
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(header.GasLimit)
 	}
-	for _, tx := range genParams.txs {
+	for i, tx := range genParams.txs {
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 		err = miner.commitTransaction(env, tx)
+		if genParams.simulate {
+			env.goatResults = append(env.goatResults, &GoatTxResult{Index: i, Hash: tx.Hash(), Err: err})
+			continue
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to commit goat tx: %s, nonce: %v, err: %w", tx.Hash(), tx.Nonce(), err)
 		}
 	}
+	// The failed goat txs are left out of the simulated block, re-encode the extra
+	// with the applied ones to keep the payload consistent with its body.
+	if genParams.simulate && len(env.txs) != len(genParams.txs) {
+		if header.Extra, err = miner.goatHeaderExtra(header.Time, env.txs, genParams.consensusBlockHash); err != nil {
+			return nil, err
+		}
+	}
 
 	return env, nil
 }
 
+// goatHeaderExtra encodes the goat header extra committing to the given goat txs
+func (miner *Miner) goatHeaderExtra(timestamp uint64, txs types.Transactions, consensusBlockHash common.Hash) ([]byte, error) {
+	return (&types.GoatHeaderExtra{
+		Version:            miner.chainConfig.Goat.HeaderExtraVersion(timestamp),
+		TxCount:            uint64(len(txs)),
+		TxRoot:             types.DeriveSha(txs, trie.NewStackTrie(nil)),
+		ConsensusBlockHash: consensusBlockHash,
+	}).Encode()
+}
+
 // makeEnv creates a new environment for the sealing block.
 func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase common.Address) (*environment, error) {
 	// Retrieve the parent state to execute on top.
@@ -393,6 +393,8 @@ func (c *ChainConfig) Description() string {
 		} else {
 			banner += "Consensus: Beacon (proof-of-stake), merged from Clique (proof-of-authority)\n"
 		}
+	case c.Goat != nil:
+		banner += fmt.Sprintf("Consensus: Goat %v\n", c.Goat)
 	default:
 		banner += "Consensus: unknown\n"
 	}
@@ -653,6 +655,9 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	if c.Goat != nil {
+		return c.Goat.CheckConfig()
+	}
 	return nil
 }
 
@@ -724,6 +729,9 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.VerkleTime, newcfg.VerkleTime, headTimestamp) {
 		return newTimestampCompatError("Verkle fork timestamp", c.VerkleTime, newcfg.VerkleTime)
 	}
+	if err := c.Goat.checkCompatible(newcfg.Goat, headTimestamp); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1,3 +1,388 @@
 package params
 
-type GoatConfig struct{}
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The default executor addresses of the goat txs
+var (
+	GoatRelayerExecutor = common.HexToAddress("0xBc10000000000000000000000000000000001000")
+	GoatLockingExecutor = common.HexToAddress("0xBC10000000000000000000000000000000001001")
+)
+
+// The default system contract addresses of the goat network
+var (
+	GoatFoundationContract = common.HexToAddress("0xBc10000000000000000000000000000000000002")
+	GoatBridgeContract     = common.HexToAddress("0xBC10000000000000000000000000000000000003")
+	GoatLockingContract    = common.HexToAddress("0xbC10000000000000000000000000000000000004")
+	GoatBitcoinContract    = common.HexToAddress("0xbc10000000000000000000000000000000000005")
+	GoatRelayerContract    = common.HexToAddress("0xBC10000000000000000000000000000000000006")
+)
+
+// GoatConfig is the chain parameters of the goat network.
+//
+// The nil parameters fall back to the defaults, and the parameters can be changed
+// at a given timestamp by the overrides.
+type GoatConfig struct {
+	GoatParams
+
+	Addresses *GoatAddresses `json:"addresses,omitempty"` // The system contract and executor addresses
+
+	HeaderExtraV1Time *uint64 `json:"headerExtraV1Time,omitempty"` // The header extra V1 switch time (nil = no fork, 0 = already on V1)
+	StrictEventsTime  *uint64 `json:"strictEventsTime,omitempty"`  // The strict system contract events switch time (nil = no fork, 0 = already strict)
+
+	LockingRequestsTime *uint64 `json:"lockingRequestsTime,omitempty"` // The locking requests switch time (nil = no fork, 0 = from genesis)
+
+	Bitcoin         *GoatBitcoinConfig    `json:"bitcoin,omitempty"`         // The bitcoin header chain tracking (nil = disabled)
+	BtcAddressCheck *GoatBtcAddressConfig `json:"btcAddressCheck,omitempty"` // The bitcoin address check of the withdrawals (nil = disabled)
+
+	Overrides []*GoatOverride `json:"overrides,omitempty"` // The fork-scheduled parameter changes, ordered by time
+}
+
+// GoatAddresses is the system contract and executor addresses of the goat network,
+// the zero addresses fall back to the defaults.
+type GoatAddresses struct {
+	RelayerExecutor common.Address `json:"relayerExecutor"`
+	LockingExecutor common.Address `json:"lockingExecutor"`
+
+	GoatFoundation common.Address `json:"goatFoundation"`
+	Bridge         common.Address `json:"bridge"`
+	Locking        common.Address `json:"locking"`
+	Bitcoin        common.Address `json:"bitcoin"`
+	Relayer        common.Address `json:"relayer"`
+}
+
+// GoatParams is the goat parameters which can be changed by the overrides.
+type GoatParams struct {
+	GfBasePoint         *uint64                `json:"gfBasePoint,omitempty"`         // The foundation tax of the gas fees in basis points
+	GfShares            []*GoatFoundationShare `json:"gfShares"`                      // The foundation tax recipients, it supersedes the gfBasePoint, the empty list means no tax
+	GoatTxLimitPerBlock *uint64                `json:"goatTxLimitPerBlock,omitempty"` // The max number of goat txs in a block
+	GoatTxGasLimit      *uint64                `json:"goatTxGasLimit,omitempty"`      // The gas limit of a goat tx
+
+	// The topics of the system contract events which carry no request, they're accepted
+	// since the strict events fork besides the built-in ones. The empty list clears the
+	// previous ones, so it's not omitted.
+	IgnoredEvents []common.Hash `json:"ignoredEvents"`
+}
+
+// GoatFoundationShare is a share of the gas fees taken by the foundation tax.
+type GoatFoundationShare struct {
+	Recipient *common.Address `json:"recipient"` // The recipient of the share (nil = burnt)
+	BasePoint uint64          `json:"basePoint"` // The share of the gas fees in basis points
+}
+
+func (s *GoatFoundationShare) equal(o *GoatFoundationShare) bool {
+	if s.BasePoint != o.BasePoint || (s.Recipient == nil) != (o.Recipient == nil) {
+		return false
+	}
+	return s.Recipient == nil || *s.Recipient == *o.Recipient
+}
+
+// GoatOverride changes the goat parameters since the given timestamp.
+type GoatOverride struct {
+	Time uint64 `json:"time"`
+	GoatParams
+}
+
+// String implements the stringer interface, returning the goat parameters at genesis.
+func (c *GoatConfig) String() string {
+	return fmt.Sprintf("goat(gfBasePoint: %d, goatTxLimitPerBlock: %d, goatTxGasLimit: %d, overrides: %d)",
+		c.GfBasePointAt(0), c.GoatTxLimitAt(0), c.GoatTxGasLimitAt(0), len(c.Overrides))
+}
+
+// GfBasePointAt returns the total foundation tax in basis points at the given timestamp.
+func (c *GoatConfig) GfBasePointAt(time uint64) uint64 {
+	var total uint64
+	for _, s := range c.GfSharesAt(time) {
+		total += s.BasePoint
+	}
+	return total
+}
+
+// GfSharesAt returns the foundation tax recipients at the given timestamp.
+//
+// The latest gfShares or gfBasePoint set before the timestamp wins, the gfBasePoint
+// is paid to the goat foundation contract as a single share.
+func (c *GoatConfig) GfSharesAt(time uint64) []*GoatFoundationShare {
+	var shares []*GoatFoundationShare
+	if c != nil {
+		params := []*GoatParams{&c.GoatParams}
+		for _, o := range c.Overrides {
+			if o.Time > time {
+				break
+			}
+			params = append(params, &o.GoatParams)
+		}
+		for _, p := range params {
+			if p.GfShares != nil {
+				shares = p.GfShares
+			} else if p.GfBasePoint != nil {
+				shares = nil
+			}
+		}
+	}
+	if shares != nil {
+		return shares
+	}
+	foundation := c.SystemAddresses().GoatFoundation
+	basePoint := c.value(time, GoatGfBasePoint, func(p *GoatParams) *uint64 { return p.GfBasePoint })
+	return []*GoatFoundationShare{{Recipient: &foundation, BasePoint: basePoint}}
+}
+
+// GoatTxLimitAt returns the max number of goat txs in a block at the given timestamp.
+func (c *GoatConfig) GoatTxLimitAt(time uint64) uint64 {
+	return c.value(time, GoatTxLimitPerBlock, func(p *GoatParams) *uint64 { return p.GoatTxLimitPerBlock })
+}
+
+// GoatTxGasLimitAt returns the gas limit of a goat tx at the given timestamp.
+func (c *GoatConfig) GoatTxGasLimitAt(time uint64) uint64 {
+	return c.value(time, GoatTxGasLimit, func(p *GoatParams) *uint64 { return p.GoatTxGasLimit })
+}
+
+// value returns the latest parameter which is set before the given timestamp
+func (c *GoatConfig) value(time uint64, def uint64, field func(*GoatParams) *uint64) uint64 {
+	res := def
+	if c == nil {
+		return res
+	}
+	if v := field(&c.GoatParams); v != nil {
+		res = *v
+	}
+	for _, o := range c.Overrides {
+		if o.Time > time {
+			break
+		}
+		if v := field(&o.GoatParams); v != nil {
+			res = *v
+		}
+	}
+	return res
+}
+
+// HeaderExtraVersion returns the version of the header extra at the given timestamp.
+func (c *GoatConfig) HeaderExtraVersion(time uint64) uint8 {
+	if c != nil && isTimestampForked(c.HeaderExtraV1Time, time) {
+		return 1
+	}
+	return 0
+}
+
+// IsStrictEvents returns whether the unknown or malformed system contract events
+// invalidate the block at the given timestamp.
+func (c *GoatConfig) IsStrictEvents(time uint64) bool {
+	return c != nil && isTimestampForked(c.StrictEventsTime, time)
+}
+
+// IsLockingRequests returns whether the locking contract events are decoded into the
+// goat requests at the given timestamp, they carry no request before the fork.
+func (c *GoatConfig) IsLockingRequests(time uint64) bool {
+	return c != nil && isTimestampForked(c.LockingRequestsTime, time)
+}
+
+// IgnoredEventsAt returns the topics of the system contract events which are
+// configured to carry no request at the given timestamp.
+func (c *GoatConfig) IgnoredEventsAt(time uint64) []common.Hash {
+	if c == nil {
+		return nil
+	}
+	res := c.IgnoredEvents
+	for _, o := range c.Overrides {
+		if o.Time > time {
+			break
+		}
+		if o.IgnoredEvents != nil {
+			res = o.IgnoredEvents
+		}
+	}
+	return res
+}
+
+// IsIgnoredEvent returns whether the event is configured to carry no request at
+// the given timestamp.
+func (c *GoatConfig) IsIgnoredEvent(time uint64, topics []common.Hash) bool {
+	return len(topics) != 0 && slices.Contains(c.IgnoredEventsAt(time), topics[0])
+}
+
+// SystemAddresses returns the system contract and executor addresses.
+func (c *GoatConfig) SystemAddresses() GoatAddresses {
+	res := GoatAddresses{
+		RelayerExecutor: GoatRelayerExecutor,
+		LockingExecutor: GoatLockingExecutor,
+		GoatFoundation:  GoatFoundationContract,
+		Bridge:          GoatBridgeContract,
+		Locking:         GoatLockingContract,
+		Bitcoin:         GoatBitcoinContract,
+		Relayer:         GoatRelayerContract,
+	}
+	if c == nil || c.Addresses == nil {
+		return res
+	}
+	for _, v := range []struct{ dst, src *common.Address }{
+		{&res.RelayerExecutor, &c.Addresses.RelayerExecutor},
+		{&res.LockingExecutor, &c.Addresses.LockingExecutor},
+		{&res.GoatFoundation, &c.Addresses.GoatFoundation},
+		{&res.Bridge, &c.Addresses.Bridge},
+		{&res.Locking, &c.Addresses.Locking},
+		{&res.Bitcoin, &c.Addresses.Bitcoin},
+		{&res.Relayer, &c.Addresses.Relayer},
+	} {
+		if *v.src != (common.Address{}) {
+			*v.dst = *v.src
+		}
+	}
+	return res
+}
+
+// SystemAddress maps the default system contract or executor address used by the
+// goat txs to the configured one, the other addresses are returned as is.
+func (c *GoatConfig) SystemAddress(addr common.Address) common.Address {
+	if c == nil || c.Addresses == nil {
+		return addr
+	}
+	addrs := c.SystemAddresses()
+	switch addr {
+	case GoatRelayerExecutor:
+		return addrs.RelayerExecutor
+	case GoatLockingExecutor:
+		return addrs.LockingExecutor
+	case GoatFoundationContract:
+		return addrs.GoatFoundation
+	case GoatBridgeContract:
+		return addrs.Bridge
+	case GoatLockingContract:
+		return addrs.Locking
+	case GoatBitcoinContract:
+		return addrs.Bitcoin
+	case GoatRelayerContract:
+		return addrs.Relayer
+	}
+	return addr
+}
+
+// CheckConfig checks that the overrides are ordered by time and the parameters are valid.
+func (c *GoatConfig) CheckConfig() error {
+	for i := 1; i < len(c.Overrides); i++ {
+		if prev, cur := c.Overrides[i-1].Time, c.Overrides[i].Time; prev >= cur {
+			return fmt.Errorf("unsupported goat override ordering: override %d at timestamp %d, but override %d at timestamp %d", i-1, prev, i, cur)
+		}
+	}
+	if err := c.GoatParams.check(c.HeaderExtraVersion(0)); err != nil {
+		return err
+	}
+	for _, o := range c.Overrides {
+		if err := o.GoatParams.check(c.HeaderExtraVersion(o.Time)); err != nil {
+			return fmt.Errorf("%w at timestamp %d", err, o.Time)
+		}
+	}
+	if c.Bitcoin != nil {
+		if err := c.Bitcoin.check(); err != nil {
+			return err
+		}
+	}
+	if c.BtcAddressCheck != nil {
+		return c.BtcAddressCheck.check()
+	}
+	return nil
+}
+
+// check checks the parameters which take effect with the given header extra version
+func (p *GoatParams) check(extraVersion uint8) error {
+	if p.GfBasePoint != nil && *p.GfBasePoint > GoatGfMaxBasePoint {
+		return fmt.Errorf("invalid goat gfBasePoint %d", *p.GfBasePoint)
+	}
+	if p.GfShares != nil {
+		if p.GfBasePoint != nil {
+			return errors.New("goat gfBasePoint and gfShares are both set")
+		}
+		var total uint64
+		for i, s := range p.GfShares {
+			if s == nil || s.BasePoint == 0 {
+				return fmt.Errorf("invalid goat gfShares %d", i)
+			}
+			for _, o := range p.GfShares[:i] {
+				if (s.Recipient == nil && o.Recipient == nil) || (s.Recipient != nil && o.Recipient != nil && *s.Recipient == *o.Recipient) {
+					return fmt.Errorf("duplicate goat gfShares recipient %d", i)
+				}
+			}
+			total += s.BasePoint
+		}
+		if total > GoatGfMaxBasePoint {
+			return fmt.Errorf("invalid goat gfShares total basis points %d", total)
+		}
+	}
+	// the goat tx length is encoded in one byte of the header extra V0, and in two
+	// bytes since V1
+	if p.GoatTxLimitPerBlock != nil {
+		limit := uint64(math.MaxUint16)
+		if extraVersion == 0 {
+			limit = math.MaxUint8
+		}
+		if *p.GoatTxLimitPerBlock > limit {
+			return fmt.Errorf("invalid goatTxLimitPerBlock %d for header extra v%d", *p.GoatTxLimitPerBlock, extraVersion)
+		}
+	}
+	return nil
+}
+
+// checkCompatible checks whether the goat parameters of the new config are the same
+// with the stored one until the given head timestamp.
+func (c *GoatConfig) checkCompatible(newcfg *GoatConfig, headTimestamp uint64) *ConfigCompatError {
+	if c == nil || newcfg == nil {
+		return nil
+	}
+	if isForkTimestampIncompatible(c.HeaderExtraV1Time, newcfg.HeaderExtraV1Time, headTimestamp) {
+		return newTimestampCompatError("Goat header extra V1 timestamp", c.HeaderExtraV1Time, newcfg.HeaderExtraV1Time)
+	}
+	if isForkTimestampIncompatible(c.StrictEventsTime, newcfg.StrictEventsTime, headTimestamp) {
+		return newTimestampCompatError("Goat strict events timestamp", c.StrictEventsTime, newcfg.StrictEventsTime)
+	}
+	if isForkTimestampIncompatible(c.LockingRequestsTime, newcfg.LockingRequestsTime, headTimestamp) {
+		return newTimestampCompatError("Goat locking requests timestamp", c.LockingRequestsTime, newcfg.LockingRequestsTime)
+	}
+	if isForkTimestampIncompatible(c.Bitcoin.time(), newcfg.Bitcoin.time(), headTimestamp) {
+		return newTimestampCompatError("Goat bitcoin header tracking timestamp", c.Bitcoin.time(), newcfg.Bitcoin.time())
+	}
+	if c.IsBitcoinHeaders(headTimestamp) && newcfg.IsBitcoinHeaders(headTimestamp) && *c.Bitcoin != *newcfg.Bitcoin {
+		return newTimestampCompatError("Goat bitcoin header tracking", c.Bitcoin.time(), newcfg.Bitcoin.time())
+	}
+	if isForkTimestampIncompatible(c.BtcAddressCheck.time(), newcfg.BtcAddressCheck.time(), headTimestamp) {
+		return newTimestampCompatError("Goat bitcoin address check timestamp", c.BtcAddressCheck.time(), newcfg.BtcAddressCheck.time())
+	}
+	if _, ok := c.BtcAddressNetwork(headTimestamp); ok && c.BtcAddressCheck.Network != newcfg.BtcAddressCheck.Network {
+		return newTimestampCompatError("Goat bitcoin address check network", c.BtcAddressCheck.time(), newcfg.BtcAddressCheck.time())
+	}
+	times := []uint64{0}
+	if c.StrictEventsTime != nil {
+		times = append(times, *c.StrictEventsTime)
+	}
+	for _, o := range c.Overrides {
+		times = append(times, o.Time)
+	}
+	for _, o := range newcfg.Overrides {
+		times = append(times, o.Time)
+	}
+	for _, time := range times {
+		if time > headTimestamp {
+			continue
+		}
+		if !slices.EqualFunc(c.GfSharesAt(time), newcfg.GfSharesAt(time), (*GoatFoundationShare).equal) ||
+			c.GoatTxLimitAt(time) != newcfg.GoatTxLimitAt(time) ||
+			c.GoatTxGasLimitAt(time) != newcfg.GoatTxGasLimitAt(time) {
+			return newTimestampCompatError("Goat parameter override", &time, &time)
+		}
+		// the ignored events only change the block validity since the strict events fork
+		if c.IsStrictEvents(time) && !slices.Equal(c.IgnoredEventsAt(time), newcfg.IgnoredEventsAt(time)) {
+			return newTimestampCompatError("Goat ignored events", &time, &time)
+		}
+	}
+	if c.SystemAddresses() != newcfg.SystemAddresses() {
+		zero := uint64(0)
+		return newTimestampCompatError("Goat system addresses", &zero, &zero)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package params
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Satoshi is the wei amount of a satoshi, the bridged BTC is the native token
+// of the goat chain with 18 decimals.
+const Satoshi = 1e10
+
+// GoatBitcoinConfig is the bitcoin header chain tracked by the execution layer, the
+// relayer appends the full bitcoin headers instead of the hashes since the given time,
+// and the headers are checked with the proof of work and the prev hash linkage.
+type GoatBitcoinConfig struct {
+	Time    uint64 `json:"time"`    // The switch time of the header tracking
+	Network string `json:"network"` // The bitcoin network, mainnet/testnet3/signet/regtest
+
+	// The checkpoint of the tracked chain, the first appended header should be the child of it
+	StartHeight uint64      `json:"startHeight"`
+	StartHash   common.Hash `json:"startHash"` // in the internal byte order
+
+	// The confirmations of the bitcoin block before its deposits are accepted, the block
+	// itself is counted (0 = 1)
+	Confirmations uint64 `json:"confirmations"`
+}
+
+// BitcoinPowParams is the proof of work parameters of a bitcoin network
+type BitcoinPowParams struct {
+	PowLimit         *big.Int
+	TargetTimespan   uint64 // the expected seconds of a retarget interval
+	TargetSpacing    uint64 // the expected seconds between two blocks
+	MinDifficulty    bool   // allow the min difficulty block if no block is mined in 2*TargetSpacing
+	NoRetargeting    bool   // the difficulty is never changed
+	RetargetInterval uint64 // the number of blocks in a retarget interval
+}
+
+func newPowLimit(hex string) *big.Int {
+	v, _ := new(big.Int).SetString(hex, 16)
+	return v
+}
+
+// BitcoinNetworks is the proof of work parameters of the supported bitcoin networks
+var BitcoinNetworks = map[string]*BitcoinPowParams{
+	"mainnet": {
+		PowLimit:         newPowLimit("00000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		TargetTimespan:   14 * 24 * 60 * 60,
+		TargetSpacing:    10 * 60,
+		RetargetInterval: 2016,
+	},
+	"testnet3": {
+		PowLimit:         newPowLimit("00000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		TargetTimespan:   14 * 24 * 60 * 60,
+		TargetSpacing:    10 * 60,
+		MinDifficulty:    true,
+		RetargetInterval: 2016,
+	},
+	"signet": {
+		PowLimit:         newPowLimit("00000377ae000000000000000000000000000000000000000000000000000000"),
+		TargetTimespan:   14 * 24 * 60 * 60,
+		TargetSpacing:    10 * 60,
+		RetargetInterval: 2016,
+	},
+	"regtest": {
+		PowLimit:         newPowLimit("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		TargetTimespan:   14 * 24 * 60 * 60,
+		TargetSpacing:    10 * 60,
+		MinDifficulty:    true,
+		NoRetargeting:    true,
+		RetargetInterval: 2016,
+	},
+}
+
+// IsBitcoinHeaders returns whether the bitcoin header chain is tracked at the given timestamp.
+func (c *GoatConfig) IsBitcoinHeaders(time uint64) bool {
+	return c != nil && c.Bitcoin != nil && c.Bitcoin.Time <= time
+}
+
+// BitcoinPowParams returns the proof of work parameters of the tracked bitcoin network
+func (c *GoatBitcoinConfig) BitcoinPowParams() *BitcoinPowParams {
+	return BitcoinNetworks[c.Network]
+}
+
+// MinConfirmations returns the confirmations of the bitcoin block before its deposits are accepted
+func (c *GoatBitcoinConfig) MinConfirmations() uint64 {
+	return max(c.Confirmations, 1)
+}
+
+func (c *GoatBitcoinConfig) check() error {
+	if c.BitcoinPowParams() == nil {
+		return fmt.Errorf("unsupported bitcoin network %q", c.Network)
+	}
+	if c.StartHash == (common.Hash{}) {
+		return fmt.Errorf("bitcoin start hash is not set")
+	}
+	return nil
+}
+
+func (c *GoatBitcoinConfig) time() *uint64 {
+	if c == nil {
+		return nil
+	}
+	return &c.Time
+}
+
+// GoatBtcAddressConfig is the bitcoin address check of the bridge withdrawals, the
+// withdrawals to the invalid addresses are flagged in the requests since the given time.
+type GoatBtcAddressConfig struct {
+	Time    uint64 `json:"time"`    // The switch time of the address check
+	Network string `json:"network"` // The bitcoin network, mainnet/testnet3/signet/regtest
+}
+
+// BtcAddressNetwork returns the bitcoin network to check the withdrawal addresses with,
+// the false is returned if the check is not enabled at the given timestamp.
+func (c *GoatConfig) BtcAddressNetwork(time uint64) (string, bool) {
+	if c == nil || c.BtcAddressCheck == nil || c.BtcAddressCheck.Time > time {
+		return "", false
+	}
+	return c.BtcAddressCheck.Network, true
+}
+
+func (c *GoatBtcAddressConfig) check() error {
+	if _, ok := BitcoinNetworks[c.Network]; !ok {
+		return fmt.Errorf("unsupported bitcoin address network %q", c.Network)
+	}
+	return nil
+}
+
+func (c *GoatBtcAddressConfig) time() *uint64 {
+	if c == nil {
+		return nil
+	}
+	return &c.Time
+}
@@ -0,0 +1,299 @@
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGoatConfig(t *testing.T) {
+	var config GoatConfig
+	input := `{
+		"gfBasePoint": 100,
+		"addresses": {"bridge": "0x1000000000000000000000000000000000000003"},
+		"overrides": [
+			{"time": 10, "goatTxLimitPerBlock": 50},
+			{"time": 20, "gfBasePoint": 300, "goatTxGasLimit": 1000000}
+		]
+	}`
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.CheckConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		time                 uint64
+		gfBasePoint, txLimit uint64
+		txGasLimit           uint64
+	}{
+		{0, 100, GoatTxLimitPerBlock, GoatTxGasLimit},
+		{9, 100, GoatTxLimitPerBlock, GoatTxGasLimit},
+		{10, 100, 50, GoatTxGasLimit},
+		{20, 300, 50, 1000000},
+		{100, 300, 50, 1000000},
+	}
+	for _, test := range tests {
+		if v := config.GfBasePointAt(test.time); v != test.gfBasePoint {
+			t.Errorf("time %d: gfBasePoint mismatch: have %d want %d", test.time, v, test.gfBasePoint)
+		}
+		if v := config.GoatTxLimitAt(test.time); v != test.txLimit {
+			t.Errorf("time %d: goatTxLimitPerBlock mismatch: have %d want %d", test.time, v, test.txLimit)
+		}
+		if v := config.GoatTxGasLimitAt(test.time); v != test.txGasLimit {
+			t.Errorf("time %d: goatTxGasLimit mismatch: have %d want %d", test.time, v, test.txGasLimit)
+		}
+	}
+
+	addrs := config.SystemAddresses()
+	if want := common.HexToAddress("0x1000000000000000000000000000000000000003"); addrs.Bridge != want {
+		t.Errorf("bridge address mismatch: have %s want %s", addrs.Bridge, want)
+	}
+	if addrs.Locking != GoatLockingContract {
+		t.Errorf("locking address mismatch: have %s want %s", addrs.Locking, GoatLockingContract)
+	}
+	if v := config.SystemAddress(GoatBridgeContract); v != addrs.Bridge {
+		t.Errorf("bridge address is not redirected: %s", v)
+	}
+
+	var nilConfig *GoatConfig
+	if v := nilConfig.GfBasePointAt(0); v != GoatGfBasePoint {
+		t.Errorf("nil config gfBasePoint mismatch: have %d want %d", v, GoatGfBasePoint)
+	}
+}
+
+func TestGoatConfigCheck(t *testing.T) {
+	var (
+		ten   = uint64(10)
+		large = uint64(GoatGfMaxBasePoint + 1)
+	)
+	unordered := &GoatConfig{Overrides: []*GoatOverride{{Time: 20}, {Time: 10}}}
+	if err := unordered.CheckConfig(); err == nil {
+		t.Error("expect error for unordered overrides")
+	}
+	invalid := &GoatConfig{Overrides: []*GoatOverride{{Time: 20, GoatParams: GoatParams{GfBasePoint: &large}}}}
+	if err := invalid.CheckConfig(); err == nil {
+		t.Error("expect error for invalid gfBasePoint")
+	}
+	// the goat tx count is encoded in one byte before the header extra V1
+	var (
+		v1Time = uint64(30)
+		limit  = uint64(256)
+	)
+	for i, test := range []struct {
+		config *GoatConfig
+		valid  bool
+	}{
+		{&GoatConfig{GoatParams: GoatParams{GoatTxLimitPerBlock: &limit}}, false},
+		{&GoatConfig{Overrides: []*GoatOverride{{Time: 20, GoatParams: GoatParams{GoatTxLimitPerBlock: &limit}}}}, false},
+		{&GoatConfig{HeaderExtraV1Time: &v1Time, Overrides: []*GoatOverride{{Time: 20, GoatParams: GoatParams{GoatTxLimitPerBlock: &limit}}}}, false},
+		{&GoatConfig{HeaderExtraV1Time: &v1Time, Overrides: []*GoatOverride{{Time: 30, GoatParams: GoatParams{GoatTxLimitPerBlock: &limit}}}}, true},
+		{&GoatConfig{HeaderExtraV1Time: new(uint64), GoatParams: GoatParams{GoatTxLimitPerBlock: &limit}}, true},
+	} {
+		if err := test.config.CheckConfig(); (err == nil) != test.valid {
+			t.Errorf("goatTxLimitPerBlock %d: valid mismatch: have %v want %v", i, err, test.valid)
+		}
+	}
+	unknown := &GoatConfig{Bitcoin: &GoatBitcoinConfig{Network: "unknown", StartHash: common.Hash{0x1}}}
+	if err := unknown.CheckConfig(); err == nil {
+		t.Error("expect error for unknown bitcoin network")
+	}
+
+	stored := &ChainConfig{ChainID: big.NewInt(1), Goat: &GoatConfig{}}
+	scheduled := &ChainConfig{ChainID: big.NewInt(1), Goat: &GoatConfig{
+		Overrides: []*GoatOverride{{Time: 100, GoatParams: GoatParams{GfBasePoint: &ten}}},
+	}}
+	if err := stored.CheckCompatible(scheduled, 10, 50); err != nil {
+		t.Errorf("expect compatible override in the future: %v", err)
+	}
+	err := stored.CheckCompatible(scheduled, 10, 150)
+	if err == nil {
+		t.Fatal("expect incompatible override in the past")
+	}
+	if err.RewindToTime != 99 {
+		t.Errorf("rewind time mismatch: have %d want 99", err.RewindToTime)
+	}
+}
+
+func TestGoatStrictEvents(t *testing.T) {
+	var (
+		ten    = uint64(10)
+		config = &GoatConfig{
+			StrictEventsTime: &ten,
+			GoatParams:       GoatParams{IgnoredEvents: []common.Hash{{0x1}}},
+			Overrides: []*GoatOverride{
+				{Time: 20, GoatParams: GoatParams{IgnoredEvents: []common.Hash{{0x2}}}},
+				{Time: 30, GoatParams: GoatParams{GfBasePoint: &ten}},
+				{Time: 40, GoatParams: GoatParams{IgnoredEvents: []common.Hash{}}},
+			},
+		}
+	)
+	if config.IsStrictEvents(9) || !config.IsStrictEvents(10) {
+		t.Error("strict events fork mismatch")
+	}
+	if !config.IsIgnoredEvent(0, []common.Hash{{0x1}, {0x2}}) || config.IsIgnoredEvent(0, []common.Hash{{0x2}, {0x1}}) || config.IsIgnoredEvent(0, nil) {
+		t.Error("ignored events mismatch")
+	}
+	for _, test := range []struct {
+		time    uint64
+		topic   common.Hash
+		ignored bool
+	}{
+		{19, common.Hash{0x1}, true},
+		{20, common.Hash{0x1}, false},
+		{20, common.Hash{0x2}, true},
+		{35, common.Hash{0x2}, true},
+		{40, common.Hash{0x2}, false},
+	} {
+		if config.IsIgnoredEvent(test.time, []common.Hash{test.topic}) != test.ignored {
+			t.Errorf("ignored event %x at %d mismatch, want %v", test.topic, test.time, test.ignored)
+		}
+	}
+	// the empty list survives the database round trip
+	enc, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec GoatConfig
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal(err)
+	}
+	if dec.IsIgnoredEvent(40, []common.Hash{{0x2}}) {
+		t.Error("ignored events are not cleared after the round trip")
+	}
+
+	var nilConfig *GoatConfig
+	if nilConfig.IsStrictEvents(10) || nilConfig.IsIgnoredEvent(10, []common.Hash{{0x1}}) {
+		t.Error("nil config should not be strict")
+	}
+
+	stored := &ChainConfig{ChainID: big.NewInt(1), Goat: &GoatConfig{}}
+	scheduled := &ChainConfig{ChainID: big.NewInt(1), Goat: config}
+	if err := stored.CheckCompatible(scheduled, 0, 5); err != nil {
+		t.Errorf("expect compatible strict events fork in the future: %v", err)
+	}
+	if err := stored.CheckCompatible(scheduled, 0, 20); err == nil {
+		t.Error("expect incompatible strict events fork in the past")
+	}
+
+	// the ignored events change the block validity since the strict events fork
+	changed := *config
+	changed.Overrides = []*GoatOverride{{Time: 25, GoatParams: GoatParams{IgnoredEvents: []common.Hash{{0x3}}}}}
+	for _, test := range []struct {
+		head   uint64
+		rewind uint64
+	}{
+		{19, 0},
+		{25, 19},
+	} {
+		err := (&ChainConfig{ChainID: big.NewInt(1), Goat: config}).CheckCompatible(&ChainConfig{ChainID: big.NewInt(1), Goat: &changed}, 0, test.head)
+		if test.rewind == 0 {
+			if err != nil {
+				t.Errorf("head %d: expect compatible ignored events: %v", test.head, err)
+			}
+			continue
+		}
+		if err == nil || err.RewindToTime != test.rewind {
+			t.Errorf("head %d: expect incompatible ignored events rewinding to %d: %v", test.head, test.rewind, err)
+		}
+	}
+	before := &GoatConfig{StrictEventsTime: &ten, GoatParams: GoatParams{IgnoredEvents: []common.Hash{{0x1}}}}
+	after := &GoatConfig{StrictEventsTime: &ten}
+	if err := (&ChainConfig{ChainID: big.NewInt(1), Goat: before}).CheckCompatible(&ChainConfig{ChainID: big.NewInt(1), Goat: after}, 0, 9); err != nil {
+		t.Errorf("expect compatible ignored events before the strict events fork: %v", err)
+	}
+}
+
+func TestGoatLockingRequests(t *testing.T) {
+	ten := uint64(10)
+	config := &GoatConfig{LockingRequestsTime: &ten}
+	if config.IsLockingRequests(9) || !config.IsLockingRequests(10) {
+		t.Error("locking requests fork mismatch")
+	}
+	var nilConfig *GoatConfig
+	if nilConfig.IsLockingRequests(10) || new(GoatConfig).IsLockingRequests(10) {
+		t.Error("locking requests without the fork")
+	}
+
+	stored := &ChainConfig{ChainID: big.NewInt(1), Goat: &GoatConfig{}}
+	scheduled := &ChainConfig{ChainID: big.NewInt(1), Goat: config}
+	if err := stored.CheckCompatible(scheduled, 0, 5); err != nil {
+		t.Errorf("expect compatible locking requests fork in the future: %v", err)
+	}
+	if err := stored.CheckCompatible(scheduled, 0, 20); err == nil {
+		t.Error("expect incompatible locking requests fork in the past")
+	}
+}
+
+func TestGoatFoundationShares(t *testing.T) {
+	var config GoatConfig
+	input := `{
+		"gfBasePoint": 100,
+		"overrides": [
+			{"time": 10, "gfShares": [{"recipient": "0x00000000000000000000000000000000000000aa", "basePoint": 300}, {"recipient": null, "basePoint": 50}]},
+			{"time": 20, "gfBasePoint": 150},
+			{"time": 30, "gfShares": []}
+		]
+	}`
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.CheckConfig(); err != nil {
+		t.Fatal(err)
+	}
+	// the config is stored in the database as JSON, the empty gfShares is kept
+	enc, err := json.Marshal(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded GoatConfig
+	if err := json.Unmarshal(enc, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		foundation = GoatFoundationContract
+		treasury   = common.HexToAddress("0xaa")
+	)
+	tests := []struct {
+		time   uint64
+		shares []*GoatFoundationShare
+		total  uint64
+	}{
+		{0, []*GoatFoundationShare{{Recipient: &foundation, BasePoint: 100}}, 100},
+		{10, []*GoatFoundationShare{{Recipient: &treasury, BasePoint: 300}, {BasePoint: 50}}, 350},
+		{20, []*GoatFoundationShare{{Recipient: &foundation, BasePoint: 150}}, 150},
+		{30, []*GoatFoundationShare{}, 0},
+	}
+	for _, test := range tests {
+		for name, c := range map[string]*GoatConfig{"config": &config, "decoded": &decoded} {
+			if shares := c.GfSharesAt(test.time); !slices.EqualFunc(shares, test.shares, (*GoatFoundationShare).equal) {
+				t.Errorf("%s time %d: gfShares mismatch: have %v want %v", name, test.time, shares, test.shares)
+			}
+			if v := c.GfBasePointAt(test.time); v != test.total {
+				t.Errorf("%s time %d: gfBasePoint mismatch: have %d want %d", name, test.time, v, test.total)
+			}
+		}
+	}
+
+	invalid := []GoatParams{
+		{GfBasePoint: new(uint64), GfShares: []*GoatFoundationShare{{BasePoint: 1}}},
+		{GfShares: []*GoatFoundationShare{{BasePoint: 0}}},
+		{GfShares: []*GoatFoundationShare{{BasePoint: 1}, {BasePoint: 1}}},
+		{GfShares: []*GoatFoundationShare{{Recipient: &treasury, BasePoint: 1}, {Recipient: &treasury, BasePoint: 2}}},
+		{GfShares: []*GoatFoundationShare{{Recipient: &treasury, BasePoint: GoatGfMaxBasePoint}, {BasePoint: 1}}},
+	}
+	for i, p := range invalid {
+		if err := (&GoatConfig{GoatParams: p}).CheckConfig(); err == nil {
+			t.Errorf("invalid %d: expect error", i)
+		}
+	}
+
+	stored := &ChainConfig{ChainID: big.NewInt(1), Goat: &GoatConfig{}}
+	if err := stored.CheckCompatible(&ChainConfig{ChainID: big.NewInt(1), Goat: &config}, 0, 15); err == nil {
+		t.Error("expect incompatible gfShares in the past")
+	}
+}
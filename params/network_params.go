@@ -28,6 +28,14 @@ const (
 	// considered probably final and its rotated bits are calculated.
 	BloomConfirms = 256
 
+	// GoatWithdrawalBlocks is the number of blocks a single goat withdrawal index
+	// section contains. The lookups scan the blocks after the last section directly.
+	GoatWithdrawalBlocks uint64 = 256
+
+	// GoatWithdrawalConfirms is the number of confirmation blocks before a goat
+	// withdrawal index section is considered probably final and gets indexed.
+	GoatWithdrawalConfirms = 64
+
 	// FullImmutabilityThreshold is the number of blocks after which a chain segment is
 	// considered immutable (i.e. soft finality). It is used by the downloader as a
 	// hard limit against deep ancestors, by the blockchain against deep reorgs, by
@@ -202,7 +202,13 @@ var (
 )
 
 const (
-	GoatHeaderExtraLengthV0 = 33
-	GoatTxLimitPerBlock     = 100
-	GoatTxGasLimit          = 30_000_000 // the goat tx gas limit, it's the same with eth system tx
+	GoatHeaderExtraLengthV0  = 33
+	GoatHeaderExtraLengthV1  = 67
+	GoatHeaderExtraMaxLength = GoatHeaderExtraLengthV1 // the max length of the supported goat header extra versions
+
+	// The defaults of the goat parameters, see GoatConfig
+	GoatTxLimitPerBlock = 100
+	GoatTxGasLimit      = 30_000_000 // the goat tx gas limit, it's the same with eth system tx
+	GoatGfBasePoint     = 200        // the foundation tax is 2% of the gas fees
+	GoatGfMaxBasePoint  = 1e4
 )